@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client는 write-server/read-server의 HTTP 부하 제어 API를 호출합니다.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewClient(baseURL string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// GetConfig는 GET /load/config 결과를 반환합니다.
+// 서버마다 설정 필드가 다르므로(qps/tps 등) map으로 다룹니다.
+func (c *Client) GetConfig() (map[string]interface{}, bool, error) {
+	var resp struct {
+		Config  map[string]interface{} `json:"config"`
+		Running bool                   `json:"running"`
+	}
+	if err := c.do(http.MethodGet, "/load/config", nil, &resp); err != nil {
+		return nil, false, err
+	}
+	return resp.Config, resp.Running, nil
+}
+
+func (c *Client) UpdateConfig(config map[string]interface{}) error {
+	return c.do(http.MethodPost, "/load/config", config, nil)
+}
+
+func (c *Client) Start() error {
+	return c.do(http.MethodPost, "/load/start", nil, nil)
+}
+
+// Stop은 부하 생성을 중지하고 응답에 포함된 최종 메트릭을 반환합니다.
+func (c *Client) Stop() (map[string]interface{}, error) {
+	var resp struct {
+		Metrics map[string]interface{} `json:"metrics"`
+	}
+	if err := c.do(http.MethodPost, "/load/stop", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Metrics, nil
+}
+
+// Status는 GET /load/status 응답입니다.
+type Status struct {
+	Running bool                   `json:"running"`
+	Config  map[string]interface{} `json:"config"`
+	Metrics map[string]interface{} `json:"metrics"`
+
+	// max_error_rate를 지정한 실행의 조기 중단 상태
+	FailFast *FailFast `json:"fail_fast,omitempty"`
+}
+
+// FailFast는 /load/status의 fail_fast 중 loadctl이 사용하는 필드입니다.
+type FailFast struct {
+	Aborted bool   `json:"aborted"`
+	Reason  string `json:"reason"`
+}
+
+func (c *Client) GetStatus() (*Status, error) {
+	var status Status
+	if err := c.do(http.MethodGet, "/load/status", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func (c *Client) GetMetrics() (map[string]interface{}, error) {
+	var metrics map[string]interface{}
+	if err := c.do(http.MethodGet, "/metrics", nil, &metrics); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func (c *Client) do(method, path string, body interface{}, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, errorMessage(data))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("%s %s: invalid response: %w", method, path, err)
+		}
+	}
+	return nil
+}
+
+// errorMessage는 서버의 JSON 에러 본문({"code", "message", "request_id"})에서 메시지를 꺼냅니다.
+// JSON이 아니면(프록시 에러 페이지 등) 본문을 그대로 반환합니다.
+func errorMessage(data []byte) string {
+	var e struct {
+		Message   string `json:"message"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal(data, &e); err != nil || e.Message == "" {
+		return strings.TrimSpace(string(data))
+	}
+	if e.RequestID != "" {
+		return fmt.Sprintf("%s (request_id=%s)", e.Message, e.RequestID)
+	}
+	return e.Message
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ANSI 이스케이프 시퀀스 (외부 의존성 없이 화면을 다시 그림)
+const (
+	ansiAltScreenOn  = "\x1b[?1049h" // 대체 화면으로 전환 (종료 시 원래 화면 복원)
+	ansiAltScreenOff = "\x1b[?1049l"
+	ansiHideCursor   = "\x1b[?25l"
+	ansiShowCursor   = "\x1b[?25h"
+	ansiHome         = "\x1b[H"  // 커서를 왼쪽 위로
+	ansiClearBelow   = "\x1b[J"  // 커서 아래를 지움 (이전 프레임이 더 길었을 때)
+	ansiClearLine    = "\x1b[K"  // 줄 끝까지 지움
+	ansiBold         = "\x1b[1m" // 굵게
+	ansiRed          = "\x1b[31m"
+	ansiReset        = "\x1b[0m"
+)
+
+// sparkWidth는 스파크라인에 보여줄 최근 폴링 횟수입니다.
+const sparkWidth = 60
+
+// sparkBlocks는 낮은 값부터 높은 값 순서의 스파크라인 문자입니다.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// dashboard는 -tui 모드에서 폴링한 상태를 터미널 한 화면에 다시 그립니다.
+// 대체 화면을 쓰므로 종료하면 실행 전 터미널 내용이 그대로 돌아오고, 최종 메트릭은 평소처럼 출력됩니다.
+type dashboard struct {
+	out      io.Writer
+	server   string
+	duration time.Duration
+	opened   bool
+
+	// 직전 폴링 값 (구간 처리율 계산용)
+	lastTotal   float64
+	lastElapsed float64
+
+	rates []float64 // 폴링 구간별 처리율 (최근 sparkWidth개)
+	p95s  []float64 // 폴링 시점의 p95 (ms)
+}
+
+func newDashboard(out io.Writer, server string, duration time.Duration) *dashboard {
+	return &dashboard{out: out, server: server, duration: duration}
+}
+
+// isTerminal은 f가 터미널(문자 장치)인지 확인합니다. 파일이나 파이프로 리다이렉트했다면 false입니다.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// open은 대체 화면으로 전환하고 커서를 숨깁니다.
+func (d *dashboard) open() {
+	fmt.Fprint(d.out, ansiAltScreenOn+ansiHideCursor)
+	d.opened = true
+}
+
+// close는 커서와 원래 화면을 복원합니다. nil이거나 여러 번 호출해도 안전합니다.
+func (d *dashboard) close() {
+	if d == nil || !d.opened {
+		return
+	}
+	fmt.Fprint(d.out, ansiShowCursor+ansiAltScreenOff)
+	d.opened = false
+}
+
+// render는 상태 한 번을 반영해 화면 전체를 다시 그립니다.
+func (d *dashboard) render(status *Status) {
+	metrics := status.Metrics
+	rateKey := "QPS"
+	if _, ok := metrics["tps"]; ok {
+		rateKey = "TPS"
+	}
+
+	// 구간 처리율: 직전 폴링 이후 요청 수 / 경과 시간 (첫 폴링이나 Reset 직후에는 평균값 사용)
+	total := toFloat(metrics["total_requests"])
+	elapsed := toFloat(metrics["elapsed_seconds"])
+	avgRate := toFloat(metrics[strings.ToLower(rateKey)])
+	rate := avgRate
+	if elapsed > d.lastElapsed && total >= d.lastTotal && d.lastElapsed > 0 {
+		rate = (total - d.lastTotal) / (elapsed - d.lastElapsed)
+	}
+	d.lastTotal, d.lastElapsed = total, elapsed
+	d.rates = appendWindow(d.rates, rate)
+	d.p95s = appendWindow(d.p95s, toFloat(metrics["p95_latency_ms"]))
+
+	failed := toFloat(metrics["failed_requests"])
+	timeouts := toFloat(metrics["timeout_requests"])
+	connErrors := toFloat(metrics["connection_errors"])
+	errorRate := 0.0
+	if total > 0 {
+		errorRate = (failed + timeouts + connErrors) / total
+	}
+
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		fmt.Fprintf(&b, format, args...)
+		b.WriteString(ansiClearLine + "\n")
+	}
+
+	state := "running"
+	if !status.Running {
+		state = "finished"
+	}
+	line("%sloadctl%s  %s  [%s]", ansiBold, ansiReset, d.server, state)
+	line("elapsed  %s / %s", (time.Duration(elapsed * float64(time.Second))).Truncate(time.Second), durationLabel(d.duration))
+	line("")
+
+	line("%s%s%s", ansiBold, rateKey, ansiReset)
+	target := toFloat(metrics["target_rate"])
+	if target > 0 {
+		line("  now %10.1f   avg %10.1f   target %10.1f (%.0f%%)", rate, avgRate, target, toFloat(metrics["achieved_ratio"])*100)
+		line("  limiter wait %.0f%% of worker time", toFloat(metrics["limiter_wait_ratio"])*100)
+	} else {
+		line("  now %10.1f   avg %10.1f", rate, avgRate)
+	}
+	line("  %-4s %s  max %.1f", "now", sparkline(d.rates), maxOf(d.rates))
+	line("")
+
+	line("%slatency (ms)%s", ansiBold, ansiReset)
+	line("  avg %8.2f   p50 %8.2f   p95 %8.2f   p99 %8.2f",
+		toFloat(metrics["avg_latency_ms"]), toFloat(metrics["p50_latency_ms"]),
+		toFloat(metrics["p95_latency_ms"]), toFloat(metrics["p99_latency_ms"]))
+	line("  %-4s %s  max %.2f", "p95", sparkline(d.p95s), maxOf(d.p95s))
+	line("")
+
+	line("%srequests%s", ansiBold, ansiReset)
+	errColor, errReset := "", ""
+	if failed+timeouts+connErrors > 0 {
+		errColor, errReset = ansiRed, ansiReset
+	}
+	line("  total %d   success %d", int64(total), int64(toFloat(metrics["success_requests"])))
+	line("  %sfailed %d   timeouts %d   connection errors %d   error rate %.2f%%%s",
+		errColor, int64(failed), int64(timeouts), int64(connErrors), errorRate*100, errReset)
+	if pool, ok := metrics["pool"].(map[string]interface{}); ok {
+		line("  pool in_use %d / open %d   wait_count %d",
+			int64(toFloat(pool["in_use"])), int64(toFloat(pool["open_connections"])), int64(toFloat(pool["wait_count"])))
+	}
+	line("")
+	line("Ctrl+C to stop")
+
+	fmt.Fprint(d.out, ansiHome+b.String()+ansiClearBelow)
+}
+
+// appendWindow는 values 끝에 v를 붙이고 최근 sparkWidth개만 남깁니다.
+func appendWindow(values []float64, v float64) []float64 {
+	values = append(values, v)
+	if len(values) > sparkWidth {
+		values = values[len(values)-sparkWidth:]
+	}
+	return values
+}
+
+// sparkline은 values를 0~최댓값 구간으로 나눠 블록 문자로 그립니다.
+func sparkline(values []float64) string {
+	max := maxOf(values)
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if max > 0 {
+			i = int(v / max * float64(len(sparkBlocks)-1))
+		}
+		if i < 0 {
+			i = 0
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+	// 폴링이 쌓이기 전에도 폭이 일정하도록 빈칸으로 채움
+	b.WriteString(strings.Repeat(" ", sparkWidth-len(values)))
+	return b.String()
+}
+
+func maxOf(values []float64) float64 {
+	max := 0.0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
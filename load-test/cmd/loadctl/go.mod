@@ -0,0 +1,3 @@
+module loadctl
+
+go 1.21
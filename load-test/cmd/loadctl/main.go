@@ -0,0 +1,287 @@
+// loadctl은 write-server/read-server의 HTTP API를 이용해
+// 설정 변경 → 시작 → 상태 폴링 → 최종 메트릭 조회까지 한 번에 실행하는 CLI입니다.
+//
+//	go run . -server http://localhost:8081 -qps 2000 -workers 20 -duration 1m
+//	go run . -server http://localhost:8080 -tps 5000 -batch-size 100 -duration 5m -report run.json
+//
+// -tui를 지정하면 진행 상황을 줄 단위 로그 대신 매 폴링마다 다시 그리는 터미널 대시보드로 보여줍니다.
+//
+// -max-error-rate를 지정하면 서버가 에러율 초과로 실행을 중단했을 때 0이 아닌 코드로 종료하므로 CI 게이트로 사용할 수 있습니다.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+)
+
+type options struct {
+	server       string
+	qps          int
+	tps          int
+	workers      int
+	batchSize    int
+	duration     time.Duration
+	isolation    string
+	syncCommit   string
+	maxErrorRate float64
+	pollInterval time.Duration
+	timeout      time.Duration
+	reportPath   string
+	tui          bool
+}
+
+// Report는 -report 파일에 기록되는 실행 결과입니다.
+type Report struct {
+	Server     string                 `json:"server"`
+	StartedAt  time.Time              `json:"started_at"`
+	FinishedAt time.Time              `json:"finished_at"`
+	Config     map[string]interface{} `json:"config"`
+	Metrics    map[string]interface{} `json:"metrics"`
+	Aborted    string                 `json:"aborted,omitempty"` // 에러율 초과로 중단된 경우 사유
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.server, "server", "http://localhost:8081", "서버 URL (write: 8080, read: 8081)")
+	flag.IntVar(&opts.qps, "qps", 0, "목표 QPS (read-server, 0 = 무제한)")
+	flag.IntVar(&opts.tps, "tps", 0, "목표 TPS (write-server, 0 = 무제한)")
+	flag.IntVar(&opts.workers, "workers", 0, "동시 워커 수")
+	flag.IntVar(&opts.batchSize, "batch-size", 0, "배치 INSERT 크기 (write-server)")
+	flag.DurationVar(&opts.duration, "duration", 0, "테스트 지속 시간 (0 = Ctrl+C까지)")
+	flag.StringVar(&opts.isolation, "isolation", "", "격리 수준 (READ COMMITTED, REPEATABLE READ, SERIALIZABLE)")
+	flag.StringVar(&opts.syncCommit, "synchronous-commit", "", "트랜잭션마다 설정할 synchronous_commit (on, off, write-server)")
+	flag.Float64Var(&opts.maxErrorRate, "max-error-rate", 0, "에러율(0~1)이 이 값을 넘으면 서버가 실행을 중단하고 loadctl은 실패로 종료 (0 = 사용 안 함)")
+	flag.DurationVar(&opts.pollInterval, "poll", time.Second, "상태 폴링 간격")
+	flag.DurationVar(&opts.timeout, "timeout", 10*time.Second, "HTTP 요청 타임아웃")
+	flag.StringVar(&opts.reportPath, "report", "", "결과를 JSON 파일로 저장할 경로")
+	flag.BoolVar(&opts.tui, "tui", false, "진행 상황을 실시간 터미널 대시보드로 표시 (터미널이 아니면 줄 단위 출력)")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		log.Fatalf("loadctl: %v", err)
+	}
+}
+
+func run(opts options) error {
+	client := NewClient(opts.server, opts.timeout)
+
+	// 1. 현재 설정을 가져와 플래그로 지정한 값만 덮어씀
+	config, running, err := client.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+	if running {
+		return fmt.Errorf("load generator is already running on %s", opts.server)
+	}
+
+	if err := applyFlags(config, opts); err != nil {
+		return err
+	}
+
+	if err := client.UpdateConfig(config); err != nil {
+		return fmt.Errorf("failed to update config: %w", err)
+	}
+
+	// 2. 부하 시작
+	if err := client.Start(); err != nil {
+		return fmt.Errorf("failed to start: %w", err)
+	}
+	startedAt := time.Now()
+	fmt.Printf("▶ started on %s (duration: %s)\n", opts.server, durationLabel(time.Duration(toFloat(config["duration"]))))
+
+	// 3. 완료될 때까지 상태 폴링 (Ctrl+C 시 중지 요청)
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	// 기본은 폴링마다 한 줄씩 출력, -tui면 대시보드를 다시 그림 (종료 시 터미널 복원)
+	progress := func(status *Status) { printProgress(status.Metrics) }
+	var dash *dashboard
+	if opts.tui {
+		if isTerminal(os.Stdout) {
+			dash = newDashboard(os.Stdout, opts.server, time.Duration(toFloat(config["duration"])))
+			dash.open()
+			defer dash.close()
+			progress = dash.render
+		} else {
+			log.Printf("loadctl: stdout is not a terminal, ignoring -tui")
+		}
+	}
+
+	metrics, failFast, err := waitForCompletion(client, opts.pollInterval, sigCh, progress)
+	// 대시보드는 대체 화면이므로 최종 메트릭을 출력하기 전에 원래 화면으로 돌아감
+	dash.close()
+	if err != nil {
+		return err
+	}
+	finishedAt := time.Now()
+
+	// 4. 최종 메트릭 조회 및 출력 (중지 요청 응답에 이미 포함되어 있으면 그대로 사용)
+	if metrics == nil {
+		metrics, err = client.GetMetrics()
+		if err != nil {
+			return fmt.Errorf("failed to get metrics: %w", err)
+		}
+	}
+
+	fmt.Println()
+	printMetrics(metrics)
+
+	if opts.reportPath != "" {
+		report := Report{
+			Server:     opts.server,
+			StartedAt:  startedAt,
+			FinishedAt: finishedAt,
+			Config:     config,
+			Metrics:    metrics,
+		}
+		if failFast != nil && failFast.Aborted {
+			report.Aborted = failFast.Reason
+		}
+		if err := writeReport(opts.reportPath, report); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		fmt.Printf("\nreport written to %s\n", opts.reportPath)
+	}
+
+	if failFast != nil && failFast.Aborted {
+		return fmt.Errorf("run aborted: %s", failFast.Reason)
+	}
+	return nil
+}
+
+// applyFlags는 명시적으로 지정된 플래그만 설정에 반영합니다.
+func applyFlags(config map[string]interface{}, opts options) error {
+	var err error
+	flag.Visit(func(f *flag.Flag) {
+		if err != nil {
+			return
+		}
+		switch f.Name {
+		case "qps":
+			err = setExisting(config, "qps", opts.qps)
+		case "tps":
+			err = setExisting(config, "tps", opts.tps)
+		case "batch-size":
+			err = setExisting(config, "batch_size", opts.batchSize)
+		case "workers":
+			config["workers"] = opts.workers
+		case "duration":
+			// time.Duration은 JSON에서 나노초 정수로 표현됨
+			config["duration"] = int64(opts.duration)
+		case "isolation":
+			config["isolation_level"] = opts.isolation
+		case "synchronous-commit":
+			err = setExisting(config, "synchronous_commit", opts.syncCommit)
+		case "max-error-rate":
+			config["max_error_rate"] = opts.maxErrorRate
+		}
+	})
+	return err
+}
+
+// setExisting은 서버 설정에 존재하는 키만 변경합니다 (예: read-server에 -tps 지정 방지).
+func setExisting(config map[string]interface{}, key string, value interface{}) error {
+	if _, ok := config[key]; !ok {
+		return fmt.Errorf("server config has no %q field (wrong server?)", key)
+	}
+	config[key] = value
+	return nil
+}
+
+// waitForCompletion은 실행이 끝날 때까지 폴링합니다. 중단 신호로 중지한 경우 중지 응답의 최종 메트릭을 반환합니다.
+// 서버가 스스로 끝낸 경우 마지막 상태의 fail_fast를 함께 반환합니다. progress는 폴링한 상태마다 호출됩니다.
+func waitForCompletion(client *Client, interval time.Duration, sigCh <-chan os.Signal, progress func(*Status)) (map[string]interface{}, *FailFast, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sigCh:
+			fmt.Println("\n■ interrupted, stopping load generator...")
+			metrics, err := client.Stop()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to stop: %w", err)
+			}
+			return metrics, nil, nil
+		case <-ticker.C:
+			status, err := client.GetStatus()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get status: %w", err)
+			}
+			progress(status)
+			if !status.Running {
+				return nil, status.FailFast, nil
+			}
+		}
+	}
+}
+
+func printProgress(metrics map[string]interface{}) {
+	rate, rateKey := metrics["qps"], "QPS"
+	if v, ok := metrics["tps"]; ok {
+		rate, rateKey = v, "TPS"
+	}
+	fmt.Printf("  [%6.1fs] %s: %8.2f  total: %v  failed: %v  timeouts: %v  p95: %vms\n",
+		toFloat(metrics["elapsed_seconds"]), rateKey, toFloat(rate),
+		metrics["total_requests"], metrics["failed_requests"], metrics["timeout_requests"], metrics["p95_latency_ms"])
+}
+
+func printMetrics(metrics map[string]interface{}) {
+	keys := make([]string, 0, len(metrics))
+	width := 0
+	for k := range metrics {
+		keys = append(keys, k)
+		if len(k) > width {
+			width = len(k)
+		}
+	}
+	sort.Strings(keys)
+
+	fmt.Println("=== final metrics ===")
+	for _, k := range keys {
+		switch v := metrics[k].(type) {
+		case float64:
+			fmt.Printf("  %-*s  %.2f\n", width, k, v)
+		case map[string]interface{}, []interface{}:
+			data, _ := json.Marshal(v)
+			fmt.Printf("  %-*s  %s\n", width, k, data)
+		default:
+			fmt.Printf("  %-*s  %v\n", width, k, v)
+		}
+	}
+}
+
+func writeReport(path string, report Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func durationLabel(d time.Duration) string {
+	if d <= 0 {
+		return "until Ctrl+C"
+	}
+	return d.String()
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case int64:
+		return float64(n)
+	case int:
+		return float64(n)
+	}
+	return 0
+}
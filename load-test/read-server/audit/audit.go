@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Event는 상태를 변경하는 부하 제어 API 호출 한 건의 감사 기록입니다.
+type Event struct {
+	Time   time.Time   `json:"time"`
+	Action string      `json:"action"`           // start, stop, force_stop, config, config_profile, qps
+	Remote string      `json:"remote"`           // 요청한 클라이언트 주소
+	Detail string      `json:"detail,omitempty"` // 프로파일 이름 등 부가 정보
+	Before interface{} `json:"before,omitempty"` // 변경 전 설정
+	After  interface{} `json:"after,omitempty"`  // 변경 후(또는 실행에 사용된) 설정
+}
+
+// Logger는 감사 이벤트를 한 줄에 하나씩 JSON으로 기록합니다.
+type Logger struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{enc: json.NewEncoder(w)}
+}
+
+// Open은 path가 비어 있으면 표준 출력에, 아니면 해당 파일에 이어 쓰는 Logger를 반환합니다.
+func Open(path string) (*Logger, error) {
+	if path == "" {
+		return NewLogger(os.Stdout), nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	l := NewLogger(f)
+	l.closer = f
+	return l, nil
+}
+
+// Record는 이벤트를 기록합니다. Time이 비어 있으면 현재 시각을 사용합니다.
+func (l *Logger) Record(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.enc.Encode(e); err != nil {
+		log.Printf("Failed to write audit event: %v", err)
+	}
+}
+
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
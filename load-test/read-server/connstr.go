@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// connParam은 연결 문자열의 key=value 쌍 하나입니다.
+type connParam struct {
+	key   string
+	value string
+}
+
+// reservedConnParams는 각자의 환경 변수(DB_HOST 등)로만 지정하는 파라미터입니다.
+var reservedConnParams = map[string]string{
+	"host":     "DB_HOST",
+	"port":     "DB_PORT",
+	"user":     "DB_USER",
+	"password": "DB_PASSWORD",
+	"dbname":   "DB_NAME",
+}
+
+var connParamKey = regexp.MustCompile(`^[a-z_]+$`)
+
+// connParams는 기본 파라미터(sslmode, application_name)에 extra의 key=value 쌍을 더합니다.
+// extra는 공백 또는 쉼표로 구분하며(예: "connect_timeout=5 target_session_attrs=read-write"),
+// 기본 파라미터와 같은 키는 덮어쓰고, 예약된 키나 같은 키를 두 번 지정하면 에러를 반환합니다.
+func connParams(applicationName, extra string) ([]connParam, error) {
+	params := []connParam{
+		{key: "sslmode", value: "disable"},
+		{key: "application_name", value: applicationName},
+	}
+
+	seen := make(map[string]bool)
+	fields := strings.FieldsFunc(extra, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || !connParamKey.MatchString(key) || value == "" {
+			return nil, fmt.Errorf("invalid parameter %q: must be key=value", field)
+		}
+		if env, reserved := reservedConnParams[key]; reserved {
+			return nil, fmt.Errorf("parameter %q must be set with %s", key, env)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate parameter %q", key)
+		}
+		seen[key] = true
+
+		replaced := false
+		for i := range params {
+			if params[i].key == key {
+				params[i].value = value
+				replaced = true
+			}
+		}
+		if !replaced {
+			params = append(params, connParam{key: key, value: value})
+		}
+	}
+	return params, nil
+}
+
+// connString은 lib/pq의 key=value 형식 연결 문자열을 만듭니다. 값은 작은따옴표로 감쌉니다.
+func connString(host, port, user, password, dbname string, params []connParam) string {
+	all := append([]connParam{
+		{key: "host", value: host},
+		{key: "port", value: port},
+		{key: "user", value: user},
+		{key: "password", value: password},
+		{key: "dbname", value: dbname},
+	}, params...)
+
+	parts := make([]string, len(all))
+	for i, p := range all {
+		value := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(p.value)
+		parts[i] = fmt.Sprintf("%s='%s'", p.key, value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// paramMap은 /debug/config에 표시할 파라미터 맵을 반환합니다. sslpassword 같은 비밀 값은 가립니다.
+// DB_EXTRA_PARAMS 원문은 비밀 값이 들어 있을 수 있어 env에 넣지 않고 이 맵으로만 보여 줍니다.
+func paramMap(params []connParam) map[string]string {
+	m := make(map[string]string, len(params))
+	for _, p := range params {
+		value := p.value
+		if strings.Contains(p.key, "password") {
+			value = "[REDACTED]"
+		}
+		m[p.key] = value
+	}
+	return m
+}
@@ -0,0 +1,437 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"read-server/load"
+	"read-server/metrics"
+	"read-server/pb"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server는 HTTP LoadHandler와 동일한 Generator/Collector를 사용하는 gRPC 부하 제어 서비스입니다.
+type Server struct {
+	pb.UnimplementedLoadControlServer
+	generator *load.Generator
+	collector *metrics.Collector
+}
+
+func NewServer(generator *load.Generator, collector *metrics.Collector) *Server {
+	return &Server{
+		generator: generator,
+		collector: collector,
+	}
+}
+
+func (s *Server) Start(ctx context.Context, req *pb.StartRequest) (*pb.StartResponse, error) {
+	if s.generator.IsRunning() {
+		return nil, status.Error(codes.FailedPrecondition, "load generator is already running")
+	}
+
+	if err := s.generator.Start(); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.StartResponse{
+		Status:  "started",
+		Message: "Load generation started successfully",
+	}, nil
+}
+
+func (s *Server) Stop(ctx context.Context, req *pb.StopRequest) (*pb.StopResponse, error) {
+	if !s.generator.IsRunning() {
+		return nil, status.Error(codes.FailedPrecondition, "load generator is not running")
+	}
+
+	var finalMetrics metrics.Metrics
+	if req.GetForce() {
+		finalMetrics = s.generator.ForceStop()
+	} else {
+		finalMetrics = s.generator.Stop()
+	}
+
+	return &pb.StopResponse{
+		Status:  "stopped",
+		Message: "Load generation stopped successfully",
+		Metrics: toProtoMetrics(finalMetrics),
+	}, nil
+}
+
+func (s *Server) UpdateConfig(ctx context.Context, req *pb.UpdateConfigRequest) (*pb.UpdateConfigResponse, error) {
+	if s.generator.IsRunning() {
+		return nil, status.Error(codes.FailedPrecondition, "cannot update config while generator is running, stop it first")
+	}
+	if req.GetConfig() == nil {
+		return nil, status.Error(codes.InvalidArgument, "config is required")
+	}
+
+	// proto에 없는 필드는 현재 설정값을 유지
+	config := *s.generator.GetConfig()
+	applyConfig(&config, req.GetConfig())
+
+	if err := config.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.generator.UpdateConfig(&config); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UpdateConfigResponse{
+		Status: "updated",
+		Config: toProtoConfig(&config),
+	}, nil
+}
+
+func (s *Server) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.GetStatusResponse, error) {
+	return &pb.GetStatusResponse{
+		Running:     s.generator.IsRunning(),
+		Config:      toProtoConfig(s.generator.GetConfig()),
+		Metrics:     toProtoMetrics(s.collector.GetMetrics()),
+		Convergence: toProtoConvergence(s.generator.Convergence()),
+		FailFast:    toProtoFailFast(s.generator.FailFast()),
+		Chaos:       toProtoChaos(s.generator.Chaos()),
+	}, nil
+}
+
+func (s *Server) GetMetrics(ctx context.Context, req *pb.GetMetricsRequest) (*pb.Metrics, error) {
+	return toProtoMetrics(s.collector.GetMetrics()), nil
+}
+
+// StreamMetrics는 클라이언트가 연결을 끊을 때까지 interval마다 메트릭을 전송합니다.
+func (s *Server) StreamMetrics(req *pb.StreamMetricsRequest, stream pb.LoadControl_StreamMetricsServer) error {
+	interval := time.Second
+	if req.GetInterval() != nil {
+		if d := req.GetInterval().AsDuration(); d > 0 {
+			interval = d
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := stream.Send(toProtoMetrics(s.collector.GetMetrics())); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func applyConfig(config *load.Config, in *pb.Config) {
+	config.QPS = int(in.GetQps())
+	config.Workers = int(in.GetWorkers())
+	config.Duration = in.GetDuration().AsDuration()
+	config.IsolationLevel = in.GetIsolationLevel()
+	config.CompletionWebhook = in.GetCompletionWebhook()
+	config.CompareIsolation = in.GetCompareIsolation()
+	config.IsolationSlice = in.GetIsolationSlice().AsDuration()
+	config.IsolationRotation = in.GetIsolationRotation()
+	config.QueryTimeout = in.GetQueryTimeout().AsDuration()
+	config.DedicatedConns = in.GetDedicatedConns()
+	config.MaxOpenConns = int(in.GetMaxOpenConns())
+	config.MaxIdleConns = int(in.GetMaxIdleConns())
+	config.ConnMaxLifetime = in.GetConnMaxLifetime().AsDuration()
+	config.WorkerStartStagger = in.GetWorkerStartStagger().AsDuration()
+	config.RampUp = in.GetRampUp().AsDuration()
+	config.ThinkTime = in.GetThinkTime().AsDuration()
+	config.ThinkTimeJitter = in.GetThinkTimeJitter().AsDuration()
+	config.QueryProtocol = in.GetQueryProtocol()
+	config.UsePrepared = in.GetUsePrepared()
+	config.ConvergenceInterval = in.GetConvergenceInterval().AsDuration()
+	config.ConvergenceTolerance = in.GetConvergenceTolerance()
+	config.ConvergenceWindow = int(in.GetConvergenceWindow())
+	config.StopOnConvergence = in.GetStopOnConvergence()
+	config.MaxErrorRate = in.GetMaxErrorRate()
+	config.ErrorRateWindow = in.GetErrorRateWindow().AsDuration()
+	config.LatencySampleRate = in.GetLatencySampleRate()
+	config.Percentiles = in.GetPercentiles()
+	config.ReplayFile = in.GetReplayFile()
+	config.ReplayTiming = in.GetReplayTiming()
+	config.WarmupExclude = in.GetWarmupExclude().AsDuration()
+	config.ChaosInterval = in.GetChaosInterval().AsDuration()
+	config.DutyActive = in.GetDutyActive().AsDuration()
+	config.DutyIdle = in.GetDutyIdle().AsDuration()
+	config.VerifyRows = in.GetVerifyRows()
+	config.FetchLatency = in.GetFetchLatency()
+	if in.ResetOnStart != nil {
+		resetOnStart := in.GetResetOnStart()
+		config.ResetOnStart = &resetOnStart
+	}
+	if in.GetQueryMix() != nil {
+		config.QueryMix = load.QueryMix{
+			Simple:    int(in.GetQueryMix().GetSimple()),
+			Filter:    int(in.GetQueryMix().GetFilter()),
+			Aggregate: int(in.GetQueryMix().GetAggregate()),
+		}
+	}
+	config.CustomQueries = nil
+	for _, q := range in.GetCustomQueries() {
+		args := make([]interface{}, len(q.GetArgs()))
+		for i, arg := range q.GetArgs() {
+			args[i] = arg.AsInterface()
+		}
+		config.CustomQueries = append(config.CustomQueries, load.CustomQuery{
+			Name:   q.GetName(),
+			SQL:    q.GetSql(),
+			Weight: int(q.GetWeight()),
+			Args:   args,
+		})
+	}
+}
+
+func toProtoConfig(config *load.Config) *pb.Config {
+	return &pb.Config{
+		Qps:      int32(config.QPS),
+		Workers:  int32(config.Workers),
+		Duration: durationpb.New(config.Duration),
+		QueryMix: &pb.QueryMix{
+			Simple:    int32(config.QueryMix.Simple),
+			Filter:    int32(config.QueryMix.Filter),
+			Aggregate: int32(config.QueryMix.Aggregate),
+		},
+		IsolationLevel:       config.IsolationLevel,
+		CompletionWebhook:    config.CompletionWebhook,
+		CompareIsolation:     config.CompareIsolation,
+		IsolationSlice:       durationpb.New(config.IsolationSlice),
+		IsolationRotation:    config.IsolationRotation,
+		QueryTimeout:         durationpb.New(config.QueryTimeout),
+		ResetOnStart:         config.ResetOnStart,
+		DedicatedConns:       config.DedicatedConns,
+		MaxOpenConns:         int32(config.MaxOpenConns),
+		MaxIdleConns:         int32(config.MaxIdleConns),
+		ConnMaxLifetime:      durationpb.New(config.ConnMaxLifetime),
+		WorkerStartStagger:   durationpb.New(config.WorkerStartStagger),
+		RampUp:               durationpb.New(config.RampUp),
+		ThinkTime:            durationpb.New(config.ThinkTime),
+		ThinkTimeJitter:      durationpb.New(config.ThinkTimeJitter),
+		QueryProtocol:        config.QueryProtocol,
+		UsePrepared:          config.UsePrepared,
+		ConvergenceInterval:  durationpb.New(config.ConvergenceInterval),
+		ConvergenceTolerance: config.ConvergenceTolerance,
+		ConvergenceWindow:    int32(config.ConvergenceWindow),
+		StopOnConvergence:    config.StopOnConvergence,
+		MaxErrorRate:         config.MaxErrorRate,
+		ErrorRateWindow:      durationpb.New(config.ErrorRateWindow),
+		LatencySampleRate:    config.LatencySampleRate,
+		Percentiles:          config.Percentiles,
+		ReplayFile:           config.ReplayFile,
+		ReplayTiming:         config.ReplayTiming,
+		WarmupExclude:        durationpb.New(config.WarmupExclude),
+		ChaosInterval:        durationpb.New(config.ChaosInterval),
+		DutyActive:           durationpb.New(config.DutyActive),
+		DutyIdle:             durationpb.New(config.DutyIdle),
+		VerifyRows:           config.VerifyRows,
+		FetchLatency:         config.FetchLatency,
+		CustomQueries:        toProtoCustomQueries(config.CustomQueries),
+	}
+}
+
+func toProtoCustomQueries(queries []load.CustomQuery) []*pb.CustomQuery {
+	if len(queries) == 0 {
+		return nil
+	}
+	out := make([]*pb.CustomQuery, 0, len(queries))
+	for _, q := range queries {
+		args := make([]*structpb.Value, len(q.Args))
+		for i, arg := range q.Args {
+			v, err := structpb.NewValue(arg)
+			if err != nil {
+				// JSON으로 표현할 수 없는 인자(Go 코드에서 지정한 time.Time 등)는 문자열로 보여 줌
+				v = structpb.NewStringValue(fmt.Sprint(arg))
+			}
+			args[i] = v
+		}
+		out = append(out, &pb.CustomQuery{
+			Name:   q.Name,
+			Sql:    q.SQL,
+			Weight: int32(q.Weight),
+			Args:   args,
+		})
+	}
+	return out
+}
+
+func toProtoConvergence(c *load.ConvergenceStatus) *pb.ConvergenceStatus {
+	if c == nil {
+		return nil
+	}
+	return &pb.ConvergenceStatus{
+		Converged:             c.Converged,
+		ConvergedAfterSeconds: c.ConvergedAfter,
+		P95LatencyMs:          c.P95Latency,
+		ReferenceP95Ms:        c.Reference,
+		StableIntervals:       int32(c.StableIntervals),
+		Window:                int32(c.Window),
+		Checks:                int32(c.Checks),
+	}
+}
+
+func toProtoMetrics(m metrics.Metrics) *pb.Metrics {
+	buckets := make([]*pb.LatencyBucket, 0, len(m.LatencyBuckets))
+	for _, b := range m.LatencyBuckets {
+		buckets = append(buckets, &pb.LatencyBucket{
+			Range: b.Range,
+			MinMs: b.MinMs,
+			MaxMs: b.MaxMs,
+			Count: b.Count,
+		})
+	}
+
+	var pool *pb.PoolStats
+	if p := m.Pool; p != nil {
+		pool = &pb.PoolStats{
+			OpenConnections:         int32(p.OpenConnections),
+			InUse:                   int32(p.InUse),
+			Idle:                    int32(p.Idle),
+			WaitCount:               p.WaitCount,
+			WaitDurationMs:          p.WaitDurationMs,
+			MaxIdleClosed:           p.MaxIdleClosed,
+			MaxIdleTimeClosed:       p.MaxIdleTimeClosed,
+			MaxLifetimeClosed:       p.MaxLifetimeClosed,
+			LifetimeClosedPerMinute: p.LifetimeClosedPerMinute,
+		}
+	}
+
+	var byLabel map[string]*pb.LabelMetrics
+	if len(m.ByLabel) > 0 {
+		byLabel = make(map[string]*pb.LabelMetrics, len(m.ByLabel))
+		for label, l := range m.ByLabel {
+			byLabel[label] = &pb.LabelMetrics{
+				TotalRequests:    l.TotalRequests,
+				SuccessRequests:  l.SuccessRequests,
+				FailedRequests:   l.FailedRequests,
+				TimeoutRequests:  l.TimeoutRequests,
+				ConnectionErrors: l.ConnectionErrors,
+				RowsRead:         l.RowsRead,
+				AvgLatencyMs:     l.AvgLatency,
+				P50LatencyMs:     l.P50Latency,
+				P95LatencyMs:     l.P95Latency,
+				P99LatencyMs:     l.P99Latency,
+			}
+		}
+	}
+
+	return &pb.Metrics{
+		TotalRequests:      m.TotalRequests,
+		SuccessRequests:    m.SuccessRequests,
+		FailedRequests:     m.FailedRequests,
+		TimeoutRequests:    m.TimeoutRequests,
+		TimeoutRate:        m.TimeoutRate,
+		ConnectionErrors:   m.ConnectionErrors,
+		AbortedRequests:    m.AbortedRequests,
+		Reconnects:         m.Reconnects,
+		DowntimeSeconds:    m.DowntimeSeconds,
+		Qps:                m.QPS,
+		AvgLatencyMs:       m.AvgLatency,
+		P50LatencyMs:       m.P50Latency,
+		P95LatencyMs:       m.P95Latency,
+		P99LatencyMs:       m.P99Latency,
+		StartTime:          timestamppb.New(m.StartTime),
+		ElapsedSeconds:     m.Elapsed,
+		RowsRead:           m.RowsRead,
+		RowsPerSecond:      m.RowsPerSecond,
+		InFlightRequests:   m.InFlightRequests,
+		RejectedRequests:   m.RejectedRequests,
+		LatencyBuckets:     buckets,
+		Pool:               pool,
+		ByLabel:            byLabel,
+		FailuresByCode:     m.FailuresByCode,
+		Percentiles:        m.Percentiles,
+		TargetRate:         m.TargetRate,
+		AchievedRate:       m.AchievedRate,
+		AchievedRatio:      m.AchievedRatio,
+		LimiterWaitSeconds: m.LimiterWaitSeconds,
+		LimiterWaitRatio:   m.LimiterWaitRatio,
+		MinLatencyMs:       m.MinLatency,
+		MaxLatencyMs:       m.MaxLatency,
+		LatencySampleRate:  m.LatencySampleRate,
+		LatencySamples:     int64(m.LatencySamples),
+		Warmup:             toProtoWarmup(m.Warmup),
+		FetchLatency:       toProtoFetchLatency(m.FetchLatency),
+	}
+}
+
+func toProtoFetchLatency(f *metrics.FetchLatency) *pb.FetchLatency {
+	if f == nil {
+		return nil
+	}
+	return &pb.FetchLatency{
+		Samples:     int64(f.Samples),
+		FirstRow:    toProtoFetchPercentiles(f.FirstRow),
+		LastRow:     toProtoFetchPercentiles(f.LastRow),
+		StreamShare: f.StreamShare,
+	}
+}
+
+func toProtoFetchPercentiles(p metrics.FetchPercentiles) *pb.FetchPercentiles {
+	return &pb.FetchPercentiles{
+		AvgLatencyMs: p.AvgLatency,
+		P50LatencyMs: p.P50Latency,
+		P95LatencyMs: p.P95Latency,
+		P99LatencyMs: p.P99Latency,
+	}
+}
+
+func toProtoWarmup(w *metrics.WarmupStats) *pb.WarmupStats {
+	if w == nil {
+		return nil
+	}
+	return &pb.WarmupStats{
+		ExcludeSeconds: w.ExcludeSeconds,
+		EndsAt:         timestamppb.New(w.EndsAt),
+		Active:         w.Active,
+		Samples:        int64(w.Samples),
+		AvgLatencyMs:   w.AvgLatency,
+		P50LatencyMs:   w.P50Latency,
+		P95LatencyMs:   w.P95Latency,
+		P99LatencyMs:   w.P99Latency,
+	}
+}
+
+func toProtoFailFast(f *load.FailFastStatus) *pb.FailFastStatus {
+	if f == nil {
+		return nil
+	}
+	return &pb.FailFastStatus{
+		MaxErrorRate:        f.MaxErrorRate,
+		WindowSeconds:       f.WindowSeconds,
+		ErrorRate:           f.ErrorRate,
+		Checks:              int32(f.Checks),
+		Aborted:             f.Aborted,
+		AbortedAfterSeconds: f.AbortedAfter,
+		Reason:              f.Reason,
+	}
+}
+
+func toProtoChaos(c *load.ChaosStatus) *pb.ChaosStatus {
+	if c == nil {
+		return nil
+	}
+	var lastKillAt *timestamppb.Timestamp
+	if c.LastKillAt != nil {
+		lastKillAt = timestamppb.New(*c.LastKillAt)
+	}
+	return &pb.ChaosStatus{
+		IntervalSeconds: c.IntervalSeconds,
+		Kills:           int32(c.Kills),
+		Recovered:       int32(c.Recovered),
+		Skipped:         int32(c.Skipped),
+		Errors:          int32(c.Errors),
+		Reconnects:      int32(c.Reconnects),
+		LastKillPid:     int32(c.LastKillPID),
+		LastKillAt:      lastKillAt,
+		LastError:       c.LastError,
+	}
+}
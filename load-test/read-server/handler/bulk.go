@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// labelBulkGet은 id 목록 조회 API의 메트릭 라벨입니다.
+const labelBulkGet = "api_logs_bulk_get"
+
+// id 목록 조회 제한
+const (
+	MaxBulkGetIDs         = 1000    // 요청 한 번에 조회할 수 있는 최대 id 수
+	maxBulkGetRequestBody = 1 << 20 // 요청 본문 최대 크기 (1MB, id 1000개에 충분)
+)
+
+// BulkGetRequest는 POST /logs/bulk-get 요청 본문입니다.
+type BulkGetRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+// POST /logs/bulk-get - id 목록으로 로그 조회 (metadata 포함, 요청한 id 순서대로)
+func (h *ReadHandler) BulkGetLogs(w http.ResponseWriter, r *http.Request) {
+	var req BulkGetRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxBulkGetRequestBody)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body exceeds %d bytes", maxBytesErr.Limit))
+			return
+		}
+		writeError(w, r, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.IDs) == 0 {
+		writeError(w, r, http.StatusBadRequest, "ids must not be empty")
+		return
+	}
+	if len(req.IDs) > MaxBulkGetIDs {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("too many ids: %d (max %d)", len(req.IDs), MaxBulkGetIDs))
+		return
+	}
+
+	// 중복 id는 한 번만 조회하고 응답에도 처음 나온 위치에 한 번만 넣음
+	ids := make([]int64, 0, len(req.IDs))
+	seen := make(map[int64]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	// 기본 키 인덱스로 한 번에 조회 (/logs/{id}를 N번 호출하는 왕복 비용 없음)
+	query := `
+		SELECT id, timestamp, level, service, message, metadata
+		FROM logs
+		WHERE id = ANY($1)
+	`
+
+	start := time.Now()
+	found, err := scanLogsWithMetadata(h.db.QueryContext(r.Context(), query, pq.Array(ids)))
+	if err != nil {
+		h.collector.RecordFailureWithError(labelBulkGet, time.Since(start), err)
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to get logs: %v", err))
+		return
+	}
+
+	latency := time.Since(start)
+	h.collector.RecordSuccess(labelBulkGet, latency, len(found))
+
+	// ANY는 순서를 보장하지 않으므로 요청한 id 순서로 다시 정렬하고, 없는 id는 missing에 모음
+	byID := make(map[int64]LogEntry, len(found))
+	for _, log := range found {
+		byID[log.ID] = log
+	}
+	logs := make([]LogEntry, 0, len(found))
+	missing := []int64{}
+	for _, id := range ids {
+		if log, ok := byID[id]; ok {
+			logs = append(logs, log)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"logs":    logs,
+		"count":   len(logs),
+		"missing": missing,
+	})
+}
+
+// scanLogsWithMetadata는 scanLogs와 같지만 마지막 컬럼으로 metadata(NULL 가능)를 함께 읽습니다.
+func scanLogsWithMetadata(rows *sql.Rows, err error) ([]LogEntry, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []LogEntry{}
+	for rows.Next() {
+		var log LogEntry
+		var metadata sql.NullString
+		if err := rows.Scan(&log.ID, &log.Timestamp, &log.Level, &log.Service, &log.Message, &metadata); err != nil {
+			return nil, fmt.Errorf("scan row %d: %w", len(logs)+1, err)
+		}
+		log.Metadata = metadata.String
+		logs = append(logs, log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration: %w", err)
+	}
+	return logs, nil
+}
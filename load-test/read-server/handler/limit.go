@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+	"read-server/metrics"
+)
+
+// DefaultMaxConcurrentQueries는 조회 API의 기본 동시 실행 한도입니다.
+// DB 연결 풀(50)보다 작게 잡아 부하 생성기가 사용할 연결을 남겨 둡니다.
+const DefaultMaxConcurrentQueries = 32
+
+// ConcurrencyLimiter는 버퍼 채널 세마포어로 조회 API의 동시 실행 수를 제한합니다.
+// 한도를 넘는 요청은 연결 풀에서 대기하지 않고 즉시 503으로 거부됩니다.
+type ConcurrencyLimiter struct {
+	sem       chan struct{}
+	collector *metrics.Collector
+}
+
+// NewConcurrencyLimiter는 max가 1보다 작으면 제한하지 않는 Limiter를 반환합니다.
+func NewConcurrencyLimiter(max int, collector *metrics.Collector) *ConcurrencyLimiter {
+	l := &ConcurrencyLimiter{collector: collector}
+	if max > 0 {
+		l.sem = make(chan struct{}, max)
+	}
+	return l
+}
+
+// Wrap은 next를 세마포어로 감싼 핸들러를 반환합니다.
+func (l *ConcurrencyLimiter) Wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if l.sem != nil {
+			select {
+			case l.sem <- struct{}{}:
+				defer func() { <-l.sem }()
+			default:
+				l.collector.RecordRejected()
+				writeError(w, r, http.StatusServiceUnavailable, "Too many concurrent queries, try again later")
+				return
+			}
+		}
+
+		l.collector.AddInFlight(1)
+		defer l.collector.AddInFlight(-1)
+
+		next(w, r)
+	}
+}
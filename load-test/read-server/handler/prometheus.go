@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"read-server/metrics"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// prometheusNamespace는 /metrics/prometheus 메트릭 이름의 접두사입니다.
+const prometheusNamespace = "read_server_"
+
+// prometheusQuantile은 latency_ms{quantile=...} 게이지 하나입니다.
+type prometheusQuantile struct {
+	label string // Prometheus quantile 라벨 (예: 99.9 → "0.999")
+	value float64
+}
+
+// prometheusQuantiles는 설정(percentiles)으로 지정한 백분위수를 오름차순으로 반환합니다 (기본값 0.5/0.95/0.99).
+func prometheusQuantiles(m metrics.Metrics) []prometheusQuantile {
+	type entry struct {
+		p     float64
+		value float64
+	}
+	entries := make([]entry, 0, len(m.Percentiles))
+	for key, value := range m.Percentiles {
+		p, err := strconv.ParseFloat(strings.TrimPrefix(key, "p"), 64)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry{p, value})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].p < entries[j].p })
+
+	quantiles := make([]prometheusQuantile, len(entries))
+	for i, e := range entries {
+		quantiles[i] = prometheusQuantile{label: quantileLabel(e.p), value: e.value}
+	}
+	return quantiles
+}
+
+// quantileLabel은 백분위수 p(0 < p < 100)를 0~1 사이의 quantile 문자열로 바꿉니다.
+// p/100을 부동소수점으로 계산하면 99.9가 "0.9990000000000001"이 되므로 십진 문자열에서 소수점을 두 자리 옮깁니다.
+func quantileLabel(p float64) string {
+	whole, frac, _ := strings.Cut(strconv.FormatFloat(p, 'f', -1, 64), ".")
+	if len(whole) < 2 {
+		whole = "0" + whole
+	}
+	label := strings.TrimRight("0."+whole+frac, "0")
+	return strings.TrimSuffix(label, ".")
+}
+
+// GET /metrics/prometheus - /metrics와 같은 수집기의 값을 Prometheus 텍스트 형식으로 조회 (스크래핑용)
+func (h *LoadHandler) GetPrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+	m := h.collector.GetMetrics()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	out := bufio.NewWriter(w)
+	defer out.Flush()
+
+	metric := func(name, kind, help string, value float64) {
+		fmt.Fprintf(out, "# HELP %s%s %s\n", prometheusNamespace, name, help)
+		fmt.Fprintf(out, "# TYPE %s%s %s\n", prometheusNamespace, name, kind)
+		fmt.Fprintf(out, "%s%s %g\n", prometheusNamespace, name, value)
+	}
+
+	// 누적 카운터는 메트릭 초기화(/metrics/reset, 실행 시작) 때 0으로 돌아가며, Prometheus의 rate()는 이를 카운터 리셋으로 처리함
+	metric("total_requests", "counter", "Total requests recorded since the last metrics reset.", float64(m.TotalRequests))
+	metric("success_requests", "counter", "Successful requests.", float64(m.SuccessRequests))
+	metric("failed_requests", "counter", "Failed requests (excluding timeouts and connection errors).", float64(m.FailedRequests))
+	metric("timeout_requests", "counter", "Requests cancelled by a query timeout.", float64(m.TimeoutRequests))
+	metric("connection_errors", "counter", "Requests that failed because the database connection was lost.", float64(m.ConnectionErrors))
+	metric("rows_read", "counter", "Rows read.", float64(m.RowsRead))
+
+	metric("qps", "gauge", "Average queries per second since the last metrics reset.", m.QPS)
+	metric("rows_per_second", "gauge", "Average rows read per second since the last metrics reset.", m.RowsPerSecond)
+	metric("running", "gauge", "1 if the load generator is running.", boolGauge(h.generator.IsRunning()))
+
+	// 지연시간 (/metrics와 같은 값이므로 ms 미만은 버린 값)
+	name := prometheusNamespace + "latency_ms"
+	fmt.Fprintf(out, "# HELP %s Latency percentiles in milliseconds since the last metrics reset.\n", name)
+	fmt.Fprintf(out, "# TYPE %s gauge\n", name)
+	for _, q := range prometheusQuantiles(m) {
+		fmt.Fprintf(out, "%s{quantile=%q} %g\n", name, q.label, q.value)
+	}
+	metric("latency_avg_ms", "gauge", "Average latency in milliseconds.", m.AvgLatency)
+	metric("latency_max_ms", "gauge", "Maximum recorded latency in milliseconds.", m.MaxLatency)
+}
+
+func boolGauge(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
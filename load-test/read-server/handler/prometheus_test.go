@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"read-server/load"
+	"read-server/metrics"
+	"strings"
+	"testing"
+	"time"
+)
+
+// /metrics/prometheus가 설정한 백분위수(percentiles)를 quantile 게이지로 오름차순으로 내보내는지 확인합니다.
+func TestPrometheusMetricsQuantiles(t *testing.T) {
+	collector := metrics.NewCollector()
+	collector.SetPercentiles([]float64{99.9, 50, 75})
+	time.Sleep(110 * time.Millisecond) // 기록할 요청이 Collector를 만든 뒤에 시작하도록 (가장 긴 지연시간보다 길게)
+	for i := 1; i <= 100; i++ {
+		collector.RecordSuccess("simple", time.Duration(i)*time.Millisecond, 1)
+	}
+	h := NewLoadHandler(load.NewGenerator(nil, load.DefaultConfig(), collector), collector, nil)
+
+	rec := httptest.NewRecorder()
+	h.GetPrometheusMetrics(rec, httptest.NewRequest(http.MethodGet, "/metrics/prometheus", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Fatalf("Content-Type = %q, want text exposition format", ct)
+	}
+	body := rec.Body.String()
+
+	m := collector.GetMetrics()
+	var want []string
+	for _, q := range []struct{ key, label string }{{"p50", "0.5"}, {"p75", "0.75"}, {"p99.9", "0.999"}} {
+		want = append(want, fmt.Sprintf("read_server_latency_ms{quantile=%q} %g", q.label, m.Percentiles[q.key]))
+	}
+	var got []string
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "read_server_latency_ms{") {
+			got = append(got, line)
+		}
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("quantile lines:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	for _, line := range []string{
+		"# TYPE read_server_latency_ms gauge",
+		"# TYPE read_server_total_requests counter",
+		"read_server_total_requests 100",
+		"read_server_running 0",
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Fatalf("output has no line %q:\n%s", line, body)
+		}
+	}
+}
+
+func TestQuantileLabel(t *testing.T) {
+	tests := []struct {
+		p    float64
+		want string
+	}{
+		{50, "0.5"},
+		{95, "0.95"},
+		{99, "0.99"},
+		{99.9, "0.999"},
+		{99.99, "0.9999"},
+		{5, "0.05"},
+		{0.1, "0.001"},
+	}
+	for _, tt := range tests {
+		if got := quantileLabel(tt.p); got != tt.want {
+			t.Errorf("quantileLabel(%g) = %q, want %q", tt.p, got, tt.want)
+		}
+	}
+}
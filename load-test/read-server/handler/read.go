@@ -1,24 +1,45 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"read-server/metrics"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// DefaultMaxLimit는 한 번의 조회로 반환할 수 있는 기본 최대 행 수입니다.
+const DefaultMaxLimit = 10000
+
+// defaultLimit는 limit 파라미터가 없을 때 사용하는 행 수입니다.
+const defaultLimit = 100
+
+// 조회 API 메트릭 라벨 (부하 생성기의 쿼리 타입 라벨과 구분)
+const (
+	labelGetLogs    = "api_logs"
+	labelSearchLogs = "api_logs_search"
+	labelGetStats   = "api_logs_stats"
+)
+
 type ReadHandler struct {
 	db        *sql.DB
 	collector *metrics.Collector
+	maxLimit  int
 }
 
-func NewReadHandler(db *sql.DB, collector *metrics.Collector) *ReadHandler {
+// NewReadHandler는 maxLimit이 1보다 작으면 DefaultMaxLimit을 사용합니다.
+func NewReadHandler(db *sql.DB, collector *metrics.Collector, maxLimit int) *ReadHandler {
+	if maxLimit < 1 {
+		maxLimit = DefaultMaxLimit
+	}
 	return &ReadHandler{
 		db:        db,
 		collector: collector,
+		maxLimit:  maxLimit,
 	}
 }
 
@@ -32,182 +53,347 @@ type LogEntry struct {
 }
 
 type StatsEntry struct {
-	Level     string    `json:"level"`
+	Level     string    `json:"level,omitempty"`
+	Service   string    `json:"service,omitempty"`
 	Count     int64     `json:"count"`
 	FirstSeen time.Time `json:"first_seen"`
 	LastSeen  time.Time `json:"last_seen"`
 }
 
-// GET /logs - 로그 조회 (페이징)
+// sortColumns는 GetLogs의 sort 파라미터로 허용하는 컬럼입니다.
+// 사용자 입력을 쿼리에 그대로 넣지 않고, 이 맵의 값만 ORDER BY에 사용합니다.
+var sortColumns = map[string]string{
+	"timestamp": "timestamp",
+	"id":        "id",
+	"level":     "level",
+}
+
+// GET /logs - 로그 조회 (페이징, ?sort=timestamp|id|level&order=asc|desc)
 func (h *ReadHandler) GetLogs(w http.ResponseWriter, r *http.Request) {
-	limitStr := r.URL.Query().Get("limit")
-	limit := 100
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
+	limit := h.parseLimit(r)
+
+	orderBy, err := parseOrderBy(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	query := `
+	// orderBy는 화이트리스트에서만 만들어지므로 쿼리에 직접 넣어도 안전
+	query := fmt.Sprintf(`
 		SELECT id, timestamp, level, service, message
 		FROM logs
-		ORDER BY timestamp DESC
+		ORDER BY %s
 		LIMIT $1
-	`
+	`, orderBy)
 
 	start := time.Now()
-	rows, err := h.db.Query(query, limit)
+	logs, err := scanLogs(h.db.QueryContext(r.Context(), query, limit))
 	if err != nil {
-		h.collector.RecordFailure()
-		http.Error(w, fmt.Sprintf("Failed to query logs: %v", err), http.StatusInternalServerError)
-		return
-	}
-	defer rows.Close()
-
-	logs := make([]LogEntry, 0, limit)
-	for rows.Next() {
-		var log LogEntry
-		if err := rows.Scan(&log.ID, &log.Timestamp, &log.Level, &log.Service, &log.Message); err != nil {
-			h.collector.RecordFailure()
-			http.Error(w, fmt.Sprintf("Failed to scan row: %v", err), http.StatusInternalServerError)
-			return
-		}
-		logs = append(logs, log)
-	}
-
-	if err := rows.Err(); err != nil {
-		h.collector.RecordFailure()
-		http.Error(w, fmt.Sprintf("Row iteration error: %v", err), http.StatusInternalServerError)
+		h.collector.RecordFailureWithError(labelGetLogs, time.Since(start), err)
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to query logs: %v", err))
 		return
 	}
 
 	latency := time.Since(start)
-	h.collector.RecordSuccess(latency)
+	h.collector.RecordSuccess(labelGetLogs, latency, len(logs))
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"logs":  logs,
 		"count": len(logs),
+		"limit": limit,
 	})
 }
 
-// GET /logs/search - 로그 검색 (필터)
+// GET /logs/search - 로그 검색 (필터, ?offset=N 페이징, ?with_total=true이면 전체 일치 건수 포함)
 func (h *ReadHandler) SearchLogs(w http.ResponseWriter, r *http.Request) {
 	level := r.URL.Query().Get("level")
 	service := r.URL.Query().Get("service")
-	limitStr := r.URL.Query().Get("limit")
+	limit := h.parseLimit(r)
 
-	limit := 100
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
+	offset, err := parseOffset(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	withTotal := false
+	if v := r.URL.Query().Get("with_total"); v != "" {
+		withTotal, err = strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid with_total %q: must be true or false", v))
+			return
 		}
 	}
 
-	query := `
-		SELECT id, timestamp, level, service, message
-		FROM logs
-		WHERE 1=1
-	`
+	// 페이지 조회와 COUNT(*)가 같은 조건을 쓰도록 WHERE 절을 따로 만듦
+	where := " WHERE timestamp > NOW() - INTERVAL '1 hour'"
 	args := []interface{}{}
 	argCount := 1
 
 	if level != "" {
-		query += fmt.Sprintf(" AND level = $%d", argCount)
+		where += fmt.Sprintf(" AND level = $%d", argCount)
 		args = append(args, level)
 		argCount++
 	}
 
 	if service != "" {
-		query += fmt.Sprintf(" AND service = $%d", argCount)
+		where += fmt.Sprintf(" AND service = $%d", argCount)
 		args = append(args, service)
 		argCount++
 	}
 
-	query += fmt.Sprintf(" AND timestamp > NOW() - INTERVAL '1 hour' ORDER BY timestamp DESC LIMIT $%d", argCount)
-	args = append(args, limit)
+	query := "SELECT id, timestamp, level, service, message FROM logs" + where +
+		fmt.Sprintf(" ORDER BY timestamp DESC, id DESC LIMIT $%d OFFSET $%d", argCount, argCount+1)
+	pageArgs := append(append([]interface{}{}, args...), limit, offset)
 
 	start := time.Now()
-	rows, err := h.db.Query(query, args...)
+	var logs []LogEntry
+	var total int64
+	if withTotal {
+		logs, total, err = h.searchWithTotal(r.Context(), query, pageArgs, "SELECT COUNT(*) FROM logs"+where, args, limit)
+	} else {
+		logs, err = scanLogs(h.db.QueryContext(r.Context(), query, pageArgs...))
+	}
 	if err != nil {
-		h.collector.RecordFailure()
-		http.Error(w, fmt.Sprintf("Failed to search logs: %v", err), http.StatusInternalServerError)
+		h.collector.RecordFailureWithError(labelSearchLogs, time.Since(start), err)
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to search logs: %v", err))
 		return
 	}
+
+	latency := time.Since(start)
+	h.collector.RecordSuccess(labelSearchLogs, latency, len(logs))
+
+	resp := map[string]interface{}{
+		"logs":   logs,
+		"count":  len(logs),
+		"limit":  limit,
+		"offset": offset,
+	}
+	if withTotal {
+		resp["total"] = total
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// searchWithTotal은 페이지 조회와 COUNT(*)를 하나의 REPEATABLE READ 읽기 전용 트랜잭션에서 실행합니다.
+// READ COMMITTED는 쿼리마다 스냅샷을 새로 잡으므로, 같은 스냅샷을 봐야 total과 페이지가 어긋나지 않습니다.
+// COUNT(*)는 시간 범위 안의 일치 행을 모두 세므로 페이지 조회보다 비쌀 수 있습니다.
+func (h *ReadHandler) searchWithTotal(ctx context.Context, query string, args []interface{}, countQuery string, countArgs []interface{}, limit int) ([]LogEntry, int64, error) {
+	tx, err := h.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+
+	logs, err := scanLogs(tx.QueryContext(ctx, query, args...))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := tx.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// scanLogs는 (id, timestamp, level, service, message) 행을 LogEntry로 읽습니다.
+// 중간 행에서 Scan이 실패하면(예: NULL 또는 타입 불일치) 일부 결과를 버리고 몇 번째 행인지 담은 에러를 반환합니다.
+func scanLogs(rows *sql.Rows, err error) ([]LogEntry, error) {
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
-	logs := make([]LogEntry, 0, limit)
+	logs := []LogEntry{}
 	for rows.Next() {
 		var log LogEntry
 		if err := rows.Scan(&log.ID, &log.Timestamp, &log.Level, &log.Service, &log.Message); err != nil {
-			h.collector.RecordFailure()
-			http.Error(w, fmt.Sprintf("Failed to scan row: %v", err), http.StatusInternalServerError)
-			return
+			return nil, fmt.Errorf("scan row %d: %w", len(logs)+1, err)
 		}
 		logs = append(logs, log)
 	}
-
 	if err := rows.Err(); err != nil {
-		h.collector.RecordFailure()
-		http.Error(w, fmt.Sprintf("Row iteration error: %v", err), http.StatusInternalServerError)
-		return
+		return nil, fmt.Errorf("row iteration: %w", err)
 	}
+	return logs, nil
+}
 
-	latency := time.Since(start)
-	h.collector.RecordSuccess(latency)
+// parseOffset은 offset 쿼리 파라미터를 읽습니다. 없으면 0, 음수나 숫자가 아니면 에러를 반환합니다.
+func parseOffset(r *http.Request) (int, error) {
+	offsetStr := r.URL.Query().Get("offset")
+	if offsetStr == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid offset %q: must be a non-negative integer", offsetStr)
+	}
+	return offset, nil
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"logs":  logs,
-		"count": len(logs),
-	})
+// parseLimit은 limit 쿼리 파라미터를 읽어 maxLimit 이하로 제한합니다.
+// 잘못된 값이나 0 이하는 기본값을 사용합니다.
+func (h *ReadHandler) parseLimit(r *http.Request) int {
+	limit := defaultLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+	if limit > h.maxLimit {
+		limit = h.maxLimit
+	}
+	return limit
 }
 
-// GET /logs/stats - 로그 통계 (집계)
+// parseOrderBy는 sort/order 쿼리 파라미터를 검증하여 ORDER BY 절을 만듭니다.
+// 기본값은 timestamp DESC이며, 허용되지 않은 값은 에러를 반환합니다.
+func parseOrderBy(r *http.Request) (string, error) {
+	sort := r.URL.Query().Get("sort")
+	if sort == "" {
+		sort = "timestamp"
+	}
+	column, ok := sortColumns[sort]
+	if !ok {
+		return "", fmt.Errorf("invalid sort %q: must be one of timestamp, id, level", sort)
+	}
+
+	var direction string
+	switch r.URL.Query().Get("order") {
+	case "", "desc":
+		direction = "DESC"
+	case "asc":
+		direction = "ASC"
+	default:
+		return "", fmt.Errorf("invalid order %q: must be asc or desc", r.URL.Query().Get("order"))
+	}
+
+	// level처럼 중복이 많은 컬럼도 페이지 순서가 고정되도록 id를 보조 정렬 키로 사용
+	if column != "id" {
+		return fmt.Sprintf("%s %s, id %s", column, direction, direction), nil
+	}
+	return fmt.Sprintf("%s %s", column, direction), nil
+}
+
+// groupColumns는 GetStats의 group_by 파라미터로 허용하는 컬럼입니다.
+var groupColumns = map[string]bool{
+	"level":   true,
+	"service": true,
+}
+
+// defaultStatsWindow는 통계 집계 기본 기간입니다.
+const defaultStatsWindow = time.Hour
+
+// GET /logs/stats - 로그 통계 (집계, ?group_by=level|service|level,service&window=1h)
 func (h *ReadHandler) GetStats(w http.ResponseWriter, r *http.Request) {
-	query := `
+	groupBy, err := parseGroupBy(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	window := defaultStatsWindow
+	if windowStr := r.URL.Query().Get("window"); windowStr != "" {
+		d, err := time.ParseDuration(windowStr)
+		if err != nil || d <= 0 {
+			writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid window %q: must be a positive duration (e.g. 30m, 1h)", windowStr))
+			return
+		}
+		window = d
+	}
+
+	// groupBy는 화이트리스트에서만 만들어지므로 쿼리에 직접 넣어도 안전
+	columns := strings.Join(groupBy, ", ")
+	query := fmt.Sprintf(`
 		SELECT
-			level,
+			%s,
 			COUNT(*) as count,
 			MIN(timestamp) as first_seen,
 			MAX(timestamp) as last_seen
 		FROM logs
-		WHERE timestamp > NOW() - INTERVAL '1 hour'
-		GROUP BY level
+		WHERE timestamp > NOW() - $1::interval
+		GROUP BY %s
 		ORDER BY count DESC
-	`
+	`, columns, columns)
 
 	start := time.Now()
-	rows, err := h.db.Query(query)
+	rows, err := h.db.QueryContext(r.Context(), query, fmt.Sprintf("%d microseconds", window.Microseconds()))
+	stats, err := scanStats(rows, err, groupBy)
 	if err != nil {
-		h.collector.RecordFailure()
-		http.Error(w, fmt.Sprintf("Failed to get stats: %v", err), http.StatusInternalServerError)
+		h.collector.RecordFailureWithError(labelGetStats, time.Since(start), err)
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to get stats: %v", err))
 		return
 	}
+
+	latency := time.Since(start)
+	h.collector.RecordSuccess(labelGetStats, latency, len(stats))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"stats":    stats,
+		"group_by": groupBy,
+		"window":   window.String(),
+	})
+}
+
+// scanStats는 (groupBy 컬럼..., count, first_seen, last_seen) 행을 StatsEntry로 읽습니다.
+// scanLogs처럼 쿼리 에러도 함께 받아, 쿼리/Scan/반복 에러를 호출자가 한 곳에서 처리하게 합니다.
+func scanStats(rows *sql.Rows, err error, groupBy []string) ([]StatsEntry, error) {
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	stats := make([]StatsEntry, 0)
 	for rows.Next() {
 		var stat StatsEntry
-		if err := rows.Scan(&stat.Level, &stat.Count, &stat.FirstSeen, &stat.LastSeen); err != nil {
-			h.collector.RecordFailure()
-			http.Error(w, fmt.Sprintf("Failed to scan row: %v", err), http.StatusInternalServerError)
-			return
+		dest := make([]interface{}, 0, len(groupBy)+3)
+		for _, column := range groupBy {
+			switch column {
+			case "level":
+				dest = append(dest, &stat.Level)
+			case "service":
+				dest = append(dest, &stat.Service)
+			}
+		}
+		dest = append(dest, &stat.Count, &stat.FirstSeen, &stat.LastSeen)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan row %d: %w", len(stats)+1, err)
 		}
 		stats = append(stats, stat)
 	}
-
 	if err := rows.Err(); err != nil {
-		h.collector.RecordFailure()
-		http.Error(w, fmt.Sprintf("Row iteration error: %v", err), http.StatusInternalServerError)
-		return
+		return nil, fmt.Errorf("row iteration: %w", err)
 	}
+	return stats, nil
+}
 
-	latency := time.Since(start)
-	h.collector.RecordSuccess(latency)
+// parseGroupBy는 group_by 쿼리 파라미터(쉼표 구분)를 검증합니다. 기본값은 level입니다.
+func parseGroupBy(r *http.Request) ([]string, error) {
+	groupByStr := r.URL.Query().Get("group_by")
+	if groupByStr == "" {
+		return []string{"level"}, nil
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"stats": stats,
-	})
+	seen := make(map[string]bool)
+	groupBy := make([]string, 0, len(groupColumns))
+	for _, column := range strings.Split(groupByStr, ",") {
+		column = strings.TrimSpace(column)
+		if !groupColumns[column] {
+			return nil, fmt.Errorf("invalid group_by %q: must be level, service or level,service", groupByStr)
+		}
+		if !seen[column] {
+			seen[column] = true
+			groupBy = append(groupBy, column)
+		}
+	}
+	return groupBy, nil
 }
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"read-server/metrics"
+	"testing"
+	"time"
+)
+
+// Scan이 중간 행에서 실패하면(타입 불일치) 일부 결과 없이 JSON 500 하나만 쓰고 실패를 한 번만 기록하는지 확인합니다.
+func TestReadHandlerScanError(t *testing.T) {
+	now := time.Now()
+	logColumns := []string{"id", "timestamp", "level", "service", "message"}
+	badLogs := [][]driver.Value{
+		{int64(1), now, "INFO", "api", "ok"},
+		{"not-a-number", now, "INFO", "api", "bad id"},
+	}
+	statsColumns := []string{"level", "count", "first_seen", "last_seen"}
+	badStats := [][]driver.Value{
+		{"INFO", int64(3), now, now},
+		{"ERROR", "many", now, now},
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		columns []string
+		values  [][]driver.Value
+		label   string
+		serve   func(h *ReadHandler) http.HandlerFunc
+	}{
+		{"logs", "/logs", logColumns, badLogs, labelGetLogs, func(h *ReadHandler) http.HandlerFunc { return h.GetLogs }},
+		{"search", "/logs/search?level=INFO", logColumns, badLogs, labelSearchLogs, func(h *ReadHandler) http.HandlerFunc { return h.SearchLogs }},
+		{"stats", "/logs/stats", statsColumns, badStats, labelGetStats, func(h *ReadHandler) http.HandlerFunc { return h.GetStats }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDB{columns: tt.columns, values: tt.values}
+			collector := metrics.NewCollector()
+			h := NewReadHandler(fake.open(t), collector, 0)
+
+			rec := httptest.NewRecorder()
+			tt.serve(h)(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if rec.Code != http.StatusInternalServerError {
+				t.Fatalf("status = %d, want 500", rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Fatalf("Content-Type = %q, want application/json", ct)
+			}
+			dec := json.NewDecoder(rec.Body)
+			var resp ErrorResponse
+			if err := dec.Decode(&resp); err != nil {
+				t.Fatalf("decode error response: %v", err)
+			}
+			if resp.Code != http.StatusInternalServerError || resp.Message == "" {
+				t.Fatalf("error response = %+v", resp)
+			}
+			var extra json.RawMessage
+			if err := dec.Decode(&extra); err != io.EOF {
+				t.Fatalf("body has more than one JSON value (next: %s, err: %v)", extra, err)
+			}
+
+			m := collector.GetMetrics()
+			if m.TotalRequests != 1 || m.FailedRequests != 1 || m.SuccessRequests != 0 {
+				t.Fatalf("total/failed/success = %d/%d/%d, want 1/1/0", m.TotalRequests, m.FailedRequests, m.SuccessRequests)
+			}
+			if got := m.ByLabel[tt.label].FailedRequests; got != 1 {
+				t.Fatalf("by_label[%s].failed_requests = %d, want 1", tt.label, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// MinChaosInterval은 연결 강제 종료 간격의 최솟값입니다.
+	MinChaosInterval = time.Second
+	// chaosKillTimeout은 연결 종료 쿼리 하나의 타임아웃입니다.
+	chaosKillTimeout = 5 * time.Second
+)
+
+// chaosKillQuery는 이 서버와 같은 application_name으로 접속한 다른 연결 하나를 무작위로 골라 종료합니다.
+// 종료할 연결이 없으면 행이 없습니다. 같은 역할(role)의 백엔드는 슈퍼유저가 아니어도 종료할 수 있습니다.
+const chaosKillQuery = `
+	SELECT pid, pg_terminate_backend(pid)
+	FROM pg_stat_activity
+	WHERE application_name = current_setting('application_name')
+	  AND datname = current_database()
+	  AND pid <> pg_backend_pid()
+	ORDER BY random()
+	LIMIT 1
+`
+
+// ChaosStatus는 연결 강제 종료(chaos) 모드의 상태입니다.
+type ChaosStatus struct {
+	IntervalSeconds float64    `json:"interval_seconds"`
+	Kills           int        `json:"kills"`      // 종료한 연결 수
+	Recovered       int        `json:"recovered"`  // 종료 후 다음 확인 전까지 성공한 요청이 있었던 횟수 (마지막 종료는 다음 확인 때 판정)
+	Skipped         int        `json:"skipped"`    // 종료할 연결이 없어 건너뛴 횟수
+	Errors          int        `json:"errors"`     // 종료 쿼리 실패 횟수
+	Reconnects      int        `json:"reconnects"` // 끊긴 워커 전용 연결을 다시 얻은 횟수 (DedicatedConns)
+	LastKillPID     int        `json:"last_kill_pid,omitempty"`
+	LastKillAt      *time.Time `json:"last_kill_at,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+}
+
+// chaosMonitor는 연결 종료 결과와, 종료 직후 부하 생성이 회복되었는지를 기록합니다.
+type chaosMonitor struct {
+	mu     sync.Mutex
+	status ChaosStatus
+
+	// 마지막 종료 시점의 누적 성공 요청 수 (pending이면 다음 확인 때 회복 여부를 판정)
+	pending       bool
+	successAtKill int64
+}
+
+func newChaosMonitor(interval time.Duration) *chaosMonitor {
+	return &chaosMonitor{status: ChaosStatus{IntervalSeconds: interval.Seconds()}}
+}
+
+// checkRecovery는 직전 종료 이후 성공한 요청이 있었으면 회복으로 셉니다.
+// 실행 중 메트릭 초기화로 누적 수가 줄었으면 초기화 이후 성공한 요청이 있는지로 판단합니다.
+func (m *chaosMonitor) checkRecovery(success int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.pending {
+		return
+	}
+	if success > m.successAtKill || (success < m.successAtKill && success > 0) {
+		m.status.Recovered++
+	}
+	m.pending = false
+}
+
+func (m *chaosMonitor) killed(pid int, success int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.status.Kills++
+	m.status.LastKillPID = pid
+	m.status.LastKillAt = &now
+	m.pending = true
+	m.successAtKill = success
+}
+
+func (m *chaosMonitor) skipped() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.status.Skipped++
+}
+
+func (m *chaosMonitor) failed(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.status.Errors++
+	m.status.LastError = err.Error()
+}
+
+func (m *chaosMonitor) reconnected() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.status.Reconnects++
+}
+
+func (m *chaosMonitor) snapshot() ChaosStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.status
+}
+
+// injectChaos는 interval마다 직전 종료에서 회복되었는지 확인한 뒤 연결 하나를 종료합니다.
+// 공유 풀의 끊긴 연결은 database/sql이 버리고 새로 맺으며, 워커 전용 연결은 워커가 다시 얻습니다.
+func (g *Generator) injectChaos(monitor *chaosMonitor, interval time.Duration, stopCh chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			success := g.collector.GetMetrics().SuccessRequests
+			monitor.checkRecovery(success)
+
+			pid, err := g.killConnection()
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				monitor.skipped()
+			case err != nil:
+				log.Printf("Chaos: failed to terminate connection: %v", err)
+				monitor.failed(err)
+			default:
+				log.Printf("Chaos: terminated backend %d", pid)
+				monitor.killed(pid, success)
+			}
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// killConnection은 chaosKillQuery로 연결 하나를 종료하고 그 백엔드 pid를 반환합니다.
+func (g *Generator) killConnection() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), chaosKillTimeout)
+	defer cancel()
+
+	var pid int
+	var terminated bool
+	if err := g.db.QueryRowContext(ctx, chaosKillQuery).Scan(&pid, &terminated); err != nil {
+		return 0, err
+	}
+	if !terminated {
+		return 0, errors.New("pg_terminate_backend returned false")
+	}
+	return pid, nil
+}
+
+// Chaos는 연결 강제 종료 모드의 상태를 반환합니다.
+// ChaosInterval을 지정하고 실행한 적이 없으면 nil입니다. 실행이 끝난 뒤에도 다음 Start까지 유지됩니다.
+func (g *Generator) Chaos() *ChaosStatus {
+	monitor := g.chaos.Load()
+	if monitor == nil {
+		return nil
+	}
+	status := monitor.snapshot()
+	return &status
+}
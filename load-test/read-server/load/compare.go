@@ -0,0 +1,189 @@
+package load
+
+import (
+	"log"
+	"read-server/metrics"
+	"sync"
+	"time"
+)
+
+// IsolationLevels는 격리 수준 비교 모드에서 순환하는 격리 수준입니다.
+var IsolationLevels = []string{"READ COMMITTED", "REPEATABLE READ", "SERIALIZABLE"}
+
+// DefaultIsolationSlice는 비교 모드에서 한 격리 수준을 유지하는 기본 시간입니다.
+const DefaultIsolationSlice = 10 * time.Second
+
+// 비교 모드에서 격리 수준을 바꾸는 단위 (Config.IsolationRotation)
+const (
+	RotationSlice       = "slice"       // IsolationSlice마다 다음 격리 수준으로 전환 (기본값)
+	RotationTransaction = "transaction" // 트랜잭션마다 다음 격리 수준으로 전환
+)
+
+// IsolationResult는 비교 모드에서 격리 수준 하나의 결과입니다.
+// QPS는 전체 경과 시간이 아닌, 해당 격리 수준이 활성화되어 있던 시간 기준입니다.
+// 트랜잭션 단위로 순환하면 모든 격리 수준이 실행 내내 활성이므로 세 QPS의 합이 전체 처리율입니다.
+type IsolationResult struct {
+	IsolationLevel  string  `json:"isolation_level"`
+	ActiveSeconds   float64 `json:"active_seconds"`
+	TotalRequests   int64   `json:"total_requests"`
+	SuccessRequests int64   `json:"success_requests"`
+	FailedRequests  int64   `json:"failed_requests"`
+	TimeoutRequests int64   `json:"timeout_requests"`
+	FailureRate     float64 `json:"failure_rate"` // (실패 + 타임아웃) / 전체
+	QPS             float64 `json:"qps"`
+	AvgLatency      float64 `json:"avg_latency_ms"`
+	P50Latency      float64 `json:"p50_latency_ms"`
+	P95Latency      float64 `json:"p95_latency_ms"`
+	P99Latency      float64 `json:"p99_latency_ms"`
+
+	// 직렬화 충돌(40001)로 실패한 수 (failed_requests에 포함)와 전체 대비 비율
+	SerializationFailures    int64   `json:"serialization_failures"`
+	SerializationFailureRate float64 `json:"serialization_failure_rate"`
+}
+
+// isolationRotation은 한 실행 안에서 격리 수준을 같은 시간 간격으로 번갈아 바꾸고,
+// 격리 수준별 메트릭을 따로 수집합니다. 번갈아 실행하므로 별도 실행 간의 환경 변화(캐시, autovacuum 등)가
+// 특정 격리 수준에만 치우치지 않습니다.
+// perTransaction이면 시간 구간 대신 트랜잭션마다 다음 격리 수준을 사용하므로, 세 격리 수준이
+// 실행 내내 같은 조건(동시에 실행 중인 다른 격리 수준의 트랜잭션 포함)에서 측정됩니다.
+type isolationRotation struct {
+	mu             sync.Mutex
+	perTransaction bool
+	current        int
+	start          time.Time
+	sliceStart     time.Time
+	finished       bool
+	active         map[string]time.Duration
+	collectors     map[string]*metrics.Collector
+	conflicts      map[string]int64
+}
+
+func newIsolationRotation(perTransaction bool) *isolationRotation {
+	now := time.Now()
+	r := &isolationRotation{
+		perTransaction: perTransaction,
+		start:          now,
+		sliceStart:     now,
+		active:         make(map[string]time.Duration, len(IsolationLevels)),
+		collectors:     make(map[string]*metrics.Collector, len(IsolationLevels)),
+		conflicts:      make(map[string]int64, len(IsolationLevels)),
+	}
+	for _, level := range IsolationLevels {
+		r.collectors[level] = metrics.NewCollector()
+	}
+	return r
+}
+
+// level은 현재 격리 수준과 그 수준의 컬렉터를 반환합니다.
+// 쿼리는 시작 시점의 격리 수준으로 실행·기록되므로 구간 경계에 걸쳐도 섞이지 않습니다.
+func (r *isolationRotation) level() (string, *metrics.Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	level := IsolationLevels[r.current]
+	if r.perTransaction {
+		r.current = (r.current + 1) % len(IsolationLevels)
+	}
+	return level, r.collectors[level]
+}
+
+// recordConflict는 level에서 발생한 직렬화 충돌 count건을 기록합니다.
+func (r *isolationRotation) recordConflict(level string, count int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.conflicts[level] += int64(count)
+}
+
+// advance는 현재 구간의 활성 시간을 누적하고 다음 격리 수준으로 넘어갑니다.
+func (r *isolationRotation) advance() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.finished || r.perTransaction {
+		return
+	}
+	now := time.Now()
+	r.active[IsolationLevels[r.current]] += now.Sub(r.sliceStart)
+	r.current = (r.current + 1) % len(IsolationLevels)
+	r.sliceStart = now
+}
+
+// finish는 마지막 구간의 활성 시간을 누적합니다. 이후 advance는 무시됩니다.
+func (r *isolationRotation) finish() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.finished {
+		return
+	}
+	if r.perTransaction {
+		// 모든 격리 수준이 실행 내내 활성
+		for _, level := range IsolationLevels {
+			r.active[level] = time.Since(r.start)
+		}
+	} else {
+		r.active[IsolationLevels[r.current]] += time.Since(r.sliceStart)
+	}
+	r.finished = true
+}
+
+// results는 격리 수준별 결과를 IsolationLevels 순서로 반환합니다.
+func (r *isolationRotation) results() []IsolationResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]IsolationResult, 0, len(IsolationLevels))
+	for i, level := range IsolationLevels {
+		active := r.active[level]
+		switch {
+		case r.finished:
+		case r.perTransaction:
+			active = time.Since(r.start)
+		case i == r.current:
+			active += time.Since(r.sliceStart)
+		}
+
+		m := r.collectors[level].GetMetrics()
+		rate := 0.0
+		if active > 0 {
+			rate = float64(m.TotalRequests) / active.Seconds()
+		}
+		conflicts := r.conflicts[level]
+		failureRate, conflictRate := 0.0, 0.0
+		if m.TotalRequests > 0 {
+			failureRate = float64(m.FailedRequests+m.TimeoutRequests) / float64(m.TotalRequests)
+			conflictRate = float64(conflicts) / float64(m.TotalRequests)
+		}
+
+		results = append(results, IsolationResult{
+			IsolationLevel:  level,
+			ActiveSeconds:   active.Seconds(),
+			TotalRequests:   m.TotalRequests,
+			SuccessRequests: m.SuccessRequests,
+			FailedRequests:  m.FailedRequests,
+			TimeoutRequests: m.TimeoutRequests,
+			FailureRate:     failureRate,
+			QPS:             rate,
+			AvgLatency:      m.AvgLatency,
+			P50Latency:      m.P50Latency,
+			P95Latency:      m.P95Latency,
+			P99Latency:      m.P99Latency,
+
+			SerializationFailures:    conflicts,
+			SerializationFailureRate: conflictRate,
+		})
+	}
+	return results
+}
+
+// logIsolationComparison은 격리 수준별 결과를 나란히 비교할 수 있도록 로그에 표로 출력합니다.
+func logIsolationComparison(results []IsolationResult) {
+	log.Printf("Isolation level comparison:")
+	log.Printf("  %-16s %8s %10s %8s %8s %9s %9s %9s %9s %9s", "level", "active_s", "qps", "failed", "fail_%", "conflicts", "avg_ms", "p50_ms", "p95_ms", "p99_ms")
+	for _, r := range results {
+		log.Printf("  %-16s %8.1f %10.2f %8d %8.2f %9d %9.2f %9.2f %9.2f %9.2f",
+			r.IsolationLevel, r.ActiveSeconds, r.QPS, r.FailedRequests, r.FailureRate*100, r.SerializationFailures,
+			r.AvgLatency, r.P50Latency, r.P95Latency, r.P99Latency)
+	}
+}
@@ -2,6 +2,9 @@ package load
 
 import (
 	"fmt"
+	"math/rand"
+	"net/url"
+	"read-server/metrics"
 	"time"
 )
 
@@ -17,19 +20,118 @@ type Config struct {
 	Duration       time.Duration `json:"duration"`        // 테스트 지속 시간 (0 = 무제한)
 	QueryMix       QueryMix      `json:"query_mix"`       // 쿼리 타입 비율
 	IsolationLevel string        `json:"isolation_level"` // READ COMMITTED, REPEATABLE READ, SERIALIZABLE
+
+	// 사용자 지정 쿼리: 설정하면 QueryMix 대신 이 쿼리들을 가중치에 따라 골라 실행 (custom.go)
+	// replay_file, verify_rows, query_protocol: simple과 함께 쓸 수 없음
+	CustomQueries []CustomQuery `json:"custom_queries,omitempty"`
+
+	// 쿼리 프로토콜: extended($1 파라미터, 기본값) 또는 simple(인자를 리터럴로 인라인). 인자가 있는 filter 쿼리에만 영향
+	QueryProtocol string `json:"query_protocol"`
+
+	// 준비된 문장 재사용: 실행 시작 시 세 읽기 쿼리를 한 번 준비하고 워커가 매번 그 문장을 실행 (파싱/계획 생략)
+	// false면 매번 쿼리 텍스트를 보냄 (ad-hoc). 인자를 인라인하는 query_protocol: simple과는 함께 쓸 수 없음
+	UsePrepared bool `json:"use_prepared"`
+
+	// 쿼리 타임아웃 (0 = 무제한). 초과하면 쿼리를 취소하고 실패가 아닌 타임아웃으로 집계
+	QueryTimeout time.Duration `json:"query_timeout"`
+
+	// 워커 전용 연결 모드: 각 워커가 풀에서 연결 하나를 꺼내 실행이 끝날 때까지 고정 사용 (연결 획득 경합 제거)
+	// 워커 수가 풀의 최대 연결 수(max_open_conns, 기본값 50)를 넘으면 남는 워커는 연결을 얻지 못해 대기합니다.
+	DedicatedConns bool `json:"dedicated_conns"`
+
+	// 연결 풀 설정: Start할 때 부하 생성기의 풀에 적용 (0 = 서버 기본값, 즉 50 / DB_MAX_IDLE_CONNS / DB_CONN_MAX_LIFETIME)
+	// 실행 중에는 바꿀 수 없으며(SIGHUP 리로드도 거부), 풀은 서버의 다른 요청과 공유하므로 실행이 끝나면 서버 기본값으로 되돌립니다 (pool.go)
+	MaxOpenConns    int           `json:"max_open_conns"`
+	MaxIdleConns    int           `json:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
+
+	// 워커 시작 간격: i번째 워커를 i × WorkerStartStagger 뒤에 시작 (0 = 모든 워커 동시 시작)
+	// 시작 직후 모든 워커가 한꺼번에 연결을 만드는 연결 폭주를 완화합니다.
+	WorkerStartStagger time.Duration `json:"worker_start_stagger"`
+
+	// 램프업: 워커를 RampUp 구간에 고르게 나눠 시작 (i번째 워커는 RampUp × i / Workers 뒤, 0 = 사용 안 함)
+	// 워커 수와 상관없이 RampUp이 지나면 모든 워커가 실행 중입니다. WorkerStartStagger와 함께 쓸 수 없으며,
+	// WarmupExclude를 지정하지 않으면 램프업 구간에 시작한 요청을 워밍업으로 보고 주 백분위수에서 제외합니다.
+	RampUp time.Duration `json:"ramp_up"`
+
+	// 생각 시간(think time): 워커가 쿼리 하나를 끝낼 때마다 ThinkTime + [0, ThinkTimeJitter) 동안 쉰 뒤 다음 쿼리를 보냄 (0 = 쉬지 않음)
+	// 요청 사이에 멈추는 실제 클라이언트를 흉내 냅니다. QPS가 설정되어 있으면 QPS는 여전히 상한이며, replay_timing과 함께 쓸 수 없음
+	ThinkTime       time.Duration `json:"think_time"`
+	ThinkTimeJitter time.Duration `json:"think_time_jitter"`
+
+	// Start 시 메트릭 초기화 여부 (생략 시 true). false면 여러 번의 짧은 실행을 하나의 메트릭으로 누적
+	ResetOnStart *bool `json:"reset_on_start,omitempty"`
+
+	// 실행 종료(자동/수동) 시 최종 메트릭을 POST할 URL (빈 값 = 사용 안 함)
+	CompletionWebhook string `json:"completion_webhook,omitempty"`
+
+	// 격리 수준 비교 모드: 한 실행 안에서 세 격리 수준을 IsolationSlice마다 번갈아 실행 (IsolationLevel 무시)
+	CompareIsolation bool          `json:"compare_isolation"`
+	IsolationSlice   time.Duration `json:"isolation_slice"` // 격리 수준 하나를 유지하는 시간 (0 = 10초)
+	// 격리 수준을 바꾸는 단위: "slice"(IsolationSlice마다, 기본) 또는 "transaction"(트랜잭션마다 순환)
+	IsolationRotation string `json:"isolation_rotation,omitempty"`
+
+	// 수렴 감지: ConvergenceInterval마다 누적 p95를 확인해, ConvergenceWindow번 연속으로
+	// 기준값의 ±ConvergenceTolerance 안에 머물면 수렴으로 표시 (/load/status의 convergence)
+	ConvergenceInterval  time.Duration `json:"convergence_interval"`  // 확인 간격 (0 = 사용 안 함)
+	ConvergenceTolerance float64       `json:"convergence_tolerance"` // 허용 변동 비율 (0 = 0.05, 즉 ±5%)
+	ConvergenceWindow    int           `json:"convergence_window"`    // 필요한 연속 구간 수 (0 = 5)
+	StopOnConvergence    bool          `json:"stop_on_convergence"`   // 수렴하면 자동 종료
+
+	// 조기 중단: 최근 ErrorRateWindow 동안의 에러율((실패 + 타임아웃 + 연결 끊김) / 전체)이 MaxErrorRate를 넘으면
+	// 실행을 중지하고 실패로 표시 (/load/status의 fail_fast). CI에서 이미 실패한 실행에 시간을 쓰지 않기 위함
+	MaxErrorRate    float64       `json:"max_error_rate"`    // 허용 에러율 0~1 (0 = 사용 안 함)
+	ErrorRateWindow time.Duration `json:"error_rate_window"` // 에러율 계산 윈도우 (0 = 10초)
+
+	// 지연시간 샘플링: 성공한 요청 중 이 비율만 지연시간을 기록 (0 < rate <= 1, 0 = 1)
+	// 요청 수/처리율은 항상 정확하고, 백분위수는 표본 기준, 히스토그램/히트맵 건수는 1/rate 배로 보정
+	LatencySampleRate float64 `json:"latency_sample_rate"`
+
+	// 보고할 지연시간 백분위수 (0 < p < 100, 최대 20개, 비어 있으면 [50, 95, 99]). /metrics의 percentiles에 "p75", "p99.9" 형식 키로 보고
+	Percentiles []float64 `json:"percentiles,omitempty"`
+
+	// 워밍업 제외: 실행 시작 후 이 시간 안에 시작한 요청의 지연시간은 주 백분위수에서 빼고
+	// /metrics의 warmup에 따로 보고 (0 = 사용 안 함). 요청 수/처리율 카운터에는 그대로 포함
+	WarmupExclude time.Duration `json:"warmup_exclude"`
+
+	// 재생 모드: 서버가 읽을 수 있는 NDJSON 파일의 쿼리를 파일 순서대로 실행 (QueryMix 무시)
+	// 파일 끝에 도달하면 Duration이 설정된 경우 처음부터 반복하고, 아니면 실행을 종료
+	ReplayFile   string `json:"replay_file,omitempty"`
+	ReplayTiming bool   `json:"replay_timing"` // 기록된 timestamp 간격대로 쿼리를 보냄 (QPS 무시)
+
+	// 연결 강제 종료(chaos): 이 간격마다 이 서버의 DB 연결 하나를 pg_terminate_backend로 종료 (0 = 사용 안 함, 최소 1초)
+	// 끊긴 연결로 실패한 요청은 connection_errors로 따로 집계하고, 워커 전용 연결은 새로 얻어 계속 실행
+	ChaosInterval time.Duration `json:"chaos_interval"`
+
+	// 첫 행 vs 전체 fetch 지연시간: 부하 생성기 쿼리마다 첫 행까지와 마지막 행까지 시간을 따로 기록해
+	// /metrics의 fetch_latency로 보고 (쿼리 실행 시작이 느린지, 결과 전송이 느린지 구분)
+	FetchLatency bool `json:"fetch_latency"`
+
+	// 듀티 사이클(버스트 부하): DutyActive 동안 목표 처리율로 부하를 생성하고 DutyIdle 동안 멈추는 것을 반복 (둘 다 0 = 사용 안 함, 각각 최소 100ms)
+	// 연결 풀이 늘었다 줄어드는 상황과 쉬는 동안 식은 캐시를 재현하며, 활성 구간별 메트릭을 /load/status의 duty_cycle로 보고
+	DutyActive time.Duration `json:"duty_active"`
+	DutyIdle   time.Duration `json:"duty_idle"`
+
+	// 결과 행 검증: 부하 생성기 쿼리마다 결과 행의 체크섬을 계산하고 불변식(timestamp 내림차순, id 중복 없음, filter 조건 일치,
+	// 같은 워커의 최신 행이 과거로 돌아가지 않음)을 확인해 위반을 /load/status의 row_verification으로 보고
+	// 동시 쓰기 중 격리 수준과 레플리카 읽기의 일관성을 확인하기 위한 모드로, 처리율 측정과는 따로 실행하는 것을 권장
+	VerifyRows bool `json:"verify_rows"`
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		QPS:     1000,
-		Workers: 10,
+		QPS:      1000,
+		Workers:  10,
 		Duration: 0,
 		QueryMix: QueryMix{
 			Simple:    60, // 60%
 			Filter:    30, // 30%
 			Aggregate: 10, // 10%
 		},
-		IsolationLevel: "READ COMMITTED",
+		IsolationLevel:    "READ COMMITTED",
+		LatencySampleRate: 1.0,
+		Percentiles:       append([]float64(nil), metrics.DefaultPercentiles...),
+		QueryProtocol:     ProtocolExtended,
 	}
 }
 
@@ -43,15 +145,132 @@ func (c *Config) Validate() error {
 	if c.Duration < 0 {
 		c.Duration = 0
 	}
+	if c.QueryTimeout < 0 {
+		c.QueryTimeout = 0
+	}
+	if c.WorkerStartStagger < 0 {
+		c.WorkerStartStagger = 0
+	}
+	if c.RampUp < 0 {
+		c.RampUp = 0
+	}
+	if c.RampUp > 0 && c.WorkerStartStagger > 0 {
+		return fmt.Errorf("ramp_up and worker_start_stagger cannot be used together")
+	}
+	if c.MaxOpenConns < 0 || c.MaxIdleConns < 0 || c.ConnMaxLifetime < 0 {
+		return fmt.Errorf("max_open_conns, max_idle_conns and conn_max_lifetime must not be negative, got %d, %d and %s", c.MaxOpenConns, c.MaxIdleConns, c.ConnMaxLifetime)
+	}
+	if c.ResetOnStart == nil {
+		resetOnStart := true
+		c.ResetOnStart = &resetOnStart
+	}
+
+	// QueryMix 정규화 (사용자 지정 쿼리가 있으면 QueryMix는 쓰지 않음)
+	if len(c.CustomQueries) == 0 {
+		total := c.QueryMix.Simple + c.QueryMix.Filter + c.QueryMix.Aggregate
+		if total != 100 {
+			return fmt.Errorf("query_mix percentages must sum to 100, got %d", total)
+		}
+
+		if c.QueryMix.Simple < 0 || c.QueryMix.Filter < 0 || c.QueryMix.Aggregate < 0 {
+			return fmt.Errorf("query_mix percentages must be non-negative")
+		}
+	}
+
+	if c.CompletionWebhook != "" {
+		u, err := url.Parse(c.CompletionWebhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("completion_webhook must be an absolute http(s) URL, got %q", c.CompletionWebhook)
+		}
+	}
+
+	if c.IsolationSlice <= 0 {
+		c.IsolationSlice = DefaultIsolationSlice
+	}
+	switch c.IsolationRotation {
+	case "":
+		c.IsolationRotation = RotationSlice
+	case RotationSlice, RotationTransaction:
+	default:
+		return fmt.Errorf("isolation_rotation must be %q or %q, got %q", RotationSlice, RotationTransaction, c.IsolationRotation)
+	}
+
+	if c.MaxErrorRate < 0 || c.MaxErrorRate > 1 {
+		return fmt.Errorf("max_error_rate must be between 0 and 1, got %g", c.MaxErrorRate)
+	}
+	if c.ErrorRateWindow <= 0 {
+		c.ErrorRateWindow = DefaultErrorRateWindow
+	}
+
+	if c.LatencySampleRate < 0 || c.LatencySampleRate > 1 {
+		return fmt.Errorf("latency_sample_rate must be between 0 and 1, got %g", c.LatencySampleRate)
+	}
+	if c.LatencySampleRate == 0 {
+		c.LatencySampleRate = 1
+	}
+	if len(c.Percentiles) == 0 {
+		c.Percentiles = append([]float64(nil), metrics.DefaultPercentiles...)
+	}
+	if len(c.Percentiles) > metrics.MaxPercentiles {
+		return fmt.Errorf("percentiles must have at most %d entries, got %d", metrics.MaxPercentiles, len(c.Percentiles))
+	}
+	requested := make(map[float64]bool, len(c.Percentiles))
+	for _, p := range c.Percentiles {
+		if !(p > 0 && p < 100) {
+			return fmt.Errorf("percentiles must be between 0 and 100 (exclusive), got %g", p)
+		}
+		if requested[p] {
+			return fmt.Errorf("percentiles contains %g more than once", p)
+		}
+		requested[p] = true
+	}
+	if c.WarmupExclude < 0 {
+		c.WarmupExclude = 0
+	}
+
+	if c.ChaosInterval < 0 {
+		c.ChaosInterval = 0
+	}
+	if c.ChaosInterval > 0 && c.ChaosInterval < MinChaosInterval {
+		return fmt.Errorf("chaos_interval must be at least %s, got %s", MinChaosInterval, c.ChaosInterval)
+	}
+
+	if c.ReplayTiming && c.ReplayFile == "" {
+		return fmt.Errorf("replay_timing requires replay_file")
+	}
+	if c.ThinkTime < 0 || c.ThinkTimeJitter < 0 {
+		return fmt.Errorf("think_time and think_time_jitter must not be negative, got %s and %s", c.ThinkTime, c.ThinkTimeJitter)
+	}
+	if c.ReplayTiming && c.ThinkTime+c.ThinkTimeJitter > 0 {
+		return fmt.Errorf("think_time cannot be combined with replay_timing (the replay file decides when to send)")
+	}
+	if err := c.validateDutyCycle(); err != nil {
+		return err
+	}
 
-	// QueryMix 정규화
-	total := c.QueryMix.Simple + c.QueryMix.Filter + c.QueryMix.Aggregate
-	if total != 100 {
-		return fmt.Errorf("query_mix percentages must sum to 100, got %d", total)
+	if c.ConvergenceInterval < 0 {
+		c.ConvergenceInterval = 0
+	}
+	if c.ConvergenceTolerance <= 0 {
+		c.ConvergenceTolerance = DefaultConvergenceTolerance
+	}
+	if c.ConvergenceWindow < 1 {
+		c.ConvergenceWindow = DefaultConvergenceWindow
 	}
 
-	if c.QueryMix.Simple < 0 || c.QueryMix.Filter < 0 || c.QueryMix.Aggregate < 0 {
-		return fmt.Errorf("query_mix percentages must be non-negative")
+	switch c.QueryProtocol {
+	case "":
+		c.QueryProtocol = ProtocolExtended
+	case ProtocolExtended, ProtocolSimple:
+		// 유효한 값
+	default:
+		return fmt.Errorf("query_protocol must be %q or %q, got %q", ProtocolExtended, ProtocolSimple, c.QueryProtocol)
+	}
+	if c.UsePrepared && c.QueryProtocol == ProtocolSimple {
+		return fmt.Errorf("use_prepared cannot be combined with query_protocol %q", ProtocolSimple)
+	}
+	if err := c.validateCustomQueries(); err != nil {
+		return err
 	}
 
 	// 격리 수준 정규화
@@ -64,3 +283,32 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+// shouldResetOnStart는 Start 시 메트릭을 초기화해야 하는지 반환합니다 (Validate 전이면 기본값 true).
+func (c *Config) shouldResetOnStart() bool {
+	return c.ResetOnStart == nil || *c.ResetOnStart
+}
+
+// workerStartDelay는 i번째 워커가 첫 요청 전에 기다리는 시간입니다 (RampUp 또는 WorkerStartStagger).
+func (c *Config) workerStartDelay(i int) time.Duration {
+	if c.RampUp > 0 {
+		return c.RampUp * time.Duration(i) / time.Duration(c.Workers)
+	}
+	return time.Duration(i) * c.WorkerStartStagger
+}
+
+// thinkDelay는 쿼리 하나를 끝낸 워커가 다음 쿼리 전에 쉬는 시간입니다 (ThinkTime 이상, ThinkTime + ThinkTimeJitter 미만).
+func (c *Config) thinkDelay() time.Duration {
+	if c.ThinkTimeJitter <= 0 {
+		return c.ThinkTime
+	}
+	return c.ThinkTime + time.Duration(rand.Int63n(int64(c.ThinkTimeJitter)))
+}
+
+// warmupWindow는 주 백분위수에서 제외할 워밍업 구간입니다. WarmupExclude를 지정하지 않았으면 램프업 구간입니다.
+func (c *Config) warmupWindow() time.Duration {
+	if c.WarmupExclude > 0 {
+		return c.WarmupExclude
+	}
+	return c.RampUp
+}
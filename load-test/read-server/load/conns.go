@@ -0,0 +1,115 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"math/rand"
+	"time"
+)
+
+// txBeginner는 트랜잭션을 시작할 수 있는 연결입니다 (공유 풀 *sql.DB 또는 워커 전용 *sql.Conn).
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// workerConn은 워커가 쿼리에 사용할 연결과 반납 함수를 반환합니다.
+// DedicatedConns면 풀에서 연결 하나를 꺼내 워커가 끝날 때까지 고정하므로, 쿼리마다 풀에서 연결을 얻는 비용과 경합이 없습니다.
+// 워커 수가 풀의 최대 연결 수보다 많으면 남는 워커는 연결을 기다리며, stopCh가 닫히면 context.Canceled로 포기합니다.
+func (g *Generator) workerConn(stopCh <-chan struct{}) (txBeginner, func(), error) {
+	if !g.config.DedicatedConns {
+		return g.db, func() {}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	conn, err := g.db.Conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, func() { conn.Close() }, nil
+}
+
+// reconnect는 끊긴 연결을 반납하고 workerConn으로 다시 얻습니다.
+// 워커 전용 연결은 한 번 끊기면 이후 쿼리가 모두 실패하므로 연결 에러가 나면 호출합니다
+// (공유 풀의 끊긴 연결은 database/sql이 버리고 새로 맺으므로 필요 없음).
+// DB가 아직 내려가 있으면 backoff만큼 기다리며 다시 시도하고, 중지되거나 연결 에러가 아닌 에러가 나면
+// 아무것도 하지 않는 반납 함수와 에러를 반환합니다.
+func (g *Generator) reconnect(release func(), backoff *reconnectBackoff, stopCh <-chan struct{}) (txBeginner, func(), error) {
+	release()
+	for {
+		conn, release, err := g.workerConn(stopCh)
+		if err == nil {
+			if monitor := g.chaos.Load(); monitor != nil {
+				monitor.reconnected()
+			}
+			return conn, release, nil
+		}
+		// 연결을 기다리는 중에 중지된 경우는 정상 종료
+		if errors.Is(err, context.Canceled) {
+			return nil, func() {}, err
+		}
+		if !isConnectionError(err) {
+			log.Printf("Worker failed to reacquire dedicated connection: %v", err)
+			return nil, func() {}, err
+		}
+		if !backoff.wait(stopCh) {
+			return nil, func() {}, context.Canceled
+		}
+	}
+}
+
+// 연결 에러 뒤 재시도 전 대기 시간 (연결 에러가 이어질 때마다 두 배로 늘림)
+const (
+	minReconnectBackoff = 100 * time.Millisecond
+	maxReconnectBackoff = 5 * time.Second
+)
+
+// reconnectBackoff는 워커가 연결 에러 뒤 다음 요청(또는 재연결)까지 기다리는 시간입니다.
+// DB가 재시작되는 동안 워커가 실패를 쉬지 않고 반복하지 않도록, 성공할 때까지 대기 시간을 지수적으로 늘립니다.
+type reconnectBackoff struct {
+	delay time.Duration // 다음 대기 시간 (0이면 연결 에러 없이 성공 중)
+}
+
+// wait는 현재 대기 시간에 지터를 더해 기다리고 다음 대기 시간을 두 배로 늘립니다 (최대 maxReconnectBackoff).
+// 워커들이 같은 순간에 몰려 재시도하지 않도록 대기 시간의 절반~전체 사이에서 무작위로 고릅니다. stopCh가 닫히면 false를 반환합니다.
+func (b *reconnectBackoff) wait(stopCh <-chan struct{}) bool {
+	if b.delay == 0 {
+		b.delay = minReconnectBackoff
+	}
+	d := b.delay/2 + time.Duration(rand.Int63n(int64(b.delay/2)+1))
+	if b.delay *= 2; b.delay > maxReconnectBackoff {
+		b.delay = maxReconnectBackoff
+	}
+	return waitUntil(time.Now().Add(d), stopCh)
+}
+
+// connLost는 연결 에러를 본 워커가 호출합니다. 끊긴 구간이 새로 시작되었으면 로그를 남기고, 백오프만큼 기다립니다.
+// stopCh가 닫히면 false를 반환합니다.
+func (g *Generator) connLost(backoff *reconnectBackoff, err error, stopCh <-chan struct{}) bool {
+	if g.collector.RecordDisconnect(time.Now()) {
+		log.Printf("Database connection lost: %v (workers backing off and retrying)", err)
+	}
+	return backoff.wait(stopCh)
+}
+
+// connRestored는 요청이 성공한 워커가 호출합니다. 연결 에러 뒤 처음 성공한 것이면 백오프를 초기화하고,
+// 끊긴 구간을 닫은 워커가 복구까지 걸린 시간을 로그로 남깁니다.
+func (g *Generator) connRestored(backoff *reconnectBackoff) {
+	if backoff.delay == 0 {
+		return
+	}
+	backoff.delay = 0
+	if downtime, ok := g.collector.RecordRecovered(time.Now()); ok {
+		log.Printf("Database connection restored after %s", downtime.Round(time.Millisecond))
+	}
+}
@@ -0,0 +1,135 @@
+package load
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// CustomQuery는 사용자가 지정한 읽기 쿼리입니다 (Config.CustomQueries).
+// 내장 simple/filter/aggregate 쿼리 대신 자신의 스키마에 부하를 줄 때 씁니다.
+type CustomQuery struct {
+	// 메트릭 라벨 (/metrics의 by_label, 생략하면 custom_1, custom_2, ... 순서대로)
+	Name string `json:"name,omitempty"`
+	SQL  string `json:"sql"`
+	// 선택 가중치: 모든 쿼리의 가중치 합에 대한 비율로 선택 (0 = 선택하지 않음)
+	Weight int `json:"weight"`
+	// $1, $2, ... 인자 (문자열, 숫자, bool, null)
+	Args []interface{} `json:"args,omitempty"`
+}
+
+// validateCustomQueries는 CustomQueries를 검증하고 생략한 이름을 채웁니다.
+func (c *Config) validateCustomQueries() error {
+	if len(c.CustomQueries) == 0 {
+		return nil
+	}
+	switch {
+	case c.ReplayFile != "":
+		return fmt.Errorf("custom_queries cannot be combined with replay_file")
+	case c.VerifyRows:
+		return fmt.Errorf("custom_queries cannot be combined with verify_rows (row invariants assume the logs queries)")
+	case c.QueryProtocol == ProtocolSimple:
+		return fmt.Errorf("custom_queries cannot be combined with query_protocol %q", ProtocolSimple)
+	}
+
+	names := make(map[string]bool, len(c.CustomQueries))
+	totalWeight := 0
+	for i := range c.CustomQueries {
+		q := &c.CustomQueries[i]
+		if strings.TrimSpace(q.SQL) == "" {
+			return fmt.Errorf("custom_queries[%d].sql must not be empty", i)
+		}
+		if q.Weight < 0 {
+			return fmt.Errorf("custom_queries[%d].weight must not be negative, got %d", i, q.Weight)
+		}
+		totalWeight += q.Weight
+
+		if q.Name == "" {
+			q.Name = fmt.Sprintf("custom_%d", i+1)
+		}
+		switch {
+		case q.Name == "simple" || q.Name == "filter" || q.Name == "aggregate":
+			return fmt.Errorf("custom_queries[%d].name %q is reserved for the built-in queries", i, q.Name)
+		case names[q.Name]:
+			return fmt.Errorf("custom_queries contains name %q more than once", q.Name)
+		}
+		names[q.Name] = true
+
+		for j, arg := range q.Args {
+			if _, err := driver.DefaultParameterConverter.ConvertValue(arg); err != nil {
+				return fmt.Errorf("custom_queries[%d].args[%d]: %v", i, j, err)
+			}
+		}
+	}
+	if totalWeight <= 0 {
+		return fmt.Errorf("custom_queries weights must sum to a positive number, got %d", totalWeight)
+	}
+	return nil
+}
+
+// selectCustomQuery는 가중치에 따라 CustomQueries 중 하나를 골라 그 이름을 반환합니다.
+func (g *Generator) selectCustomQuery() string {
+	totalWeight := 0
+	for _, q := range g.config.CustomQueries {
+		totalWeight += q.Weight
+	}
+
+	r := rand.Intn(totalWeight)
+	for _, q := range g.config.CustomQueries {
+		if r < q.Weight {
+			return q.Name
+		}
+		r -= q.Weight
+	}
+	// Validate가 가중치 합을 양수로 보장하므로 도달하지 않음
+	return g.config.CustomQueries[len(g.config.CustomQueries)-1].Name
+}
+
+// customQuery는 이름이 name인 CustomQuery를 찾습니다.
+func (g *Generator) customQuery(name string) (CustomQuery, bool) {
+	for _, q := range g.config.CustomQueries {
+		if q.Name == name {
+			return q, true
+		}
+	}
+	return CustomQuery{}, false
+}
+
+// runCustomQuery는 내장 쿼리와 같은 방식(격리 수준을 지정한 트랜잭션)으로 q를 실행하고 결과 행을 모두 읽습니다.
+// 결과의 형태를 모르므로 행의 값은 읽지 않고 건수만 셉니다.
+func (g *Generator) runCustomQuery(ctx context.Context, conn txBeginner, isolation string, q CustomQuery) (time.Duration, int, fetchTimer, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, fetchTimer{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET TRANSACTION ISOLATION LEVEL %s", isolation)); err != nil {
+		return 0, 0, fetchTimer{}, err
+	}
+
+	fetch := newFetchTimer()
+	rows, err := g.queryRows(ctx, tx, q.SQL, q.Args...)
+	if err != nil {
+		return 0, 0, fetchTimer{}, err
+	}
+	defer rows.Close()
+
+	rowCount := 0
+	for fetch.next(rows) {
+		rowCount++
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, 0, fetchTimer{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fetchTimer{}, err
+	}
+
+	return time.Since(fetch.start), rowCount, fetch, nil
+}
@@ -0,0 +1,53 @@
+package load
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// 가중치 70/30인 사용자 지정 쿼리 두 개를 실행하면 관측한 비율이 허용 오차 안에서 70/30이고,
+// 내장 쿼리 대신 지정한 SQL만 실행하는지 확인합니다.
+func TestCustomQueriesWeightedSplit(t *testing.T) {
+	const (
+		hotSQL  = "SELECT id FROM orders WHERE status = $1"
+		coldSQL = "SELECT count(*) FROM archive"
+		samples = 2000
+	)
+	fake := &fakeDB{}
+	config := DefaultConfig()
+	config.QPS = 0
+	config.Workers = 2
+	config.CustomQueries = []CustomQuery{
+		{Name: "hot", SQL: hotSQL, Weight: 70, Args: []interface{}{"open"}},
+		{Name: "cold", SQL: coldSQL, Weight: 30},
+	}
+	g := newTestGenerator(t, fake, config)
+
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	waitFor(t, 10*time.Second, "custom query samples", func() bool {
+		total, _ := g.collector.RequestCounts()
+		return total >= samples
+	})
+	m := g.Stop()
+
+	if len(m.ByLabel) != 2 {
+		t.Fatalf("by_label = %+v, want only hot and cold", m.ByLabel)
+	}
+	hot, cold := m.ByLabel["hot"].SuccessRequests, m.ByLabel["cold"].SuccessRequests
+	if hot+cold != m.TotalRequests || m.FailedRequests != 0 {
+		t.Fatalf("hot %d + cold %d != total %d (failed %d)", hot, cold, m.TotalRequests, m.FailedRequests)
+	}
+	// 2000개 이상이면 표준편차는 약 1%p이므로 5%p는 충분한 여유
+	if share := float64(hot) / float64(hot+cold); math.Abs(share-0.7) > 0.05 {
+		t.Fatalf("hot share = %.3f (%d/%d), want 0.70 ± 0.05", share, hot, hot+cold)
+	}
+
+	for _, q := range fake.history() {
+		if q.query != hotSQL && q.query != coldSQL {
+			t.Fatalf("executed %q, want only the custom queries", q.query)
+		}
+	}
+}
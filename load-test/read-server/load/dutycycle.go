@@ -0,0 +1,303 @@
+package load
+
+import (
+	"fmt"
+	"log"
+	"read-server/metrics"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// MinDutyPhase는 듀티 사이클의 활성/휴지 구간 하나의 최소 길이입니다.
+	MinDutyPhase = 100 * time.Millisecond
+	// maxDutyWindows는 상태에 보관하는 최근 활성 구간 수입니다.
+	maxDutyWindows = 20
+	// maxDutyWindowSamples는 활성 구간 하나에 보관하는 최대 지연시간 샘플 수입니다.
+	maxDutyWindowSamples = 100000
+)
+
+// 듀티 사이클의 현재 단계 (DutyCycleStatus.Phase)
+const (
+	DutyPhaseActive = "active"
+	DutyPhaseIdle   = "idle"
+)
+
+// loadPattern은 시간에 따라 부하 생성을 조절하는 패턴입니다.
+// 워커는 요청마다 (처리율 제한 틱을 기다리기 전에) pausedUntil을 확인하고, 쉬어야 하면 반환된 시각까지 기다립니다.
+// 활성 구간에서는 설정한 목표 처리율(qps/tps) 그대로, 또는 제한이 없으면 최대 속도로 요청을 보냅니다.
+type loadPattern interface {
+	// pausedUntil은 now가 쉬는 구간이면 다음 활성 구간의 시작 시각을, 활성 구간이면 zero time을 반환합니다.
+	pausedUntil(now time.Time) time.Time
+}
+
+// DutyWindow는 듀티 사이클의 활성 구간 하나의 결과입니다.
+// 요청 수는 활성 구간 시작부터 다음 활성 구간 시작까지의 증가분이므로, 활성 구간 끝에 진행 중이던 요청도 포함합니다.
+type DutyWindow struct {
+	Index         int       `json:"index"` // 0부터 시작하는 활성 구간 번호
+	StartedAt     time.Time `json:"started_at"`
+	ActiveSeconds float64   `json:"active_seconds"` // 실제 활성 시간 (진행 중이면 지금까지)
+	InProgress    bool      `json:"in_progress,omitempty"`
+
+	TotalRequests   int64   `json:"total_requests"`
+	SuccessRequests int64   `json:"success_requests"`
+	ErrorRequests   int64   `json:"error_requests"` // 실패 + 타임아웃 + 연결 끊김
+	Rate            float64 `json:"rate"`           // success_requests / active_seconds
+
+	AvgLatency float64 `json:"avg_latency_ms"`
+	P50Latency float64 `json:"p50_latency_ms"`
+	P95Latency float64 `json:"p95_latency_ms"`
+	P99Latency float64 `json:"p99_latency_ms"`
+}
+
+// DutyCycleStatus는 듀티 사이클 모드의 상태입니다.
+type DutyCycleStatus struct {
+	ActiveSeconds float64      `json:"active_seconds"` // 설정한 활성 구간 길이
+	IdleSeconds   float64      `json:"idle_seconds"`   // 설정한 휴지 구간 길이
+	Phase         string       `json:"phase"`          // 지금 단계 (실행이 끝났으면 마지막 단계)
+	Cycles        int          `json:"cycles"`         // 시작한 활성 구간 수
+	Windows       []DutyWindow `json:"windows"`        // 최근 활성 구간 (진행 중인 구간 포함, 최대 20개)
+}
+
+// dutyWindowState는 진행 중인 활성 구간입니다.
+type dutyWindowState struct {
+	index     int
+	start     time.Time
+	activeEnd time.Time // 휴지 구간이 시작된 시각 (아직 활성이면 zero)
+	base      metrics.Metrics
+	latencies []time.Duration
+}
+
+// dutyCycle은 active 동안 부하를 생성하고 idle 동안 멈추는 것을 반복하는 패턴입니다.
+// 단계는 실행 시작 시각부터의 경과 시간으로 계산하므로 워커는 잠금 없이 확인하고,
+// runDutyCycle 고루틴이 구간 경계마다 활성 구간별 메트릭을 정리합니다.
+type dutyCycle struct {
+	active    time.Duration
+	idle      time.Duration
+	start     time.Time
+	collector *metrics.Collector
+
+	mu       sync.Mutex
+	phase    string
+	cycles   int
+	finished bool
+	current  *dutyWindowState
+	windows  []DutyWindow // 끝난 활성 구간 (최근 maxDutyWindows개)
+}
+
+var _ loadPattern = (*dutyCycle)(nil)
+
+func newDutyCycle(active, idle time.Duration, collector *metrics.Collector) *dutyCycle {
+	return &dutyCycle{
+		active:    active,
+		idle:      idle,
+		start:     time.Now(),
+		collector: collector,
+		phase:     DutyPhaseActive,
+	}
+}
+
+func (d *dutyCycle) pausedUntil(now time.Time) time.Time {
+	period := d.active + d.idle
+	elapsed := now.Sub(d.start)
+	if elapsed < 0 || elapsed%period < d.active {
+		return time.Time{}
+	}
+	return d.start.Add((elapsed/period + 1) * period)
+}
+
+// begin은 새 활성 구간을 시작합니다. 직전 구간이 있으면 지금까지의 증가분으로 마감합니다.
+func (d *dutyCycle) begin(now time.Time) {
+	snapshot := d.collector.GetMetrics()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.finished {
+		return
+	}
+	if d.current != nil {
+		d.closeWindow(now, snapshot)
+	}
+	d.current = &dutyWindowState{index: d.cycles, start: now, base: snapshot}
+	d.cycles++
+	d.phase = DutyPhaseActive
+}
+
+// pause는 현재 활성 구간의 활성 시간을 마감하고 휴지 구간으로 넘어갑니다.
+// 요청 수는 다음 활성 구간이 시작될 때 마감하므로, 휴지 구간에 끝난 요청도 이 구간에 들어갑니다.
+func (d *dutyCycle) pause(now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.finished {
+		return
+	}
+	if d.current != nil {
+		d.current.activeEnd = now
+	}
+	d.phase = DutyPhaseIdle
+}
+
+// finish는 실행이 끝날 때 진행 중인 구간을 마감합니다.
+func (d *dutyCycle) finish() {
+	now, snapshot := time.Now(), d.collector.GetMetrics()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.finished {
+		return
+	}
+	d.finished = true
+	if d.current != nil {
+		d.closeWindow(now, snapshot)
+		d.current = nil
+	}
+}
+
+// record는 성공한 요청의 지연시간을 현재 활성 구간에 기록합니다 (휴지 구간에 끝난 요청은 직전 활성 구간).
+func (d *dutyCycle) record(latency time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if w := d.current; w != nil && len(w.latencies) < maxDutyWindowSamples {
+		w.latencies = append(w.latencies, latency)
+	}
+}
+
+// closeWindow는 현재 활성 구간을 결과로 옮깁니다. d.mu를 잡은 상태에서 호출해야 합니다.
+func (d *dutyCycle) closeWindow(now time.Time, snapshot metrics.Metrics) {
+	d.windows = append(d.windows, d.current.result(now, snapshot))
+	if len(d.windows) > maxDutyWindows {
+		d.windows = d.windows[len(d.windows)-maxDutyWindows:]
+	}
+}
+
+// result는 구간 시작 이후의 메트릭 증가분으로 활성 구간 결과를 계산합니다.
+func (w *dutyWindowState) result(now time.Time, snapshot metrics.Metrics) DutyWindow {
+	end := w.activeEnd
+	if end.IsZero() {
+		end = now
+	}
+
+	// 실행 중 메트릭이 초기화되었으면 초기화 이후 값만 셈
+	base := w.base
+	if snapshot.TotalRequests < base.TotalRequests {
+		base = metrics.Metrics{}
+	}
+	result := DutyWindow{
+		Index:           w.index,
+		StartedAt:       w.start,
+		ActiveSeconds:   end.Sub(w.start).Seconds(),
+		TotalRequests:   snapshot.TotalRequests - base.TotalRequests,
+		SuccessRequests: snapshot.SuccessRequests - base.SuccessRequests,
+	}
+	result.ErrorRequests = result.TotalRequests - result.SuccessRequests
+	if result.ActiveSeconds > 0 {
+		result.Rate = float64(result.SuccessRequests) / result.ActiveSeconds
+	}
+	if len(w.latencies) > 0 {
+		sorted := make([]time.Duration, len(w.latencies))
+		copy(sorted, w.latencies)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i] < sorted[j]
+		})
+		var sum time.Duration
+		for _, lat := range sorted {
+			sum += lat
+		}
+		ms := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+		result.AvgLatency = ms(sum) / float64(len(sorted))
+		result.P50Latency = ms(sorted[len(sorted)*50/100])
+		result.P95Latency = ms(sorted[len(sorted)*95/100])
+		result.P99Latency = ms(sorted[len(sorted)*99/100])
+	}
+	return result
+}
+
+// status는 현재 상태를 반환합니다. 진행 중인 활성 구간은 지금까지의 값으로 포함합니다.
+func (d *dutyCycle) status() DutyCycleStatus {
+	now, snapshot := time.Now(), d.collector.GetMetrics()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	status := DutyCycleStatus{
+		ActiveSeconds: d.active.Seconds(),
+		IdleSeconds:   d.idle.Seconds(),
+		Phase:         d.phase,
+		Cycles:        d.cycles,
+		Windows:       append([]DutyWindow{}, d.windows...),
+	}
+	if d.current != nil {
+		window := d.current.result(now, snapshot)
+		window.InProgress = true
+		status.Windows = append(status.Windows, window)
+		if len(status.Windows) > maxDutyWindows {
+			status.Windows = status.Windows[1:]
+		}
+	}
+	return status
+}
+
+// runDutyCycle은 활성/휴지 구간 경계마다 듀티 사이클의 구간을 전환합니다. 워커가 쉬는 것은 pausedUntil로 따로 판단하므로,
+// 이 고루틴은 활성 구간별 메트릭만 정리합니다.
+func (g *Generator) runDutyCycle(cycle *dutyCycle, stopCh <-chan struct{}) {
+	period := cycle.active + cycle.idle
+	for n := 0; ; n++ {
+		windowStart := cycle.start.Add(time.Duration(n) * period)
+		if !waitUntil(windowStart, stopCh) {
+			return
+		}
+		cycle.begin(time.Now())
+		if !waitUntil(windowStart.Add(cycle.active), stopCh) {
+			return
+		}
+		cycle.pause(time.Now())
+	}
+}
+
+// pattern은 워커가 따를 부하 패턴을 반환합니다 (패턴이 없으면 nil).
+func (g *Generator) pattern() loadPattern {
+	if cycle := g.dutyCycle.Load(); cycle != nil {
+		return cycle
+	}
+	return nil
+}
+
+// DutyCycle은 듀티 사이클 모드의 상태를 반환합니다.
+// 듀티 사이클로 실행한 적이 없으면 nil입니다. 실행이 끝난 뒤에도 다음 Start까지 유지됩니다.
+func (g *Generator) DutyCycle() *DutyCycleStatus {
+	cycle := g.dutyCycle.Load()
+	if cycle == nil {
+		return nil
+	}
+	status := cycle.status()
+	return &status
+}
+
+// logDutyCycle은 활성 구간별 결과를 로그에 표로 출력합니다.
+func logDutyCycle(status *DutyCycleStatus) {
+	log.Printf("Duty cycle (%.1fs active / %.1fs idle, %d cycles):", status.ActiveSeconds, status.IdleSeconds, status.Cycles)
+	log.Printf("  %5s %8s %10s %10s %7s %10s %8s %8s", "index", "active_s", "requests", "success", "errors", "rate", "p50_ms", "p95_ms")
+	for _, w := range status.Windows {
+		log.Printf("  %5d %8.2f %10d %10d %7d %10.1f %8.2f %8.2f",
+			w.Index, w.ActiveSeconds, w.TotalRequests, w.SuccessRequests, w.ErrorRequests, w.Rate, w.P50Latency, w.P95Latency)
+	}
+}
+
+// validateDutyCycle은 DutyActive와 DutyIdle을 검증합니다. 둘 다 0이면 사용하지 않습니다.
+func (c *Config) validateDutyCycle() error {
+	if c.DutyActive == 0 && c.DutyIdle == 0 {
+		return nil
+	}
+	if c.DutyActive < MinDutyPhase || c.DutyIdle < MinDutyPhase {
+		return fmt.Errorf("duty_active and duty_idle must both be at least %s, got %s and %s", MinDutyPhase, c.DutyActive, c.DutyIdle)
+	}
+	if c.ReplayTiming {
+		return fmt.Errorf("duty cycle cannot be combined with replay_timing (the replay file decides when to send)")
+	}
+	return nil
+}
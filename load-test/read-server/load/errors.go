@@ -0,0 +1,53 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// queryCanceled는 PostgreSQL의 query_canceled 에러 코드입니다.
+// statement_timeout 초과나 취소 요청(context deadline 포함)으로 쿼리가 중단되면 반환됩니다.
+const queryCanceled = "57014"
+
+// serializationFailure는 PostgreSQL의 serialization_failure 에러 코드입니다.
+// REPEATABLE READ/SERIALIZABLE에서 동시 트랜잭션과 충돌해 커밋할 수 없으면 반환됩니다.
+const serializationFailure = "40001"
+
+// isTimeout은 에러가 쿼리 타임아웃(57014 또는 context deadline 초과)인지 확인합니다.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == queryCanceled
+}
+
+// isSerializationFailure는 에러가 직렬화 충돌(40001)인지 확인합니다.
+func isSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == serializationFailure
+}
+
+// isConnectionError는 에러가 연결 끊김인지 확인합니다: 서버가 백엔드를 종료(57P01 admin_shutdown 등 57P 계열)했거나,
+// 연결 예외(08 계열), 이미 끊긴 연결(driver.ErrBadConn, sql.ErrConnDone), 네트워크 에러인 경우입니다.
+// 쿼리 자체의 문제가 아니라 연결을 다시 맺으면 회복되는 일시적 실패이므로 일반 실패와 따로 셉니다.
+func isConnectionError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		code := string(pqErr.Code)
+		return strings.HasPrefix(code, "08") || strings.HasPrefix(code, "57P")
+	}
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.As(err, &netErr)
+}
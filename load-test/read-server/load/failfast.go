@@ -0,0 +1,133 @@
+package load
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultErrorRateWindow는 에러율을 계산하는 기본 슬라이딩 윈도우 길이입니다.
+	DefaultErrorRateWindow = 10 * time.Second
+	// errorRateCheckInterval은 에러율 확인 간격입니다 (윈도우가 더 짧으면 윈도우 길이).
+	errorRateCheckInterval = time.Second
+	// minErrorRateRequests는 에러율을 판단하기 위해 윈도우 안에 필요한 최소 요청 수입니다.
+	minErrorRateRequests = 10
+)
+
+// FailFastStatus는 에러율 기반 조기 중단 상태입니다.
+type FailFastStatus struct {
+	MaxErrorRate  float64 `json:"max_error_rate"`
+	WindowSeconds float64 `json:"window_seconds"`
+	ErrorRate     float64 `json:"error_rate"` // 마지막으로 확인한 윈도우 에러율 ((실패 + 타임아웃 + 연결 끊김) / 전체)
+	Checks        int     `json:"checks"`     // 지금까지 확인한 횟수
+	Aborted       bool    `json:"aborted"`    // 에러율 초과로 실행을 중단했으면 true (실패한 실행)
+	AbortedAfter  float64 `json:"aborted_after_seconds,omitempty"`
+	Reason        string  `json:"reason,omitempty"` // 중단 사유
+}
+
+// errorSample은 확인 시점의 누적 요청 수와 에러 수입니다.
+type errorSample struct {
+	total  int64
+	errors int64
+}
+
+// failFastMonitor는 주기적으로 누적 카운터를 기록해, 최근 window 동안의 에러율이
+// maxErrorRate를 넘으면 중단을 요청합니다. window를 다 채우기 전에는 판단하지 않으므로
+// 일시적인 에러 몇 건으로는 중단되지 않습니다.
+type failFastMonitor struct {
+	mu      sync.Mutex
+	start   time.Time
+	size    int // window를 덮는 데 필요한 샘플 수 (구간 수 + 1)
+	samples []errorSample
+	status  FailFastStatus
+}
+
+func newFailFastMonitor(maxErrorRate float64, window, interval time.Duration) *failFastMonitor {
+	return &failFastMonitor{
+		start: time.Now(),
+		size:  int((window+interval-1)/interval) + 1,
+		status: FailFastStatus{
+			MaxErrorRate:  maxErrorRate,
+			WindowSeconds: window.Seconds(),
+		},
+	}
+}
+
+// observe는 누적 카운터 하나를 반영하고, 이번 관찰로 에러율 한도를 넘었으면 true를 반환합니다.
+func (m *failFastMonitor) observe(total, errors int64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := &m.status
+	s.Checks++
+
+	// 실행 중 메트릭 초기화로 카운터가 줄었으면 윈도우를 다시 채움
+	if n := len(m.samples); n > 0 && total < m.samples[n-1].total {
+		m.samples = m.samples[:0]
+	}
+	m.samples = append(m.samples, errorSample{total: total, errors: errors})
+	if len(m.samples) > m.size {
+		m.samples = append(m.samples[:0], m.samples[len(m.samples)-m.size:]...)
+	}
+	if len(m.samples) < m.size {
+		return false
+	}
+
+	first, last := m.samples[0], m.samples[len(m.samples)-1]
+	requests := last.total - first.total
+	if requests < minErrorRateRequests {
+		return false
+	}
+	s.ErrorRate = float64(last.errors-first.errors) / float64(requests)
+
+	if s.Aborted || s.ErrorRate <= s.MaxErrorRate {
+		return false
+	}
+	s.Aborted = true
+	s.AbortedAfter = time.Since(m.start).Seconds()
+	s.Reason = fmt.Sprintf("error rate %.1f%% over last %.0fs exceeded max %.1f%%",
+		s.ErrorRate*100, s.WindowSeconds, s.MaxErrorRate*100)
+	return true
+}
+
+func (m *failFastMonitor) snapshot() FailFastStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.status
+}
+
+// watchErrorRate는 interval마다 누적 요청/에러 수를 monitor에 반영하고,
+// 에러율 한도를 넘으면 로그를 남기고 해당 실행을 중지합니다.
+func (g *Generator) watchErrorRate(monitor *failFastMonitor, interval time.Duration, stopCh chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m := g.collector.GetMetrics()
+			if !monitor.observe(m.TotalRequests, m.FailedRequests+m.TimeoutRequests+m.ConnectionErrors) {
+				continue
+			}
+			log.Printf("Aborting run: %s", monitor.snapshot().Reason)
+			g.stopRun(stopCh, StopReasonErrorRate)
+			return
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// FailFast는 에러율 기반 조기 중단 상태를 반환합니다.
+// MaxErrorRate를 지정하고 실행한 적이 없으면 nil입니다. 실행이 끝난 뒤에도 다음 Start까지 유지됩니다.
+func (g *Generator) FailFast() *FailFastStatus {
+	monitor := g.failFast.Load()
+	if monitor == nil {
+		return nil
+	}
+	status := monitor.snapshot()
+	return &status
+}
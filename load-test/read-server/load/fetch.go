@@ -0,0 +1,33 @@
+package load
+
+import (
+	"database/sql"
+	"time"
+)
+
+// fetchTimer는 쿼리 하나의 첫 행까지, 마지막 행까지 시간을 잽니다 (FetchLatency 설정 시 /metrics의 fetch_latency로 보고).
+// start는 QueryContext 호출 직전 시각입니다.
+type fetchTimer struct {
+	start    time.Time
+	firstRow time.Duration // 첫 rows.Next() 반환까지 (결과가 없어도 첫 호출이 끝난 시점)
+	lastRow  time.Duration // rows.Next()가 false를 반환할 때까지 (결과를 모두 읽음)
+	started  bool
+}
+
+func newFetchTimer() fetchTimer {
+	return fetchTimer{start: time.Now()}
+}
+
+// next는 rows.Next()를 호출하고, 첫 호출과 결과 끝(false)이 반환된 시각을 기록합니다.
+func (t *fetchTimer) next(rows *sql.Rows) bool {
+	ok := rows.Next()
+	elapsed := time.Since(t.start)
+	if !t.started {
+		t.firstRow = elapsed
+		t.started = true
+	}
+	if !ok {
+		t.lastRow = elapsed
+	}
+	return ok
+}
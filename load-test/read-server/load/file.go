@@ -0,0 +1,64 @@
+package load
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// LoadConfigFile은 JSON 설정 파일을 읽어 Config를 반환합니다.
+// 파일에 없는 필드는 DefaultConfig 값을 유지하며, 모르는 필드가 있으면 에러입니다. 반환 전에 Validate를 거칩니다.
+func LoadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	config := DefaultConfig()
+	if err := DecodeConfig(bytes.NewReader(data), config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// DecodeConfig는 r의 JSON 설정을 config에 디코딩합니다 (/load/config 요청 본문과 설정 파일 공통).
+// 모르는 필드는 오타일 가능성이 높으므로 무시하지 않고 에러로 돌려줍니다.
+// 예를 들어 "workers"를 "worker"로 잘못 쓰면 워커 수가 조용히 기본값으로 실행되는 대신 unknown config field "worker"로 실패합니다.
+func DecodeConfig(r io.Reader, config *Config) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(config); err != nil {
+		return configDecodeError(err)
+	}
+	// 객체 뒤에 다른 값이 이어지면 본문이 잘못 잘렸거나 합쳐진 것
+	if dec.More() {
+		return errors.New("unexpected data after config object")
+	}
+	return nil
+}
+
+// configDecodeError는 encoding/json 에러를 어떤 필드가 왜 잘못됐는지 알 수 있는 메시지로 바꿉니다.
+func configDecodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return fmt.Errorf("config field %q must be %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+	case errors.As(err, &syntaxErr):
+		return fmt.Errorf("malformed JSON at offset %d: %v", syntaxErr.Offset, err)
+	case errors.Is(err, io.EOF):
+		return errors.New("empty config body")
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// DisallowUnknownFields 에러는 별도 타입이 없어 메시지로 구분
+		return fmt.Errorf("unknown config field %s", strings.TrimPrefix(err.Error(), "json: unknown field "))
+	}
+	return err
+}
@@ -1,8 +1,11 @@
 package load
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log"
 	"math/rand"
 	"read-server/metrics"
 	"sync"
@@ -17,91 +20,484 @@ type Generator struct {
 	running   atomic.Bool
 	wg        sync.WaitGroup
 	stopCh    chan struct{}
+	// cancelRun은 현재 실행의 컨텍스트를 취소합니다 (g.mu로 보호). 워커의 쿼리 컨텍스트는 모두 이 컨텍스트에서 파생되므로,
+	// 중지하면(Stop, ForceStop, Duration 종료 등) 진행 중인 쿼리가 끝나기를 기다리지 않고 취소됩니다.
+	cancelRun context.CancelFunc
+
+	// mu는 Start/Stop/UpdateConfig를 직렬화합니다.
+	// Stop이 wg.Wait()를 끝내기 전에 다음 Start가 wg.Add()를 호출하지 않도록 보장합니다.
+	mu sync.Mutex
+	// configMu는 실행 중이 아닐 때만 바뀌는 config 포인터를 GetConfig와 안전하게 공유합니다.
+	configMu sync.RWMutex
+	// rate는 워커가 따르는 목표 QPS입니다. config를 바꾸거나 실행을 시작할 때 config.QPS로 맞추고,
+	// 실행 중에는 SetQPS로만 바뀝니다 (config는 실행 중에 바뀌지 않으므로 따로 둠, rate.go).
+	rate atomic.Int64
+	// rotation은 격리 수준 비교 모드의 상태입니다 (비교 모드가 아니면 nil).
+	// 실행이 끝난 뒤에도 결과 조회를 위해 다음 Start까지 유지됩니다.
+	rotation atomic.Pointer[isolationRotation]
+	// convergence는 p95 수렴 감지 상태입니다 (ConvergenceInterval이 0이면 nil).
+	convergence atomic.Pointer[convergenceMonitor]
+	// failFast는 에러율 기반 조기 중단 상태입니다 (MaxErrorRate가 0이면 nil).
+	failFast atomic.Pointer[failFastMonitor]
+	// chaos는 연결 강제 종료 모드의 상태입니다 (ChaosInterval이 0이면 nil).
+	chaos atomic.Pointer[chaosMonitor]
+	// dutyCycle은 듀티 사이클(버스트 부하) 모드의 상태입니다 (DutyActive가 0이면 nil).
+	dutyCycle atomic.Pointer[dutyCycle]
+	// rowVerifier는 결과 행 검증 모드의 상태입니다 (VerifyRows가 false면 nil).
+	rowVerifier atomic.Pointer[rowVerifier]
+	// statements는 현재 실행의 준비된 문장입니다 (UsePrepared가 false면 nil, prepared.go).
+	// 워커를 시작하기 전에 채우고 모든 워커가 끝난 뒤 닫으므로 config처럼 잠금 없이 읽습니다.
+	statements preparedStatements
+	// history는 최근 상태 샘플의 원형 버퍼입니다 (Start마다 비우며, 실행이 끝난 뒤에도 다음 Start까지 유지).
+	history *statusHistory
+	// metricsCSVPath는 실행 중 초당 메트릭을 덧붙일 CSV 파일 경로입니다 (SetMetricsCSV, 빈 값 = 기록 안 함).
+	// metricsCSV는 현재 실행이 연 파일로, g.mu로 보호되며 실행이 끝나면 닫습니다.
+	metricsCSVPath string
+	metricsCSV     *metricsCSV
+	// poolDefaults는 config의 풀 설정이 0일 때 쓰는 서버 기본값입니다 (SetPoolDefaults, pool.go).
+	poolDefaults PoolSettings
+	// poolApplied는 마지막으로 g.db에 적용한 풀 설정입니다 (PoolSettings, /debug/config에서 잠금 없이 읽음).
+	poolApplied atomic.Pointer[PoolSettings]
 }
 
 func NewGenerator(db *sql.DB, config *Config, collector *metrics.Collector) *Generator {
-	return &Generator{
+	g := &Generator{
 		db:        db,
 		config:    config,
 		collector: collector,
 		stopCh:    make(chan struct{}),
+		history:   newStatusHistory(MaxStatusHistory),
 	}
+	g.rate.Store(int64(config.QPS))
+	return g
 }
 
 func (g *Generator) Start() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	if g.running.Load() {
 		return fmt.Errorf("generator already running")
 	}
 
+	trace, err := loadReplayTrace(g.config)
+	if err != nil {
+		return err
+	}
+	csvLog, err := g.openMetricsCSV()
+	if err != nil {
+		return err
+	}
+	stmts, err := g.prepareStatements(g.config)
+	if err != nil {
+		csvLog.close()
+		return err
+	}
+
+	// ResetOnStart가 false면 이전 실행의 메트릭에 이어서 누적
+	if g.config.shouldResetOnStart() {
+		g.collector.Reset()
+	}
+	// 상태 이력은 메트릭 누적 여부와 관계없이 실행마다 새로 기록
+	g.history.reset()
+	g.startLocked(trace, csvLog, stmts)
+	return nil
+}
+
+// startLocked는 현재 config로 워커와 타이머를 시작합니다. g.mu를 잡은 상태에서 호출해야 합니다.
+// 메트릭은 초기화하지 않으므로 Reload에서 누적 메트릭을 유지한 채 재시작할 수 있습니다.
+// trace는 재생 모드일 때 미리 읽어 둔 재생 파일, csvLog는 미리 연 CSV 메트릭 로그,
+// stmts는 미리 준비한 문장입니다 (사용하지 않으면 nil).
+func (g *Generator) startLocked(trace *replayTrace, csvLog *metricsCSV, stmts preparedStatements) {
+	// 실행마다 새 stopCh를 만들어 워커와 타이머에 직접 전달 (필드 재할당과 경합 방지)
+	stopCh := make(chan struct{})
+	g.stopCh = stopCh
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	g.cancelRun = cancelRun
+	g.rate.Store(int64(g.config.QPS))
+	g.statements = stmts
+	g.applyPoolSettings()
 	g.running.Store(true)
-	g.stopCh = make(chan struct{})
-	g.collector.Reset()
 
-	if g.config.Duration > 0 {
+	if duration := g.config.Duration; duration > 0 {
 		go func() {
-			time.Sleep(g.config.Duration)
-			g.Stop()
+			timer := time.NewTimer(duration)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				g.stopRun(stopCh, StopReasonDuration)
+			case <-stopCh:
+			}
 		}()
 	}
+	// 격리 수준 비교 모드: IsolationSlice마다 (또는 트랜잭션마다) 다음 격리 수준으로 전환
+	g.rotation.Store(nil)
+	if g.config.CompareIsolation {
+		rotation := newIsolationRotation(g.config.IsolationRotation == RotationTransaction)
+		g.rotation.Store(rotation)
+		if !rotation.perTransaction {
+			go func(slice time.Duration) {
+				ticker := time.NewTicker(slice)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						rotation.advance()
+					case <-stopCh:
+						return
+					}
+				}
+			}(g.config.IsolationSlice)
+		}
+	}
+
+	// 지연시간 샘플링 비율 (Reload로 바뀌면 이후 요청부터 적용)
+	g.collector.SetLatencySampleRate(g.config.LatencySampleRate)
+	g.collector.SetPercentiles(g.config.Percentiles)
+
+	// 워밍업 제외 구간(warmup_exclude, 없으면 ramp_up)은 실행(재시작 포함)마다 지금부터 다시 셈
+	g.collector.SetWarmupExclude(g.config.warmupWindow())
+
+	// 수렴 감지: ConvergenceInterval마다 p95가 안정되었는지 확인
+	g.convergence.Store(nil)
+	if interval := g.config.ConvergenceInterval; interval > 0 {
+		monitor := newConvergenceMonitor(g.config.ConvergenceTolerance, g.config.ConvergenceWindow)
+		g.convergence.Store(monitor)
+		go g.watchConvergence(monitor, interval, g.config.StopOnConvergence, stopCh)
+	}
+
+	// 조기 중단: 윈도우 에러율이 MaxErrorRate를 넘으면 실행 중지
+	g.failFast.Store(nil)
+	if g.config.MaxErrorRate > 0 {
+		window := g.config.ErrorRateWindow
+		interval := errorRateCheckInterval
+		if window < interval {
+			interval = window
+		}
+		monitor := newFailFastMonitor(g.config.MaxErrorRate, window, interval)
+		g.failFast.Store(monitor)
+		go g.watchErrorRate(monitor, interval, stopCh)
+	}
+
+	// 연결 강제 종료: ChaosInterval마다 이 서버의 연결 하나를 종료해 네트워크 끊김을 흉내 냄
+	g.chaos.Store(nil)
+	if interval := g.config.ChaosInterval; interval > 0 {
+		monitor := newChaosMonitor(interval)
+		g.chaos.Store(monitor)
+		go g.injectChaos(monitor, interval, stopCh)
+	}
+
+	// 듀티 사이클: DutyActive 동안 부하를 생성하고 DutyIdle 동안 멈추는 것을 반복 (워커는 pausedUntil로 확인)
+	g.dutyCycle.Store(nil)
+	if g.config.DutyActive > 0 {
+		cycle := newDutyCycle(g.config.DutyActive, g.config.DutyIdle, g.collector)
+		g.dutyCycle.Store(cycle)
+		go g.runDutyCycle(cycle, stopCh)
+	}
+
+	// 결과 행 검증: 워커가 쿼리 결과의 체크섬을 계산하고 불변식(정렬, 중복, 조건, 단조 읽기)을 확인
+	g.rowVerifier.Store(nil)
+	if g.config.VerifyRows {
+		g.rowVerifier.Store(newRowVerifier())
+	}
+
+	// 상태 이력: StatusHistoryInterval마다 메트릭 요약을 기록 (GET /load/status/history)
+	go g.recordHistory(stopCh)
+
+	// CSV 메트릭 로그: MetricsCSVInterval마다 한 행씩 덧붙임 (-metrics-csv)
+	g.metricsCSV = csvLog
+	if csvLog != nil {
+		go g.writeMetricsCSV(csvLog, stopCh)
+	}
+
+	// 재생 모드: 워커들이 파일 순서대로 쿼리를 나눠 실행 (Duration이 있으면 파일을 반복)
+	var replay *replayCursor
+	if trace != nil {
+		replay = newReplayCursor(trace, g.config.Duration > 0)
+	}
 
 	for i := 0; i < g.config.Workers; i++ {
 		g.wg.Add(1)
-		go g.worker()
+		go g.worker(runCtx, stopCh, g.config.workerStartDelay(i), replay)
 	}
+}
 
-	return nil
+// Stop은 부하 생성을 중지하고 모든 워커가 끝난 시점의 최종 메트릭을 반환합니다.
+// 실행 컨텍스트를 먼저 취소하므로 진행 중인 쿼리가 느려도 끝나기를 기다리지 않고 곧바로 멈춥니다.
+// 취소된 요청은 성공도 실패도 아닌 aborted_requests로 기록됩니다.
+// g.mu를 잡은 채 조회하므로 다음 Start의 메트릭 초기화보다 항상 먼저입니다.
+func (g *Generator) Stop() metrics.Metrics {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.stopLocked(StopReasonManual)
+	return g.collector.GetMetrics()
+}
+
+// ForceStop은 Stop과 같지만 완료 웹훅의 중지 사유를 forced로 기록합니다 (긴급 중지, ?force=true).
+// 진행 중인 요청을 취소하는 것은 Stop도 같습니다.
+func (g *Generator) ForceStop() metrics.Metrics {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.stopLocked(StopReasonForced)
+	return g.collector.GetMetrics()
+}
+
+// stopRun은 stopCh에 해당하는 실행이 아직 진행 중일 때만 중지합니다.
+// Duration 타이머나 수렴 감지가 이후에 재시작된 다른 실행을 중지하지 않도록 합니다.
+func (g *Generator) stopRun(stopCh chan struct{}, reason string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.stopCh != stopCh {
+		return
+	}
+	g.stopLocked(reason)
 }
 
-func (g *Generator) Stop() {
+func (g *Generator) stopLocked(reason string) {
 	if !g.running.Load() {
 		return
 	}
 
 	g.running.Store(false)
+	// 실행 컨텍스트를 먼저 취소해 진행 중인 쿼리를 중단시킴 (느린 쿼리가 끝나기를 기다리지 않음, 중단된 요청은 aborted_requests)
+	g.cancelRun()
 	close(g.stopCh)
 	g.wg.Wait()
+	g.statements.close()
+	g.statements = nil
+	// Reload는 같은 풀 설정으로 곧바로 다시 시작하므로(checkPoolUnchanged) 되돌리지 않음
+	if reason != StopReasonReload {
+		g.restorePoolSettings()
+	}
+
+	// 모든 워커가 끝난 시점을 상태 이력과 CSV 로그의 마지막 행으로 기록
+	now, final := time.Now(), g.collector.GetMetrics()
+	g.history.record(now, false, final)
+	if g.metricsCSV != nil {
+		if err := g.metricsCSV.record(now, final); err != nil {
+			log.Printf("Failed to write metrics CSV: %v", err)
+		}
+		g.metricsCSV.close()
+		g.metricsCSV = nil
+	}
+
+	var comparison []IsolationResult
+	if rotation := g.rotation.Load(); rotation != nil {
+		rotation.finish()
+		comparison = rotation.results()
+		logIsolationComparison(comparison)
+	}
+	if cycle := g.dutyCycle.Load(); cycle != nil {
+		cycle.finish()
+		logDutyCycle(g.DutyCycle())
+	}
+	if verification := g.RowVerification(); verification != nil {
+		logRowVerification(verification)
+	}
+
+	// 모든 워커가 끝난 뒤의 메트릭이 최종 결과
+	if url := g.config.CompletionWebhook; url != "" {
+		go notifyCompletion(url, CompletionEvent{
+			Reason:     reason,
+			FinishedAt: time.Now(),
+			Config:     *g.config,
+			Metrics:    g.collector.GetMetrics(),
+
+			IsolationComparison: comparison,
+			Convergence:         g.Convergence(),
+			FailFast:            g.FailFast(),
+			Chaos:               g.Chaos(),
+			DutyCycle:           g.DutyCycle(),
+			RowVerification:     g.RowVerification(),
+		})
+	}
 }
 
-func (g *Generator) worker() {
+// worker는 startDelay만큼 기다린 뒤 부하를 생성합니다. 기다리는 중에 중지되면 연결을 얻지 않고 종료합니다.
+// replay가 있으면 무작위 쿼리 대신 재생 파일의 쿼리를 실행합니다.
+// runCtx는 실행 컨텍스트로, 중지하면 취소되어 진행 중인 쿼리를 중단하고 종료합니다.
+func (g *Generator) worker(runCtx context.Context, stopCh chan struct{}, startDelay time.Duration, replay *replayCursor) {
 	defer g.wg.Done()
 
-	var ticker *time.Ticker
-	var tickerCh <-chan time.Time
+	if startDelay > 0 {
+		timer := time.NewTimer(startDelay)
+		select {
+		case <-timer.C:
+		case <-stopCh:
+			timer.Stop()
+			return
+		}
+	}
 
-	if g.config.QPS > 0 {
-		qpsPerWorker := g.config.QPS / g.config.Workers
-		if qpsPerWorker < 1 {
-			qpsPerWorker = 1
+	conn, release, err := g.workerConn(stopCh)
+	if err != nil {
+		// 연결을 기다리는 중에 중지된 경우는 정상 종료
+		if !errors.Is(err, context.Canceled) {
+			log.Printf("Worker failed to acquire dedicated connection: %v", err)
 		}
-		interval := time.Second / time.Duration(qpsPerWorker)
-		ticker = time.NewTicker(interval)
-		tickerCh = ticker.C
-		defer ticker.Stop()
+		return
 	}
+	defer func() { release() }()
+
+	// 지연시간은 워커별 기록기에 모았다가 주기적으로 합침 (종료할 때 남은 샘플을 합침)
+	rec := g.newWorkerRecorders()
+	defer rec.flush()
+
+	// QPS 제한 (SetQPS로 목표가 바뀌면 다음 쿼리부터 새 간격)
+	var limiter workerLimiter
+	defer limiter.stop()
+
+	// 연결 에러가 나면 성공할 때까지 점점 길게 기다리며 재시도
+	var backoff reconnectBackoff
+	// 직전 틱을 받은 시각 (틱 사이에서 일한 시간과 기다린 시간을 나눠 기록)
+	lastTick := time.Now()
+
+	// 듀티 사이클이면 쉬는 구간마다 다음 활성 구간까지 멈춤
+	pattern := g.pattern()
+	cycle := g.dutyCycle.Load()
+
+	// 결과 행 검증이면 쿼리마다 체크섬과 불변식을 확인 (lastNewest는 이 워커가 본 최신 timestamp)
+	verifier := g.rowVerifier.Load()
+	var lastNewest time.Time
+
+	// 생각 시간: 첫 쿼리를 제외하고 쿼리를 끝낼 때마다(성공, 실패 모두) 쉰 뒤 다음 쿼리를 보냄
+	queried := false
 
 	for {
 		select {
-		case <-g.stopCh:
+		case <-stopCh:
 			return
 		default:
-			if tickerCh != nil {
+			if queried {
+				if delay := g.config.thinkDelay(); delay > 0 {
+					rec.flushIfDue()
+					if !waitUntil(time.Now().Add(delay), stopCh) {
+						return
+					}
+					// 생각 시간도 처리율 제한 대기나 작업이 아님. ticker는 그동안 틱을 하나만 쌓아 두므로 QPS는 여전히 상한
+					lastTick = time.Now()
+				}
+			}
+			queried = true
+			if pattern != nil {
+				if due := pattern.pausedUntil(time.Now()); !due.IsZero() {
+					rec.flushIfDue()
+					if !waitUntil(due, stopCh) {
+						return
+					}
+					// 쉬는 구간은 처리율 제한 대기도 작업도 아님
+					lastTick = time.Now()
+				}
+			}
+			if tickerCh := limiter.update(g.limitRate(), g.config.Workers); tickerCh != nil {
+				rec.flushIfDue()
+				waitStart := time.Now()
 				select {
 				case <-tickerCh:
-				case <-g.stopCh:
+				case <-stopCh:
+					return
+				}
+				lastTick = g.recordLimiterWait(lastTick, waitStart)
+			}
+
+			// 쿼리 타입 선택 (재생 모드면 파일의 다음 쿼리)
+			queryType, level, service := g.selectQueryType(), randomLevel(), randomService()
+			if replay != nil {
+				q, due, ok := replay.next()
+				if !ok {
+					// 파일 끝: stopRun은 워커 종료를 기다리므로 별도 고루틴에서 호출
+					go g.stopRun(stopCh, StopReasonReplayEnd)
 					return
 				}
+				if !waitUntil(due, stopCh) {
+					return
+				}
+				queryType = q.Type
+				if q.Level != "" {
+					level = q.Level
+				}
+				if q.Service != "" {
+					service = q.Service
+				}
+			}
+
+			isolation, levelCollector := g.isolation()
+			var check *rowCheck
+			if verifier != nil {
+				check = newRowCheck(queryType, level, service)
+			}
+			queryStart := time.Now()
+			latency, rows, fetch, err := g.executeQuery(runCtx, conn, queryType, level, service, isolation, check)
+			elapsed := time.Since(queryStart)
+			if aborted(runCtx, err) {
+				g.collector.RecordAborted(elapsed)
+				if levelCollector != nil {
+					levelCollector.RecordAborted(elapsed)
+				}
+				return
+			}
+			if isConnectionError(err) {
+				g.collector.RecordConnError(queryType, elapsed)
+				if levelCollector != nil {
+					levelCollector.RecordConnError(queryType, elapsed)
+				}
+				if !g.connLost(&backoff, err, stopCh) {
+					return
+				}
+				// 워커 전용 연결은 끊기면 이후 쿼리가 모두 실패하므로 새 연결을 얻음
+				if g.config.DedicatedConns {
+					if conn, release, err = g.reconnect(release, &backoff, stopCh); err != nil {
+						return
+					}
+				}
+				continue
+			}
+			if isTimeout(err) {
+				g.collector.RecordTimeout(queryType, elapsed)
+				if levelCollector != nil {
+					levelCollector.RecordTimeout(queryType, elapsed)
+				}
+				continue
+			}
+			if err != nil {
+				g.collector.RecordFailureWithError(queryType, elapsed, err)
+				if levelCollector != nil {
+					levelCollector.RecordFailureWithError(queryType, elapsed, err)
+					g.recordConflict(isolation, 1, err)
+				}
+				continue
 			}
 
-			// 쿼리 타입 선택
-			queryType := g.selectQueryType()
-			if err := g.executeQuery(queryType); err != nil {
-				g.collector.RecordFailure()
+			g.connRestored(&backoff)
+			if cycle != nil {
+				cycle.record(latency)
+			}
+			if verifier != nil {
+				verifier.finish(check, isolation, &lastNewest)
+			}
+			rec.main.RecordSuccessTyped(queryType, latency, rows)
+			if levelCollector != nil {
+				rec.level(levelCollector).RecordSuccessTyped(queryType, latency, rows)
+			}
+			// 첫 행까지 vs 마지막 행까지 (결과 스트리밍 비용 분리)
+			if g.config.FetchLatency {
+				rec.main.RecordFetch(latency, fetch.firstRow, fetch.lastRow)
+				if levelCollector != nil {
+					rec.level(levelCollector).RecordFetch(latency, fetch.firstRow, fetch.lastRow)
+				}
 			}
 		}
 	}
 }
 
 func (g *Generator) selectQueryType() string {
+	if len(g.config.CustomQueries) > 0 {
+		return g.selectCustomQuery()
+	}
+
 	r := rand.Intn(100)
 
 	if r < g.config.QueryMix.Simple {
@@ -113,173 +509,248 @@ func (g *Generator) selectQueryType() string {
 	}
 }
 
-func (g *Generator) executeQuery(queryType string) error {
+// isolation은 이번 쿼리에 사용할 격리 수준과, 비교 모드일 때 해당 격리 수준의 컬렉터를 반환합니다.
+func (g *Generator) isolation() (string, *metrics.Collector) {
+	if rotation := g.rotation.Load(); rotation != nil {
+		return rotation.level()
+	}
+	return g.config.IsolationLevel, nil
+}
+
+// workerRecorders는 워커 하나의 기록기(metrics.Recorder)입니다. 성공한 쿼리는 요청마다 Collector를 잠그지 않고 여기에 기록합니다.
+// 실패는 드물고 바로 보여야 하므로 Collector에 직접 기록합니다.
+type workerRecorders struct {
+	main   *metrics.Recorder
+	levels map[*metrics.Collector]*metrics.Recorder // 격리 수준 비교 모드의 수준별 기록기
+}
+
+func (g *Generator) newWorkerRecorders() *workerRecorders {
+	return &workerRecorders{main: g.collector.NewRecorder()}
+}
+
+// level은 격리 수준별 Collector c의 기록기를 반환하며, 처음이면 만듭니다.
+func (r *workerRecorders) level(c *metrics.Collector) *metrics.Recorder {
+	rec, ok := r.levels[c]
+	if !ok {
+		if r.levels == nil {
+			r.levels = make(map[*metrics.Collector]*metrics.Recorder)
+		}
+		rec = c.NewRecorder()
+		r.levels[c] = rec
+	}
+	return rec
+}
+
+// flushIfDue는 기다리기 전에 호출해, 쿼리가 뜸해도 모은 샘플이 주기를 넘겨 묵지 않게 합니다.
+func (r *workerRecorders) flushIfDue() {
+	r.main.FlushIfDue()
+	for _, rec := range r.levels {
+		rec.FlushIfDue()
+	}
+}
+
+func (r *workerRecorders) flush() {
+	r.main.Flush()
+	for _, rec := range r.levels {
+		rec.Flush()
+	}
+}
+
+// recordConflict는 비교 모드에서 err가 직렬화 충돌(40001)이면 격리 수준별로 count건을 셉니다.
+func (g *Generator) recordConflict(isolation string, count int, err error) {
+	if rotation := g.rotation.Load(); rotation != nil && isSerializationFailure(err) {
+		rotation.recordConflict(isolation, count)
+	}
+}
+
+// IsolationComparison은 격리 수준 비교 모드의 격리 수준별 결과를 반환합니다.
+// 비교 모드로 실행한 적이 없으면 nil입니다. 실행 중에는 현재까지의 결과를 반환합니다.
+func (g *Generator) IsolationComparison() []IsolationResult {
+	rotation := g.rotation.Load()
+	if rotation == nil {
+		return nil
+	}
+	return rotation.results()
+}
+
+// executeQuery는 쿼리를 실행하고 지연시간, 읽은 행 수, 첫 행/마지막 행까지 시간을 반환합니다. level, service는 filter 쿼리의 조건입니다.
+// queryType이 사용자 지정 쿼리(CustomQueries)의 이름이면 그 쿼리를 실행합니다.
+// QueryTimeout을 넘기면 쿼리가 취소되고 isTimeout으로 분류되는 에러를 반환합니다.
+// check가 있으면(결과 행 검증 모드) 읽은 행마다 체크섬과 불변식을 확인합니다.
+func (g *Generator) executeQuery(runCtx context.Context, conn txBeginner, queryType, level, service, isolation string, check *rowCheck) (latency time.Duration, rows int, fetch fetchTimer, err error) {
+	ctx, cancel := g.queryContext(runCtx)
+	defer cancel()
+
 	switch queryType {
 	case "simple":
-		return g.simpleQuery()
+		latency, rows, fetch, err = g.simpleQuery(ctx, conn, isolation, check)
 	case "filter":
-		return g.filterQuery()
+		latency, rows, fetch, err = g.filterQuery(ctx, conn, isolation, level, service, check)
 	case "aggregate":
-		return g.aggregateQuery()
+		latency, rows, fetch, err = g.aggregateQuery(ctx, conn, isolation, check)
 	default:
-		return fmt.Errorf("unknown query type: %s", queryType)
+		q, ok := g.customQuery(queryType)
+		if !ok {
+			return 0, 0, fetch, fmt.Errorf("unknown query type: %s", queryType)
+		}
+		latency, rows, fetch, err = g.runCustomQuery(ctx, conn, isolation, q)
+	}
+	return latency, rows, fetch, deadlineError(ctx, err)
+}
+
+// queryContext는 실행 컨텍스트 runCtx에서 파생된 쿼리 컨텍스트를 반환합니다.
+// QueryTimeout이 설정되어 있으면 그 시간이 지나도 취소됩니다.
+func (g *Generator) queryContext(runCtx context.Context) (context.Context, context.CancelFunc) {
+	if g.config.QueryTimeout > 0 {
+		return context.WithTimeout(runCtx, g.config.QueryTimeout)
 	}
+	return context.WithCancel(runCtx)
+}
+
+// aborted는 err가 중지로 실행 컨텍스트가 취소되어 중단된 쿼리의 에러인지 확인합니다.
+// 취소된 쿼리는 드라이버에 따라 57014(query_canceled)나 연결 에러로 끝나므로 에러 종류보다 먼저 확인합니다.
+func aborted(runCtx context.Context, err error) bool {
+	return err != nil && runCtx.Err() != nil
 }
 
-func (g *Generator) simpleQuery() error {
-	tx, err := g.db.Begin()
+// deadlineError는 컨텍스트 데드라인이 지난 뒤 발생한 에러(sql.ErrTxDone 등)도 타임아웃으로 분류되도록 감쌉니다.
+func deadlineError(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && !isTimeout(err) {
+		return fmt.Errorf("%w: %v", context.DeadlineExceeded, err)
+	}
+	return err
+}
+
+func (g *Generator) simpleQuery(ctx context.Context, conn txBeginner, isolation string, check *rowCheck) (time.Duration, int, fetchTimer, error) {
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 	defer tx.Rollback()
 
-	if _, err := tx.Exec(fmt.Sprintf("SET TRANSACTION ISOLATION LEVEL %s", g.config.IsolationLevel)); err != nil {
-		return err
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET TRANSACTION ISOLATION LEVEL %s", isolation)); err != nil {
+		return 0, 0, fetchTimer{}, err
 	}
 
-	query := `
-		SELECT id, timestamp, level, service, message
-		FROM logs
-		ORDER BY timestamp DESC
-		LIMIT 100
-	`
-
-	start := time.Now()
-	rows, err := tx.Query(query)
+	fetch := newFetchTimer()
+	rows, err := g.queryRows(ctx, tx, simpleQuerySQL)
 	if err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 	defer rows.Close()
 
 	// 결과 읽기 (실제 데이터 fetch)
-	for rows.Next() {
+	rowCount := 0
+	for fetch.next(rows) {
 		var id int64
 		var timestamp time.Time
 		var level, service, message string
 		if err := rows.Scan(&id, &timestamp, &level, &service, &message); err != nil {
-			return err
+			return 0, 0, fetchTimer{}, err
+		}
+		if check != nil {
+			check.logRow(id, timestamp, level, service)
 		}
+		rowCount++
 	}
 
 	if err := rows.Err(); err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 
-	latency := time.Since(start)
-	g.collector.RecordSuccess(latency)
-
-	return nil
+	return time.Since(fetch.start), rowCount, fetch, nil
 }
 
-func (g *Generator) filterQuery() error {
-	tx, err := g.db.Begin()
+func (g *Generator) filterQuery(ctx context.Context, conn txBeginner, isolation, level, service string, check *rowCheck) (time.Duration, int, fetchTimer, error) {
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 	defer tx.Rollback()
 
-	if _, err := tx.Exec(fmt.Sprintf("SET TRANSACTION ISOLATION LEVEL %s", g.config.IsolationLevel)); err != nil {
-		return err
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET TRANSACTION ISOLATION LEVEL %s", isolation)); err != nil {
+		return 0, 0, fetchTimer{}, err
 	}
 
-	level := randomLevel()
-	service := randomService()
+	query, args := g.bind(filterQuerySQL, level, service)
 
-	query := `
-		SELECT id, timestamp, level, service, message
-		FROM logs
-		WHERE level = $1
-		  AND service = $2
-		  AND timestamp > NOW() - INTERVAL '1 hour'
-		ORDER BY timestamp DESC
-		LIMIT 100
-	`
-
-	start := time.Now()
-	rows, err := tx.Query(query, level, service)
+	fetch := newFetchTimer()
+	rows, err := g.queryRows(ctx, tx, query, args...)
 	if err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 	defer rows.Close()
 
-	for rows.Next() {
+	rowCount := 0
+	for fetch.next(rows) {
 		var id int64
 		var timestamp time.Time
 		var level, service, message string
 		if err := rows.Scan(&id, &timestamp, &level, &service, &message); err != nil {
-			return err
+			return 0, 0, fetchTimer{}, err
+		}
+		if check != nil {
+			check.logRow(id, timestamp, level, service)
 		}
+		rowCount++
 	}
 
 	if err := rows.Err(); err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 
-	latency := time.Since(start)
-	g.collector.RecordSuccess(latency)
-
-	return nil
+	return time.Since(fetch.start), rowCount, fetch, nil
 }
 
-func (g *Generator) aggregateQuery() error {
-	tx, err := g.db.Begin()
+func (g *Generator) aggregateQuery(ctx context.Context, conn txBeginner, isolation string, check *rowCheck) (time.Duration, int, fetchTimer, error) {
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 	defer tx.Rollback()
 
-	if _, err := tx.Exec(fmt.Sprintf("SET TRANSACTION ISOLATION LEVEL %s", g.config.IsolationLevel)); err != nil {
-		return err
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET TRANSACTION ISOLATION LEVEL %s", isolation)); err != nil {
+		return 0, 0, fetchTimer{}, err
 	}
 
-	query := `
-		SELECT
-			level,
-			COUNT(*) as count,
-			MIN(timestamp) as first_seen,
-			MAX(timestamp) as last_seen
-		FROM logs
-		WHERE timestamp > NOW() - INTERVAL '1 hour'
-		GROUP BY level
-		ORDER BY count DESC
-	`
-
-	start := time.Now()
-	rows, err := tx.Query(query)
+	fetch := newFetchTimer()
+	rows, err := g.queryRows(ctx, tx, aggregateQuerySQL)
 	if err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 	defer rows.Close()
 
-	for rows.Next() {
+	rowCount := 0
+	for fetch.next(rows) {
 		var level string
 		var count int64
 		var firstSeen, lastSeen time.Time
 		if err := rows.Scan(&level, &count, &firstSeen, &lastSeen); err != nil {
-			return err
+			return 0, 0, fetchTimer{}, err
+		}
+		if check != nil {
+			check.aggregateRow(level, count, firstSeen, lastSeen)
 		}
+		rowCount++
 	}
 
 	if err := rows.Err(); err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return err
+		return 0, 0, fetchTimer{}, err
 	}
 
-	latency := time.Since(start)
-	g.collector.RecordSuccess(latency)
-
-	return nil
+	return time.Since(fetch.start), rowCount, fetch, nil
 }
 
 func randomLevel() string {
@@ -293,6 +764,9 @@ func randomService() string {
 }
 
 func (g *Generator) UpdateConfig(config *Config) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	if g.running.Load() {
 		return fmt.Errorf("cannot update config while generator is running")
 	}
@@ -301,14 +775,102 @@ func (g *Generator) UpdateConfig(config *Config) error {
 		return err
 	}
 
+	g.configMu.Lock()
 	g.config = config
+	g.rate.Store(int64(config.QPS))
+	g.configMu.Unlock()
 	return nil
 }
 
+// Reload는 config를 검증한 뒤 적용합니다. 실행 중이면 현재 실행을 중지하고 새 config로 다시 시작하며,
+// 이때 메트릭은 초기화하지 않습니다 (SIGHUP 설정 리로드용). 재시작 여부를 반환합니다.
+func (g *Generator) Reload(config *Config) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := config.Validate(); err != nil {
+		return false, err
+	}
+
+	// 재생 파일과 CSV 로그는 현재 실행을 멈추기 전에 준비해, 실패하면 기존 실행을 유지
+	restart := g.running.Load()
+	if restart {
+		if err := g.checkPoolUnchanged(config); err != nil {
+			return false, err
+		}
+	}
+	var trace *replayTrace
+	var csvLog *metricsCSV
+	var stmts preparedStatements
+	if restart {
+		var err error
+		if trace, err = loadReplayTrace(config); err != nil {
+			return false, err
+		}
+		if csvLog, err = g.openMetricsCSV(); err != nil {
+			return false, err
+		}
+		if stmts, err = g.prepareStatements(config); err != nil {
+			csvLog.close()
+			return false, err
+		}
+		g.stopLocked(StopReasonReload)
+	}
+
+	g.configMu.Lock()
+	g.config = config
+	g.rate.Store(int64(config.QPS))
+	g.configMu.Unlock()
+
+	if restart {
+		g.startLocked(trace, csvLog, stmts)
+	}
+	return restart, nil
+}
+
 func (g *Generator) GetConfig() *Config {
+	g.configMu.RLock()
+	defer g.configMu.RUnlock()
+
 	return g.config
 }
 
+// TargetRate는 워커들이 실제로 목표로 하는 초당 쿼리 수입니다 (0 = 무제한 또는 기록된 간격대로 재생, Metrics.TargetRate).
+// QPS를 워커 수로 나눈 몫(최소 1)에 워커 수를 곱하므로 설정한 QPS와 조금 다를 수 있습니다.
+func (g *Generator) TargetRate() float64 {
+	config := g.GetConfig()
+	qps := g.TargetQPS()
+	if qps <= 0 || config.ReplayTiming {
+		return 0
+	}
+	return float64(perWorkerRate(qps, config.Workers) * config.Workers)
+}
+
+// limitRate는 워커가 지금 따라야 하는 목표 QPS입니다. 기록된 간격대로 재생할 때는 QPS 제한을 쓰지 않습니다 (0).
+func (g *Generator) limitRate() int {
+	if g.config.ReplayTiming {
+		return 0
+	}
+	return g.TargetQPS()
+}
+
+// recordLimiterWait는 waitStart부터 지금까지 틱을 기다린 시간과, 직전 틱(lastTick)부터 waitStart까지 일한 시간을 기록하고
+// 틱을 받은 지금 시각을 반환합니다. 워커가 틱을 놓칠 만큼 느리면 ticker가 밀린 틱을 버리므로 대기는 거의 0이 됩니다.
+func (g *Generator) recordLimiterWait(lastTick, waitStart time.Time) time.Time {
+	now := time.Now()
+	g.collector.RecordLimiterWait(now.Sub(waitStart), waitStart.Sub(lastTick))
+	return now
+}
+
+// perWorkerRate는 워커 하나가 맡는 초당 실행 횟수입니다 (최소 1).
+func perWorkerRate(rate, workers int) int {
+	perWorker := rate / workers
+	if perWorker < 1 {
+		perWorker = 1
+	}
+	return perWorker
+}
+
 func (g *Generator) IsRunning() bool {
 	return g.running.Load()
 }
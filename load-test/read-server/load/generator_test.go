@@ -0,0 +1,262 @@
+package load
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Start/Stop을 반복해도 WaitGroup 재사용이나 stopCh 교체가 경합하지 않는지 확인합니다 (go test -race로 실행).
+func TestStartStopRepeated(t *testing.T) {
+	iterations := 1000
+	if testing.Short() {
+		iterations = 100
+	}
+
+	config := DefaultConfig()
+	config.QPS = 0
+	config.Workers = 4
+	g := newTestGenerator(t, &fakeDB{}, config)
+
+	// 실행 상태와 메트릭을 동시에 읽는 쪽 (HTTP 핸들러 역할)
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	readers.Add(1)
+	go func() {
+		defer readers.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				g.IsRunning()
+				g.TargetRate()
+				g.collector.GetMetrics()
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}()
+
+	for i := 0; i < iterations; i++ {
+		if err := g.Start(); err != nil {
+			t.Fatalf("Start #%d: %v", i, err)
+		}
+		if err := g.Start(); err == nil {
+			t.Fatalf("Start #%d succeeded while already running", i)
+		}
+		g.Stop()
+		if g.IsRunning() {
+			t.Fatalf("generator still running after Stop #%d", i)
+		}
+	}
+	close(done)
+	readers.Wait()
+}
+
+// 여러 고루틴이 동시에 Start/Stop을 호출해도 실행 상태가 일관되고 모든 워커가 끝나는지 확인합니다.
+func TestStartStopConcurrent(t *testing.T) {
+	config := DefaultConfig()
+	config.QPS = 0
+	config.Workers = 2
+	g := newTestGenerator(t, &fakeDB{}, config)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				g.Start()
+				g.Stop()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if g.IsRunning() {
+		t.Fatal("generator still running after every Stop")
+	}
+}
+
+// 쿼리 타입마다 지연시간이 다르면 by_label에 타입별로 따로 계산된 백분위수 세 벌이 나오는지 확인합니다.
+func TestQueryTypeLatencyByLabel(t *testing.T) {
+	delays := map[string]time.Duration{"simple": time.Millisecond, "filter": 5 * time.Millisecond, "aggregate": 20 * time.Millisecond}
+	fake := &fakeDB{delay: func(query string) time.Duration {
+		switch {
+		case strings.Contains(query, "GROUP BY"):
+			return delays["aggregate"]
+		case strings.Contains(query, "WHERE level"):
+			return delays["filter"]
+		default:
+			return delays["simple"]
+		}
+	}}
+
+	config := DefaultConfig()
+	config.QPS = 0
+	config.Workers = 3
+	config.QueryMix = QueryMix{Simple: 34, Filter: 33, Aggregate: 33}
+	g := newTestGenerator(t, fake, config)
+
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	waitFor(t, 10*time.Second, "30 successes per query type", func() bool {
+		byLabel := g.collector.GetMetrics().ByLabel
+		for queryType := range delays {
+			if byLabel[queryType].SuccessRequests < 30 {
+				return false
+			}
+		}
+		return true
+	})
+	g.Stop()
+
+	m := g.collector.GetMetrics()
+	if len(m.ByLabel) != len(delays) {
+		t.Fatalf("by_label has %d labels, want %d: %+v", len(m.ByLabel), len(delays), m.ByLabel)
+	}
+	var total int64
+	for queryType, delay := range delays {
+		lm := m.ByLabel[queryType]
+		total += lm.TotalRequests
+		if lm.P50Latency > lm.P95Latency || lm.P95Latency > lm.P99Latency {
+			t.Errorf("%s: percentiles not ordered: p50=%v p95=%v p99=%v", queryType, lm.P50Latency, lm.P95Latency, lm.P99Latency)
+		}
+		// 중앙값은 자기 타입의 지연시간 이상이고, 더 느린 타입의 지연시간보다는 작아야 함 (다른 타입과 섞이지 않음)
+		if lm.P50Latency < float64(delay.Milliseconds()) {
+			t.Errorf("%s: p50 = %vms, want >= %vms", queryType, lm.P50Latency, delay.Milliseconds())
+		}
+		for other, otherDelay := range delays {
+			if otherDelay > delay && lm.P50Latency >= float64(otherDelay.Milliseconds()) {
+				t.Errorf("%s: p50 = %vms, want < %s delay %vms", queryType, lm.P50Latency, other, otherDelay.Milliseconds())
+			}
+		}
+	}
+	if total != m.TotalRequests {
+		t.Errorf("sum of by_label total_requests = %d, want %d", total, m.TotalRequests)
+	}
+
+	// by_query_type은 같은 세 타입을 by_label과 같은 값으로 보고
+	if len(m.ByQueryType) != len(delays) {
+		t.Fatalf("by_query_type has %d types, want %d: %+v", len(m.ByQueryType), len(delays), m.ByQueryType)
+	}
+	for queryType := range delays {
+		stats, lm := m.ByQueryType[queryType], m.ByLabel[queryType]
+		if stats.Count != lm.SuccessRequests || stats.P50Latency != lm.P50Latency || stats.P95Latency != lm.P95Latency || stats.P99Latency != lm.P99Latency {
+			t.Errorf("%s: by_query_type %+v differs from by_label %+v", queryType, stats, lm)
+		}
+	}
+}
+
+// 램프업 중에는 워커가 모두 실행 중이지 않고, RampUp이 지나면 모두 실행 중인지 확인합니다.
+// 쿼리가 끝나지 않도록 오래 걸리게 하고, 진행 중인 쿼리 수를 실행 중인 워커 수로 봅니다.
+func TestRampUpStartsWorkersGradually(t *testing.T) {
+	const workers = 4
+	const rampUp = 400 * time.Millisecond
+	fake := &fakeDB{delay: func(string) time.Duration { return time.Minute }}
+
+	config := DefaultConfig()
+	config.QPS = 0
+	config.Workers = workers
+	config.RampUp = rampUp
+	g := newTestGenerator(t, fake, config)
+
+	start := time.Now()
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer g.ForceStop()
+
+	// i번째 워커는 RampUp × i / Workers 뒤에 시작하므로 첫 간격(100ms) 전에는 첫 워커만 실행 중
+	time.Sleep(rampUp / workers / 2)
+	if n := fake.inFlight.Load(); n >= workers {
+		t.Fatalf("%d workers running %v after Start, want fewer than %d during ramp-up", n, time.Since(start), workers)
+	}
+
+	waitFor(t, rampUp+2*time.Second, "all workers running", func() bool { return fake.inFlight.Load() == workers })
+	// 마지막 워커는 RampUp × (Workers-1) / Workers 뒤에 시작
+	if elapsed, last := time.Since(start), rampUp*(workers-1)/workers; elapsed < last {
+		t.Fatalf("all workers running after %v, want not before %v", elapsed, last)
+	}
+	time.Sleep(time.Until(start.Add(rampUp)))
+	if n := fake.inFlight.Load(); n != workers {
+		t.Fatalf("%d workers running after RampUp, want %d", n, workers)
+	}
+}
+
+// 진행 중인 쿼리가 느려도 Stop이 기다리지 않고 곧바로 끝나며, 취소된 쿼리는 성공도 실패도 아닌 aborted_requests(요청 수)로만 기록되는지 확인합니다.
+func TestStopCancelsSlowQuery(t *testing.T) {
+	const workers = 2
+	fake := &fakeDB{delay: func(string) time.Duration { return time.Minute }}
+
+	config := DefaultConfig()
+	config.QPS = 0
+	config.Workers = workers
+	g := newTestGenerator(t, fake, config)
+
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	waitFor(t, 2*time.Second, "slow queries in flight", func() bool { return fake.inFlight.Load() == workers })
+
+	start := time.Now()
+	m := g.Stop()
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Stop took %v with a slow query in flight, want well under the query's 1m", elapsed)
+	}
+	if n := fake.inFlight.Load(); n != 0 {
+		t.Fatalf("%d queries still in flight after Stop", n)
+	}
+	if m.AbortedRequests != workers {
+		t.Fatalf("aborted_requests = %d, want %d", m.AbortedRequests, workers)
+	}
+	if m.TotalRequests != 0 || m.SuccessRequests != 0 || m.FailedRequests != 0 || m.TimeoutRequests != 0 || m.ConnectionErrors != 0 {
+		t.Fatalf("canceled queries were counted as completed: %+v", m)
+	}
+}
+
+// 워커가 쿼리를 끝낸 뒤 다음 쿼리를 보내기까지의 간격이 ThinkTime 이상이고, QPS가 설정되어 있으면 여전히 상한인지 확인합니다.
+func TestThinkTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		qps       int
+		thinkTime time.Duration
+		jitter    time.Duration
+		minStart  time.Duration // 연속한 쿼리 시작 사이의 최소 간격 (QPS 상한)
+	}{
+		{"think time only", 0, 30 * time.Millisecond, 0, 0},
+		{"think time with jitter", 0, 20 * time.Millisecond, 20 * time.Millisecond, 0},
+		// 워커 1개, QPS 20 = 50ms 간격이 생각 시간(5ms)보다 길므로 QPS가 간격을 정함
+		{"qps is still the ceiling", 20, 5 * time.Millisecond, 0, 40 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDB{delay: func(string) time.Duration { return time.Millisecond }}
+			config := DefaultConfig()
+			config.QPS = tt.qps
+			config.Workers = 1
+			config.ThinkTime = tt.thinkTime
+			config.ThinkTimeJitter = tt.jitter
+			g := newTestGenerator(t, fake, config)
+
+			if err := g.Start(); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+			waitFor(t, 5*time.Second, "10 queries", func() bool { return fake.queries.Load() >= 10 })
+			g.Stop()
+
+			queries := fake.history()
+			for i := 1; i < len(queries); i++ {
+				prev, cur := queries[i-1], queries[i]
+				if gap := cur.start.Sub(prev.end); gap < tt.thinkTime {
+					t.Errorf("query %d started %v after the previous one ended, want >= think_time %v", i, gap, tt.thinkTime)
+				}
+				if gap := cur.start.Sub(prev.start); gap < tt.minStart {
+					t.Errorf("query %d started %v after the previous one started, want >= %v (qps %d)", i, gap, tt.minStart, tt.qps)
+				}
+			}
+		})
+	}
+}
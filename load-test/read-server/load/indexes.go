@@ -0,0 +1,54 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// ExpectedIndex는 부하 생성기의 번들 쿼리가 기대하는 logs 테이블 인덱스입니다.
+// 없으면 쿼리가 순차 스캔으로 실행되어 읽기 벤치마크가 인덱스 접근이 아니라 테이블 크기를 재게 됩니다.
+type ExpectedIndex struct {
+	Name    string // 인덱스 이름 (존재 여부는 이름으로 확인)
+	Columns string // logs 테이블의 인덱스 컬럼
+	Usage   string // 이 인덱스를 쓰는 쿼리
+}
+
+// ExpectedIndexes는 simple, filter, aggregate 쿼리가 쓰는 인덱스입니다 (-ensure-indexes).
+var ExpectedIndexes = []ExpectedIndex{
+	{
+		Name:    "idx_logs_timestamp",
+		Columns: "timestamp",
+		Usage:   "simple (ORDER BY timestamp DESC LIMIT 100), aggregate (timestamp > NOW() - INTERVAL '1 hour')",
+	},
+	{
+		Name:    "idx_logs_level_service_timestamp",
+		Columns: "level, service, timestamp",
+		Usage:   "filter (level = $1 AND service = $2 AND timestamp > NOW() - INTERVAL '1 hour' ORDER BY timestamp DESC)",
+	},
+}
+
+// EnsureIndexes는 ExpectedIndexes를 CREATE INDEX IF NOT EXISTS로 만들고, 인덱스마다 이미 있었는지 로그로 남깁니다.
+// 여러 번 실행해도 안전합니다. 큰 테이블에서는 인덱스 생성이 오래 걸리고 그동안 logs 쓰기를 막으므로 부하 실행 전에 호출합니다.
+func EnsureIndexes(ctx context.Context, db *sql.DB) error {
+	for _, index := range ExpectedIndexes {
+		var exists bool
+		if err := db.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", index.Name).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check index %s: %w", index.Name, err)
+		}
+		if exists {
+			log.Printf("Index %s already exists (used by %s)", index.Name, index.Usage)
+			continue
+		}
+
+		start := time.Now()
+		query := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON logs (%s)", index.Name, index.Columns)
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to create index %s: %w", index.Name, err)
+		}
+		log.Printf("Created index %s on logs (%s) in %v (used by %s)", index.Name, index.Columns, time.Since(start).Round(time.Millisecond), index.Usage)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package load
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"read-server/metrics"
+	"strconv"
+	"time"
+)
+
+// MetricsCSVInterval은 CSV 메트릭 로그에 행을 추가하는 간격입니다.
+var MetricsCSVInterval = time.Second
+
+// metricsCSVHeader는 CSV 메트릭 로그의 열입니다. 요청 수는 실행 시작(마지막 초기화) 이후 누적,
+// qps는 직전 행 이후 구간의 초당 요청 수, 지연시간은 그 시점까지의 누적 백분위수입니다 (/metrics와 같음).
+var metricsCSVHeader = []string{
+	"timestamp", "qps", "success_requests", "failed_requests",
+	"p50_latency_ms", "p95_latency_ms", "p99_latency_ms",
+}
+
+// metricsCSV는 실행 중 MetricsCSVInterval마다 메트릭 한 행을 덧붙이는 CSV 파일입니다 (-metrics-csv).
+// 행마다 파일에 바로 써서, 서버가 비정상 종료되어도 그때까지의 행은 남습니다.
+type metricsCSV struct {
+	file *os.File
+	w    *csv.Writer
+
+	// 구간 처리율 계산용 직전 행
+	prevTotal   int64
+	prevElapsed float64
+	hasPrev     bool
+}
+
+// SetMetricsCSV는 실행 중 메트릭을 기록할 CSV 파일 경로를 지정합니다 (빈 값 = 기록 안 함).
+// 파일은 실행을 시작할 때 이어 쓰기로 열고 실행이 끝나면 닫습니다. 부하 생성 시작 전에 호출해야 합니다.
+func (g *Generator) SetMetricsCSV(path string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.metricsCSVPath = path
+}
+
+// openMetricsCSV는 지정한 CSV 파일을 이어 쓰기로 엽니다. 경로가 없으면 nil입니다.
+// 빈 파일이면 헤더 행을 먼저 씁니다.
+func (g *Generator) openMetricsCSV() (*metricsCSV, error) {
+	if g.metricsCSVPath == "" {
+		return nil, nil
+	}
+
+	file, err := os.OpenFile(g.metricsCSVPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metrics CSV: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open metrics CSV: %w", err)
+	}
+
+	out := &metricsCSV{file: file, w: csv.NewWriter(file)}
+	if info.Size() == 0 {
+		if err := out.writeRow(metricsCSVHeader); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to write metrics CSV header: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// record는 m을 한 행으로 쓰고 바로 플러시합니다. 구간 처리율은 직전 행과의 차이로 계산하며,
+// 첫 행이거나 그 사이 메트릭이 초기화되었으면 실행 평균을 씁니다.
+func (c *metricsCSV) record(now time.Time, m metrics.Metrics) error {
+	qps := m.QPS
+	if c.hasPrev && m.TotalRequests >= c.prevTotal && m.Elapsed > c.prevElapsed {
+		qps = float64(m.TotalRequests-c.prevTotal) / (m.Elapsed - c.prevElapsed)
+	}
+	c.prevTotal, c.prevElapsed, c.hasPrev = m.TotalRequests, m.Elapsed, true
+
+	return c.writeRow([]string{
+		now.UTC().Format(time.RFC3339),
+		strconv.FormatFloat(qps, 'f', 2, 64),
+		strconv.FormatInt(m.SuccessRequests, 10),
+		strconv.FormatInt(m.FailedRequests, 10),
+		strconv.FormatFloat(m.P50Latency, 'f', -1, 64),
+		strconv.FormatFloat(m.P95Latency, 'f', -1, 64),
+		strconv.FormatFloat(m.P99Latency, 'f', -1, 64),
+	})
+}
+
+func (c *metricsCSV) writeRow(row []string) error {
+	if err := c.w.Write(row); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// close는 파일을 닫습니다. nil이면 아무것도 하지 않습니다.
+func (c *metricsCSV) close() {
+	if c == nil {
+		return
+	}
+	if err := c.file.Close(); err != nil {
+		log.Printf("Failed to close metrics CSV: %v", err)
+	}
+}
+
+// writeMetricsCSV는 실행 중 MetricsCSVInterval마다 현재 메트릭을 CSV 파일에 한 행씩 씁니다.
+// 쓰기에 실패하면 로그를 남기고 이번 실행의 기록을 멈춥니다 (부하 생성은 계속).
+func (g *Generator) writeMetricsCSV(out *metricsCSV, stopCh chan struct{}) {
+	ticker := time.NewTicker(MetricsCSVInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := out.record(now, g.collector.GetMetrics()); err != nil {
+				log.Printf("Failed to write metrics CSV, stopping CSV log for this run: %v", err)
+				return
+			}
+		case <-stopCh:
+			return
+		}
+	}
+}
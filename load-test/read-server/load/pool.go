@@ -0,0 +1,89 @@
+package load
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// PoolSettings는 부하 생성기가 쓰는 연결 풀(*sql.DB)의 설정입니다.
+type PoolSettings struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// SetPoolDefaults는 Config의 풀 설정이 0일 때 쓸 서버 기본값(시작 시 환경 변수로 정한 값)을 지정합니다.
+// 실행이 끝나면 풀을 이 값으로 되돌립니다. 부하 생성 시작 전에 호출해야 하며, g.db에 이미 이 값이 적용되어 있어야 합니다.
+func (g *Generator) SetPoolDefaults(defaults PoolSettings) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.poolDefaults = defaults
+	g.poolApplied.Store(&defaults)
+}
+
+// PoolSettings는 지금 g.db에 적용된 연결 풀 설정입니다 (/debug/config).
+// 최대 연결 수는 *sql.DB에서 직접 읽고, database/sql이 조회 방법을 제공하지 않는 유휴 연결 수와 수명은 마지막으로 적용한 값입니다.
+func (g *Generator) PoolSettings() PoolSettings {
+	var p PoolSettings
+	if applied := g.poolApplied.Load(); applied != nil {
+		p = *applied
+	}
+	p.MaxOpenConns = g.db.Stats().MaxOpenConnections
+	return p
+}
+
+// poolSettings는 config의 풀 설정입니다. 0인 항목은 defaults 값을 씁니다.
+func (c *Config) poolSettings(defaults PoolSettings) PoolSettings {
+	p := defaults
+	if c.MaxOpenConns > 0 {
+		p.MaxOpenConns = c.MaxOpenConns
+	}
+	if c.MaxIdleConns > 0 {
+		p.MaxIdleConns = c.MaxIdleConns
+	}
+	if c.ConnMaxLifetime > 0 {
+		p.ConnMaxLifetime = c.ConnMaxLifetime
+	}
+	return p
+}
+
+// checkPoolUnchanged는 실행 중인 리로드(SIGHUP)가 풀 설정을 바꾸지 않는지 확인합니다 (g.mu를 잡은 상태).
+// 풀 크기는 워커를 시작하기 전에만 바꾸므로, 바꾸려면 실행을 멈추고 설정을 바꾼 뒤 다시 시작해야 합니다.
+func (g *Generator) checkPoolUnchanged(config *Config) error {
+	current, next := g.config.poolSettings(g.poolDefaults), config.poolSettings(g.poolDefaults)
+	if current != next {
+		return fmt.Errorf("max_open_conns, max_idle_conns and conn_max_lifetime cannot be changed while running, stop the run first")
+	}
+	return nil
+}
+
+// applyPoolSettings는 현재 config의 연결 풀 설정을 g.db에 적용합니다.
+// 실행 중에 풀 크기를 바꾸면 연결을 기다리던 워커와 열린 연결 수가 뒤섞이므로, 워커를 시작하기 전에만 호출합니다 (g.mu를 잡은 상태).
+func (g *Generator) applyPoolSettings() {
+	p := g.config.poolSettings(g.poolDefaults)
+	g.setPool(p)
+	if p != g.poolDefaults {
+		log.Printf("Connection pool: max_open_conns=%d max_idle_conns=%d conn_max_lifetime=%s", p.MaxOpenConns, p.MaxIdleConns, p.ConnMaxLifetime)
+	}
+}
+
+// restorePoolSettings는 실행이 끝난 뒤 풀을 서버 기본값으로 되돌립니다 (g.mu를 잡은 상태).
+// 풀은 HTTP API, /readyz, /db/maintenance와 공유하므로 실행에 맞춘 값이 실행 밖의 요청에 남지 않게 합니다.
+func (g *Generator) restorePoolSettings() {
+	applied := g.poolApplied.Load()
+	if applied == nil || *applied == g.poolDefaults {
+		return
+	}
+	g.setPool(g.poolDefaults)
+	log.Printf("Connection pool restored to server defaults: max_open_conns=%d max_idle_conns=%d conn_max_lifetime=%s",
+		g.poolDefaults.MaxOpenConns, g.poolDefaults.MaxIdleConns, g.poolDefaults.ConnMaxLifetime)
+}
+
+func (g *Generator) setPool(p PoolSettings) {
+	g.db.SetMaxOpenConns(p.MaxOpenConns)
+	g.db.SetMaxIdleConns(p.MaxIdleConns)
+	g.db.SetConnMaxLifetime(p.ConnMaxLifetime)
+	g.poolApplied.Store(&p)
+}
@@ -0,0 +1,120 @@
+package load
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// testPoolDefaults는 테스트에서 쓰는 서버 기본 풀 설정입니다.
+var testPoolDefaults = PoolSettings{MaxOpenConns: 50, MaxIdleConns: 10, ConnMaxLifetime: time.Hour}
+
+// Config의 풀 설정이 Start할 때 부하 생성기의 *sql.DB에 적용되고, 실행이 끝나면 서버 기본값으로 돌아가는지 확인합니다.
+func TestPoolSettingsApplied(t *testing.T) {
+	fake := &fakeDB{delay: func(string) time.Duration { return 2 * time.Millisecond }}
+	config := DefaultConfig()
+	config.QPS = 0 // 제한 없음
+	config.Workers = 6
+	config.MaxOpenConns = 2
+	config.MaxIdleConns = 1
+	config.ConnMaxLifetime = 20 * time.Millisecond
+	g := newTestGenerator(t, fake, config)
+	g.SetPoolDefaults(testPoolDefaults)
+
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer g.Stop()
+
+	if got := g.db.Stats().MaxOpenConnections; got != 2 {
+		t.Fatalf("MaxOpenConnections = %d, want 2", got)
+	}
+	want := PoolSettings{MaxOpenConns: 2, MaxIdleConns: 1, ConnMaxLifetime: 20 * time.Millisecond}
+	if got := g.PoolSettings(); got != want {
+		t.Fatalf("PoolSettings = %+v, want %+v", got, want)
+	}
+
+	// 워커가 6개여도 동시에 진행 중인 쿼리는 열린 연결 수(2)를 넘을 수 없음
+	var peak int64
+	waitFor(t, 5*time.Second, "connections closed by conn_max_lifetime", func() bool {
+		if n := fake.inFlight.Load(); n > peak {
+			peak = n
+		}
+		return g.db.Stats().MaxLifetimeClosed > 0
+	})
+	if peak > 2 {
+		t.Fatalf("peak in-flight queries = %d, want at most max_open_conns 2", peak)
+	}
+
+	// 풀은 HTTP API 등과 공유하므로 실행이 끝나면 서버 기본값으로 돌아감
+	g.Stop()
+	if got := g.db.Stats().MaxOpenConnections; got != testPoolDefaults.MaxOpenConns {
+		t.Fatalf("MaxOpenConnections after stop = %d, want server default %d", got, testPoolDefaults.MaxOpenConns)
+	}
+	if got := g.PoolSettings(); got != testPoolDefaults {
+		t.Fatalf("PoolSettings after stop = %+v, want server defaults %+v", got, testPoolDefaults)
+	}
+}
+
+// 풀 설정이 0이면 SetPoolDefaults로 정한 서버 기본값을 적용하는지 확인합니다.
+func TestPoolSettingsDefaults(t *testing.T) {
+	config := DefaultConfig()
+	config.Workers = 1
+	g := newTestGenerator(t, &fakeDB{}, config)
+	g.SetPoolDefaults(PoolSettings{MaxOpenConns: 5, MaxIdleConns: 5, ConnMaxLifetime: time.Hour})
+
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer g.Stop()
+
+	if got := g.db.Stats().MaxOpenConnections; got != 5 {
+		t.Fatalf("MaxOpenConnections = %d, want server default 5", got)
+	}
+}
+
+// 실행 중인 리로드(SIGHUP)는 풀 설정 변경을 거부하고, 풀 설정이 같으면 적용한 풀을 유지한 채 다시 시작하는지 확인합니다.
+func TestReloadPoolSettings(t *testing.T) {
+	newConfig := func(workers, maxOpenConns int) *Config {
+		config := DefaultConfig()
+		config.QPS = 0
+		config.Workers = workers
+		config.MaxOpenConns = maxOpenConns
+		if err := config.Validate(); err != nil {
+			t.Fatalf("invalid config: %v", err)
+		}
+		return config
+	}
+	g := newTestGenerator(t, &fakeDB{delay: func(string) time.Duration { return time.Millisecond }}, newConfig(2, 3))
+	g.SetPoolDefaults(testPoolDefaults)
+
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer g.Stop()
+
+	restarted, err := g.Reload(newConfig(2, 4))
+	if err == nil || !strings.Contains(err.Error(), "max_open_conns") {
+		t.Fatalf("Reload with a new max_open_conns while running: restarted=%v err=%v, want a pool settings error", restarted, err)
+	}
+	if !g.IsRunning() || g.GetConfig().MaxOpenConns != 3 {
+		t.Fatalf("rejected Reload changed the run: running=%v max_open_conns=%d", g.IsRunning(), g.GetConfig().MaxOpenConns)
+	}
+	if got := g.db.Stats().MaxOpenConnections; got != 3 {
+		t.Fatalf("MaxOpenConnections after rejected Reload = %d, want 3", got)
+	}
+
+	// 풀 설정이 같으면 다시 시작하며, 그 사이에 서버 기본값으로 되돌리지 않음
+	if restarted, err := g.Reload(newConfig(4, 3)); err != nil || !restarted {
+		t.Fatalf("Reload with the same pool settings: restarted=%v err=%v, want a restart", restarted, err)
+	}
+	if got := g.db.Stats().MaxOpenConnections; got != 3 {
+		t.Fatalf("MaxOpenConnections after Reload = %d, want 3", got)
+	}
+
+	// 멈춘 뒤에는 풀 설정도 바꿀 수 있음
+	g.Stop()
+	if _, err := g.Reload(newConfig(2, 4)); err != nil {
+		t.Fatalf("Reload while stopped: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// 부하 생성기의 읽기 쿼리 (UsePrepared면 실행 시작 시 한 번 준비)
+const (
+	simpleQuerySQL = `
+		SELECT id, timestamp, level, service, message
+		FROM logs
+		ORDER BY timestamp DESC
+		LIMIT 100
+	`
+	filterQuerySQL = `
+		SELECT id, timestamp, level, service, message
+		FROM logs
+		WHERE level = $1
+		  AND service = $2
+		  AND timestamp > NOW() - INTERVAL '1 hour'
+		ORDER BY timestamp DESC
+		LIMIT 100
+	`
+	aggregateQuerySQL = `
+		SELECT
+			level,
+			COUNT(*) as count,
+			MIN(timestamp) as first_seen,
+			MAX(timestamp) as last_seen
+		FROM logs
+		WHERE timestamp > NOW() - INTERVAL '1 hour'
+		GROUP BY level
+		ORDER BY count DESC
+	`
+)
+
+// preparedStatements는 한 실행 동안 재사용하는 준비된 문장입니다 (쿼리 텍스트 → *sql.Stmt).
+// 준비된 문장은 PostgreSQL에서 연결마다 따로 존재하므로, 풀(g.db)에서 준비한 *sql.Stmt를 트랜잭션에서 쓰면
+// database/sql이 그 트랜잭션의 연결에 아직 준비되지 않은 경우에만 한 번 준비하고 이후로는 연결별로 재사용합니다
+// (워커 전용 연결, 재연결한 연결 모두 같은 방식).
+type preparedStatements map[string]*sql.Stmt
+
+// prepareStatements는 config.UsePrepared면 읽기 쿼리 세 개와 사용자 지정 쿼리를 준비합니다. 아니면 nil을 반환합니다.
+// 하나라도 실패하면 이미 준비한 문장을 닫고 에러를 반환하므로, 실행을 시작하기 전에 호출합니다.
+func (g *Generator) prepareStatements(config *Config) (preparedStatements, error) {
+	if !config.UsePrepared {
+		return nil, nil
+	}
+
+	queries := []string{simpleQuerySQL, filterQuerySQL, aggregateQuerySQL}
+	for _, q := range config.CustomQueries {
+		queries = append(queries, q.SQL)
+	}
+
+	stmts := make(preparedStatements, len(queries))
+	for _, query := range queries {
+		if _, ok := stmts[query]; ok {
+			continue
+		}
+		stmt, err := g.db.PrepareContext(context.Background(), query)
+		if err != nil {
+			stmts.close()
+			return nil, fmt.Errorf("failed to prepare statement: %w", err)
+		}
+		stmts[query] = stmt
+	}
+	return stmts, nil
+}
+
+func (s preparedStatements) close() {
+	for _, stmt := range s {
+		if err := stmt.Close(); err != nil {
+			log.Printf("Failed to close prepared statement: %v", err)
+		}
+	}
+}
+
+// queryRows는 tx에서 query를 실행합니다. 준비된 문장이 있으면 파싱/계획 없이 그 문장을 실행하고,
+// 없으면(UsePrepared가 false) 매번 쿼리 텍스트를 보냅니다.
+func (g *Generator) queryRows(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (*sql.Rows, error) {
+	if stmt, ok := g.statements[query]; ok {
+		return tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	}
+	return tx.QueryContext(ctx, query, args...)
+}
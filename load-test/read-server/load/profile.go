@@ -0,0 +1,64 @@
+package load
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// profiles는 이름으로 불러올 수 있는 표준 부하 시나리오입니다.
+// 매번 새 Config를 만들어 반환하므로 호출자가 수정해도 다른 요청에 영향을 주지 않습니다.
+var profiles = map[string]func() *Config{
+	// 로컬 확인용 가벼운 부하
+	"light": func() *Config {
+		return &Config{
+			QPS:            100,
+			Workers:        2,
+			Duration:       time.Minute,
+			QueryMix:       QueryMix{Simple: 60, Filter: 30, Aggregate: 10},
+			IsolationLevel: "READ COMMITTED",
+		}
+	},
+	// 기본 설정과 같은 혼합 부하
+	"default": DefaultConfig,
+	// 무제한 QPS로 최대 처리량 측정
+	"heavy": func() *Config {
+		return &Config{
+			QPS:            0,
+			Workers:        50,
+			Duration:       5 * time.Minute,
+			QueryMix:       QueryMix{Simple: 50, Filter: 35, Aggregate: 15},
+			IsolationLevel: "READ COMMITTED",
+		}
+	},
+	// SERIALIZABLE에서 집계 쿼리 비중을 높여 직렬화 오버헤드 확인
+	"serializable-stress": func() *Config {
+		return &Config{
+			QPS:            2000,
+			Workers:        30,
+			Duration:       3 * time.Minute,
+			QueryMix:       QueryMix{Simple: 30, Filter: 30, Aggregate: 40},
+			IsolationLevel: "SERIALIZABLE",
+		}
+	},
+}
+
+// ProfileNames는 등록된 프로파일 이름을 정렬하여 반환합니다.
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProfileConfig는 이름에 해당하는 프로파일의 설정을 반환합니다.
+func ProfileConfig(name string) (*Config, error) {
+	newConfig, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(ProfileNames(), ", "))
+	}
+	return newConfig(), nil
+}
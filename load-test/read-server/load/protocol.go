@@ -0,0 +1,36 @@
+package load
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// 쿼리 프로토콜 (Config.QueryProtocol)
+const (
+	// ProtocolExtended는 $1 파라미터를 그대로 보냅니다. lib/pq는 인자가 있으면 확장 프로토콜로
+	// Parse/Describe 후 Bind/Execute를 따로 보내므로 DB 왕복이 한 번 더 생깁니다.
+	ProtocolExtended = "extended"
+	// ProtocolSimple은 인자를 리터럴로 인라인해 인자 없는 쿼리로 보냅니다.
+	// lib/pq는 인자 없는 쿼리를 단순 프로토콜(Query 메시지 하나)로 한 번에 보냅니다.
+	ProtocolSimple = "simple"
+)
+
+// bind는 QueryProtocol에 따라 query와 인자를 반환합니다.
+// 단순 프로토콜이면 $N을 pq.QuoteLiteral로 이스케이프한 값으로 바꾸고 인자 없이 반환합니다.
+func (g *Generator) bind(query string, args ...string) (string, []interface{}) {
+	if g.config.QueryProtocol != ProtocolSimple {
+		params := make([]interface{}, len(args))
+		for i, arg := range args {
+			params[i] = arg
+		}
+		return query, params
+	}
+
+	// $1이 $10의 앞부분과 겹치지 않도록 큰 번호부터 바꿈
+	for i := len(args); i >= 1; i-- {
+		query = strings.ReplaceAll(query, fmt.Sprintf("$%d", i), pq.QuoteLiteral(args[i-1]))
+	}
+	return query, nil
+}
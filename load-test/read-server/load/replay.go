@@ -0,0 +1,158 @@
+package load
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxReplayLine은 재생 파일 한 줄의 최대 크기입니다.
+const maxReplayLine = 1 << 20
+
+// replayQuery는 재생 파일의 한 줄입니다.
+// 예: {"type": "filter", "level": "ERROR", "service": "api", "timestamp": "2024-01-01T00:00:00.120Z"}
+type replayQuery struct {
+	Type      string    `json:"type"`      // simple, filter, aggregate
+	Level     string    `json:"level"`     // filter 쿼리의 level (비어 있으면 랜덤)
+	Service   string    `json:"service"`   // filter 쿼리의 service (비어 있으면 랜덤)
+	Timestamp time.Time `json:"timestamp"` // 기록된 도착 시각 (ReplayTiming일 때만 사용)
+}
+
+// replayTrace는 재생 파일에서 읽은 쿼리와, ReplayTiming일 때 첫 쿼리 기준 각 쿼리의 도착 시각입니다.
+type replayTrace struct {
+	queries []replayQuery
+	offsets []time.Duration // ReplayTiming이 아니면 nil
+	period  time.Duration   // 반복 재생 시 한 바퀴 길이 (마지막 도착 시각 + 평균 간격)
+}
+
+// loadReplayTrace는 config.ReplayFile을 읽습니다. ReplayFile이 비어 있으면 nil을 반환합니다.
+// 파일 전체를 메모리에 올리므로 Start/Reload 시점에 한 번만 읽습니다.
+func loadReplayTrace(config *Config) (*replayTrace, error) {
+	if config.ReplayFile == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(config.ReplayFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay file: %w", err)
+	}
+	defer f.Close()
+
+	trace := &replayTrace{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxReplayLine)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var q replayQuery
+		if err := json.Unmarshal(scanner.Bytes(), &q); err != nil {
+			return nil, fmt.Errorf("replay file %s line %d: %w", config.ReplayFile, line, err)
+		}
+		switch q.Type {
+		case "simple", "filter", "aggregate":
+		default:
+			return nil, fmt.Errorf("replay file %s line %d: type must be simple, filter or aggregate, got %q", config.ReplayFile, line, q.Type)
+		}
+		if config.ReplayTiming && q.Timestamp.IsZero() {
+			return nil, fmt.Errorf("replay file %s line %d: timestamp is required with replay_timing", config.ReplayFile, line)
+		}
+		trace.queries = append(trace.queries, q)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read replay file: %w", err)
+	}
+	if len(trace.queries) == 0 {
+		return nil, fmt.Errorf("replay file %s has no queries", config.ReplayFile)
+	}
+
+	if config.ReplayTiming {
+		timestamps := make([]time.Time, len(trace.queries))
+		for i, q := range trace.queries {
+			timestamps[i] = q.Timestamp
+		}
+		trace.offsets, trace.period = replayOffsets(timestamps)
+	}
+	return trace, nil
+}
+
+// replayOffsets는 첫 시각 기준의 도착 시각과 반복 재생 시 한 바퀴 길이를 계산합니다.
+// 기록이 시간 순이 아니어도 도착 시각이 줄어들지 않도록 앞 값으로 맞춥니다.
+func replayOffsets(timestamps []time.Time) ([]time.Duration, time.Duration) {
+	offsets := make([]time.Duration, len(timestamps))
+	for i, ts := range timestamps {
+		offsets[i] = ts.Sub(timestamps[0])
+		if i > 0 && offsets[i] < offsets[i-1] {
+			offsets[i] = offsets[i-1]
+		}
+	}
+
+	// 다음 바퀴의 첫 요청은 마지막 요청 뒤 평균 간격만큼 지나서 보냄
+	last := offsets[len(offsets)-1]
+	if len(offsets) == 1 {
+		return offsets, last
+	}
+	return offsets, last + last/time.Duration(len(offsets)-1)
+}
+
+// replayCursor는 한 실행의 워커들이 공유하는 재생 위치입니다.
+// 워커들은 파일 순서대로 쿼리를 나눠 가지며, 완료 순서는 워커 수가 1일 때만 파일 순서와 같습니다.
+type replayCursor struct {
+	trace *replayTrace
+	loop  bool      // 파일 끝에 도달하면 처음부터 다시 재생 (Duration이 설정된 경우)
+	start time.Time // 재생 시작 시각 (도착 시각 기준점)
+
+	mu    sync.Mutex
+	pos   int
+	round int
+}
+
+func newReplayCursor(trace *replayTrace, loop bool) *replayCursor {
+	return &replayCursor{trace: trace, loop: loop, start: time.Now()}
+}
+
+// next는 다음 쿼리와 그 쿼리를 보낼 시각을 반환합니다 (ReplayTiming이 아니면 zero time).
+// 파일 끝에 도달했고 반복하지 않으면 false를 반환합니다.
+func (c *replayCursor) next() (replayQuery, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pos == len(c.trace.queries) {
+		if !c.loop {
+			return replayQuery{}, time.Time{}, false
+		}
+		c.pos = 0
+		c.round++
+	}
+
+	q := c.trace.queries[c.pos]
+	var due time.Time
+	if c.trace.offsets != nil {
+		due = c.start.Add(time.Duration(c.round)*c.trace.period + c.trace.offsets[c.pos])
+	}
+	c.pos++
+	return q, due, true
+}
+
+// waitUntil은 due까지 기다립니다. due가 zero time이거나 이미 지났으면 바로 반환하고,
+// 기다리는 중에 중지되면 false를 반환합니다.
+func waitUntil(due time.Time, stopCh <-chan struct{}) bool {
+	if due.IsZero() {
+		return true
+	}
+	d := time.Until(due)
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stopCh:
+		return false
+	}
+}
@@ -0,0 +1,225 @@
+package load
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 결과 행 검증에서 찾는 이상 종류 (RowAnomaly.Kind)
+const (
+	AnomalyTimestampOrder = "timestamp_order" // ORDER BY timestamp DESC 결과에서 뒤 행의 timestamp가 앞 행보다 큼
+	AnomalyDuplicateID    = "duplicate_id"    // 한 결과에 같은 id가 두 번 나옴
+	AnomalyFilterMismatch = "filter_mismatch" // filter 쿼리 결과의 level/service가 조건과 다름
+	AnomalyStaleRead      = "stale_read"      // simple 쿼리의 최신 timestamp가 같은 워커의 이전 simple 쿼리보다 과거 (단조 읽기 위반)
+	AnomalyAggregateRange = "aggregate_range" // aggregate 결과에서 count <= 0이거나 first_seen > last_seen
+)
+
+// maxRecentAnomalies는 상태에 보관하는 최근 이상 수입니다.
+const maxRecentAnomalies = 20
+
+// FNV-1a 64비트 상수 (hash/fnv와 같은 값, 행마다 할당하지 않도록 직접 계산)
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// RowAnomaly는 결과 행 검증에서 찾은 이상 하나입니다.
+type RowAnomaly struct {
+	Time      time.Time `json:"time"`
+	Kind      string    `json:"kind"`
+	QueryType string    `json:"query_type"`
+	Isolation string    `json:"isolation"`
+	Row       int       `json:"row,omitempty"` // 이상이 나온 행 번호 (1부터, 결과 전체에 대한 이상이면 생략)
+	Detail    string    `json:"detail"`
+	Checksum  string    `json:"checksum"` // 이상이 나온 결과 전체의 체크섬 (같은 결과인지 구분)
+}
+
+// RowVerification은 결과 행 검증 모드(VerifyRows)의 상태입니다. 성공한 쿼리만 검증합니다.
+type RowVerification struct {
+	Queries   int64            `json:"queries"`   // 검증한 쿼리 수
+	Rows      int64            `json:"rows"`      // 검증한 행 수
+	Anomalies int64            `json:"anomalies"` // 찾은 이상 수 (0이 아니면 일관성 위반)
+	ByKind    map[string]int64 `json:"by_kind"`
+	Recent    []RowAnomaly     `json:"recent"` // 최근 이상 (최대 20건, 오래된 순)
+}
+
+// rowVerifier는 워커들이 검증한 쿼리 결과를 모읍니다.
+type rowVerifier struct {
+	mu     sync.Mutex
+	status RowVerification
+	logged map[string]bool // 종류별로 처음 나온 이상만 로그에 남김
+}
+
+func newRowVerifier() *rowVerifier {
+	return &rowVerifier{
+		status: RowVerification{ByKind: map[string]int64{}, Recent: []RowAnomaly{}},
+		logged: map[string]bool{},
+	}
+}
+
+// rowCheck는 쿼리 하나의 결과 행을 읽으면서 체크섬을 누적하고 행 단위 불변식을 확인합니다.
+// level, service는 filter 쿼리의 조건입니다.
+type rowCheck struct {
+	queryType string
+	level     string
+	service   string
+
+	sum       uint64
+	rows      int
+	newest    time.Time // 첫 행(가장 최근)의 timestamp
+	prev      time.Time
+	ids       map[int64]bool
+	anomalies []RowAnomaly
+}
+
+func newRowCheck(queryType, level, service string) *rowCheck {
+	return &rowCheck{queryType: queryType, level: level, service: service, sum: fnvOffset64}
+}
+
+func (c *rowCheck) mixUint64(v uint64) {
+	for i := 0; i < 8; i++ {
+		c.sum ^= v & 0xff
+		c.sum *= fnvPrime64
+		v >>= 8
+	}
+}
+
+func (c *rowCheck) mixString(s string) {
+	for i := 0; i < len(s); i++ {
+		c.sum ^= uint64(s[i])
+		c.sum *= fnvPrime64
+	}
+	c.mixUint64(uint64(len(s)))
+}
+
+func (c *rowCheck) anomaly(kind, detail string) {
+	c.anomalies = append(c.anomalies, RowAnomaly{Kind: kind, QueryType: c.queryType, Row: c.rows, Detail: detail})
+}
+
+// logRow는 simple/filter 쿼리(ORDER BY timestamp DESC)의 행 하나를 확인합니다.
+func (c *rowCheck) logRow(id int64, timestamp time.Time, level, service string) {
+	c.rows++
+	c.mixUint64(uint64(id))
+	c.mixUint64(uint64(timestamp.UnixNano()))
+
+	if c.rows == 1 {
+		c.newest = timestamp
+		c.ids = make(map[int64]bool, 100)
+	} else if timestamp.After(c.prev) {
+		c.anomaly(AnomalyTimestampOrder, fmt.Sprintf("id %d has timestamp %s after previous row's %s",
+			id, timestamp.Format(time.RFC3339Nano), c.prev.Format(time.RFC3339Nano)))
+	}
+	c.prev = timestamp
+
+	if c.ids[id] {
+		c.anomaly(AnomalyDuplicateID, fmt.Sprintf("id %d returned twice", id))
+	}
+	c.ids[id] = true
+
+	if c.queryType == "filter" && (level != c.level || service != c.service) {
+		c.anomaly(AnomalyFilterMismatch, fmt.Sprintf("id %d has level=%s service=%s, want level=%s service=%s",
+			id, level, service, c.level, c.service))
+	}
+}
+
+// aggregateRow는 aggregate 쿼리의 행(level별 집계) 하나를 확인합니다.
+func (c *rowCheck) aggregateRow(level string, count int64, firstSeen, lastSeen time.Time) {
+	c.rows++
+	c.mixString(level)
+	c.mixUint64(uint64(count))
+	c.mixUint64(uint64(firstSeen.UnixNano()))
+	c.mixUint64(uint64(lastSeen.UnixNano()))
+
+	if count <= 0 {
+		c.anomaly(AnomalyAggregateRange, fmt.Sprintf("level %s has count %d", level, count))
+	}
+	if firstSeen.After(lastSeen) {
+		c.anomaly(AnomalyAggregateRange, fmt.Sprintf("level %s has first_seen %s after last_seen %s",
+			level, firstSeen.Format(time.RFC3339Nano), lastSeen.Format(time.RFC3339Nano)))
+	}
+}
+
+// checksum은 지금까지 읽은 행의 체크섬입니다 (행 순서까지 반영).
+func (c *rowCheck) checksum() string {
+	return fmt.Sprintf("%016x", c.sum)
+}
+
+// finish는 성공한 쿼리 하나의 검증 결과를 기록합니다.
+// lastNewest는 워커별로 유지하는 이전 simple 쿼리의 최신 timestamp로, 로그는 지워지지 않고 쌓이기만 하므로
+// 같은 워커의 다음 simple 쿼리는 이보다 과거의 최신 행을 볼 수 없습니다 (보면 오래된 스냅샷이나 레플리카를 읽은 것).
+func (v *rowVerifier) finish(c *rowCheck, isolation string, lastNewest *time.Time) {
+	if c.queryType == "simple" && c.rows > 0 {
+		if !lastNewest.IsZero() && c.newest.Before(*lastNewest) {
+			c.anomalies = append(c.anomalies, RowAnomaly{Kind: AnomalyStaleRead, QueryType: c.queryType,
+				Detail: fmt.Sprintf("newest timestamp %s is older than %s seen by the previous query",
+					c.newest.Format(time.RFC3339Nano), lastNewest.Format(time.RFC3339Nano))})
+		} else {
+			*lastNewest = c.newest
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.status.Queries++
+	v.status.Rows += int64(c.rows)
+	if len(c.anomalies) == 0 {
+		return
+	}
+	now, checksum := time.Now(), c.checksum()
+	for _, a := range c.anomalies {
+		a.Time = now
+		a.Isolation = isolation
+		a.Checksum = checksum
+		v.status.Anomalies++
+		v.status.ByKind[a.Kind]++
+		v.status.Recent = append(v.status.Recent, a)
+		if !v.logged[a.Kind] {
+			v.logged[a.Kind] = true
+			log.Printf("Row verification anomaly (%s, %s query, %s): %s", a.Kind, a.QueryType, isolation, a.Detail)
+		}
+	}
+	if n := len(v.status.Recent); n > maxRecentAnomalies {
+		v.status.Recent = append([]RowAnomaly(nil), v.status.Recent[n-maxRecentAnomalies:]...)
+	}
+}
+
+func (v *rowVerifier) snapshot() RowVerification {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	status := v.status
+	status.ByKind = make(map[string]int64, len(v.status.ByKind))
+	for kind, n := range v.status.ByKind {
+		status.ByKind[kind] = n
+	}
+	status.Recent = append([]RowAnomaly{}, v.status.Recent...)
+	return status
+}
+
+// RowVerification은 결과 행 검증 모드의 상태를 반환합니다.
+// VerifyRows로 실행한 적이 없으면 nil입니다. 실행이 끝난 뒤에도 다음 Start까지 유지됩니다.
+func (g *Generator) RowVerification() *RowVerification {
+	verifier := g.rowVerifier.Load()
+	if verifier == nil {
+		return nil
+	}
+	status := verifier.snapshot()
+	return &status
+}
+
+// logRowVerification은 결과 행 검증 결과를 로그에 출력합니다.
+func logRowVerification(status *RowVerification) {
+	log.Printf("Row verification: %d queries, %d rows, %d anomalies", status.Queries, status.Rows, status.Anomalies)
+	kinds := make([]string, 0, len(status.ByKind))
+	for kind := range status.ByKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		log.Printf("  %-16s %d", kind, status.ByKind[kind])
+	}
+}
@@ -0,0 +1,94 @@
+package load
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"read-server/metrics"
+	"time"
+)
+
+const (
+	webhookTimeout  = 5 * time.Second // 요청 한 번의 타임아웃
+	webhookAttempts = 3               // 최대 시도 횟수
+	webhookBackoff  = time.Second     // 재시도 간격 (시도마다 2배씩 증가)
+)
+
+// 실행 종료 사유
+const (
+	StopReasonDuration  = "duration"   // Duration 경과로 자동 종료
+	StopReasonManual    = "manual"     // Stop 호출로 종료
+	StopReasonReload    = "reload"     // 설정 리로드로 재시작
+	StopReasonConverged = "converged"  // p95 수렴으로 자동 종료 (StopOnConvergence)
+	StopReasonErrorRate = "error_rate" // 에러율 초과로 중단 (MaxErrorRate, 실패한 실행)
+	StopReasonReplayEnd = "replay_end" // 재생 파일을 끝까지 실행해 종료 (ReplayFile, Duration 미설정)
+	StopReasonForced    = "forced"     // ForceStop(긴급 중지)으로 종료
+)
+
+// CompletionEvent는 실행 종료 시 CompletionWebhook으로 전송되는 본문입니다.
+type CompletionEvent struct {
+	Reason     string          `json:"reason"`
+	FinishedAt time.Time       `json:"finished_at"`
+	Config     Config          `json:"config"`
+	Metrics    metrics.Metrics `json:"metrics"`
+
+	// 격리 수준 비교 모드일 때의 격리 수준별 결과
+	IsolationComparison []IsolationResult `json:"isolation_comparison,omitempty"`
+
+	// 수렴 감지를 켰을 때의 p95 수렴 상태
+	Convergence *ConvergenceStatus `json:"convergence,omitempty"`
+
+	// MaxErrorRate를 지정했을 때의 조기 중단 상태 (Aborted면 실패한 실행)
+	FailFast *FailFastStatus `json:"fail_fast,omitempty"`
+
+	// ChaosInterval을 지정했을 때의 연결 강제 종료 결과
+	Chaos *ChaosStatus `json:"chaos,omitempty"`
+
+	// DutyActive를 지정했을 때의 활성 구간별 결과
+	DutyCycle *DutyCycleStatus `json:"duty_cycle,omitempty"`
+
+	// VerifyRows를 켰을 때의 결과 행 검증 결과 (Anomalies가 0이 아니면 일관성 위반)
+	RowVerification *RowVerification `json:"row_verification,omitempty"`
+}
+
+var webhookClient = &http.Client{Timeout: webhookTimeout}
+
+// notifyCompletion은 최종 메트릭을 webhook URL로 POST합니다.
+// 실패 시 지수 백오프로 재시도하고, 모두 실패하면 로그만 남깁니다.
+func notifyCompletion(url string, event CompletionEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to encode completion webhook payload: %v", err)
+		return
+	}
+
+	backoff := webhookBackoff
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		err = postWebhook(url, body)
+		if err == nil {
+			log.Printf("Completion webhook delivered to %s (reason: %s)", url, event.Reason)
+			return
+		}
+
+		log.Printf("Completion webhook attempt %d/%d failed: %v", attempt, webhookAttempts, err)
+		if attempt < webhookAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+}
+
+func postWebhook(url string, body []byte) error {
+	resp, err := webhookClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
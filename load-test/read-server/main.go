@@ -1,23 +1,43 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"expvar"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"read-server/audit"
+	"read-server/grpcapi"
 	"read-server/handler"
 	"read-server/load"
 	"read-server/metrics"
+	"read-server/pb"
+	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
 )
 
+// 연결 풀 최대 연결 수 (/debug/config에 표시)
+const maxOpenConns = 50
+
 func main() {
+	// 부하 설정 파일 (지정 시 시작할 때 적용하고, SIGHUP을 받으면 다시 읽음)
+	configPath := flag.String("config", "", "path to load config JSON file (reloaded on SIGHUP)")
+	// 실행 중 초당 메트릭을 덧붙일 CSV 파일 (스프레드시트 분석용)
+	metricsCSVPath := flag.String("metrics-csv", "", "append one CSV row of metrics per second during each run to this file")
+	// 번들 쿼리가 기대하는 인덱스를 시작 시 생성 (순차 스캔이 아닌 인덱스 접근을 측정하도록)
+	ensureIndexes := flag.Bool("ensure-indexes", false, "create the indexes the bundled read queries expect (CREATE INDEX IF NOT EXISTS) before serving")
+	flag.Parse()
+
 	// 환경 변수 읽기
 	dbHost := getEnv("DB_HOST", "localhost")
 	dbPort := getEnv("DB_PORT", "5432")
@@ -25,12 +45,77 @@ func main() {
 	dbUser := getEnv("DB_USER", "postgres")
 	dbPassword := getEnv("DB_PASSWORD", "postgres")
 	serverPort := getEnv("SERVER_PORT", "8081")
+	auditLogFile := getEnv("AUDIT_LOG_FILE", "")
+	grpcPort := getEnv("GRPC_PORT", "9081")
+
+	// 조회 API 1회 최대 반환 행 수
+	maxResultLimit, err := strconv.Atoi(getEnv("MAX_RESULT_LIMIT", strconv.Itoa(handler.DefaultMaxLimit)))
+	if err != nil {
+		log.Fatalf("Invalid MAX_RESULT_LIMIT: %v", err)
+	}
+
+	// 조회 API 동시 실행 한도 (0 = 무제한)
+	maxConcurrentQueries, err := strconv.Atoi(getEnv("MAX_CONCURRENT_QUERIES", strconv.Itoa(handler.DefaultMaxConcurrentQueries)))
+	if err != nil {
+		log.Fatalf("Invalid MAX_CONCURRENT_QUERIES: %v", err)
+	}
+
+	// 연결 풀 설정 (연결 재생성(churn)은 /metrics의 pool로 확인)
+	maxIdleConns, err := strconv.Atoi(getEnv("DB_MAX_IDLE_CONNS", "10"))
+	if err != nil {
+		log.Fatalf("Invalid DB_MAX_IDLE_CONNS: %v", err)
+	}
+	connMaxLifetime, err := time.ParseDuration(getEnv("DB_CONN_MAX_LIFETIME", "1h"))
+	if err != nil {
+		log.Fatalf("Invalid DB_CONN_MAX_LIFETIME: %v", err)
+	}
+
+	// HTTP 서버 타임아웃 (0 = 제한 없음)
+	// 읽기/쓰기 타임아웃은 가장 오래 걸리는 요청(큰 배치 INSERT, /db/maintenance 등)보다 길어야 함
+	readTimeout, err := durationEnv("HTTP_READ_TIMEOUT", "15s")
+	if err != nil {
+		log.Fatalf("Invalid HTTP_READ_TIMEOUT: %v", err)
+	}
+	writeTimeout, err := durationEnv("HTTP_WRITE_TIMEOUT", "15s")
+	if err != nil {
+		log.Fatalf("Invalid HTTP_WRITE_TIMEOUT: %v", err)
+	}
+	idleTimeout, err := durationEnv("HTTP_IDLE_TIMEOUT", "60s")
+	if err != nil {
+		log.Fatalf("Invalid HTTP_IDLE_TIMEOUT: %v", err)
+	}
+
+	// /readyz 판정 기준 (최근 윈도우 에러율, DB ping 지연)
+	var healthThresholds handler.HealthThresholds
+	healthThresholds.Window, err = durationEnv("READYZ_WINDOW", handler.DefaultHealthWindow.String())
+	if err != nil {
+		log.Fatalf("Invalid READYZ_WINDOW: %v", err)
+	}
+	healthThresholds.DegradedErrorRate, err = strconv.ParseFloat(getEnv("READYZ_DEGRADED_ERROR_RATE", strconv.FormatFloat(handler.DefaultDegradedErrorRate, 'g', -1, 64)), 64)
+	if err != nil {
+		log.Fatalf("Invalid READYZ_DEGRADED_ERROR_RATE: %v", err)
+	}
+	healthThresholds.UnhealthyErrorRate, err = strconv.ParseFloat(getEnv("READYZ_UNHEALTHY_ERROR_RATE", strconv.FormatFloat(handler.DefaultUnhealthyErrorRate, 'g', -1, 64)), 64)
+	if err != nil {
+		log.Fatalf("Invalid READYZ_UNHEALTHY_ERROR_RATE: %v", err)
+	}
+	healthThresholds.DegradedPingLatency, err = durationEnv("READYZ_DEGRADED_PING", handler.DefaultDegradedPingLatency.String())
+	if err != nil {
+		log.Fatalf("Invalid READYZ_DEGRADED_PING: %v", err)
+	}
+	if err := healthThresholds.Validate(); err != nil {
+		log.Fatalf("Invalid READYZ_* thresholds: %v", err)
+	}
+
+	// 추가 연결 파라미터 (예: "connect_timeout=5 target_session_attrs=read-write")
+	// application_name 기본값으로 pg_stat_activity에서 부하 연결을 구분
+	dbParams, err := connParams("loadtest-read-server", getEnv("DB_EXTRA_PARAMS", ""))
+	if err != nil {
+		log.Fatalf("Invalid DB_EXTRA_PARAMS: %v", err)
+	}
 
 	// PostgreSQL 연결
-	connStr := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		dbHost, dbPort, dbUser, dbPassword, dbName,
-	)
+	connStr := connString(dbHost, dbPort, dbUser, dbPassword, dbName, dbParams)
 
 	log.Printf("Connecting to PostgreSQL at %s:%s/%s", dbHost, dbPort, dbName)
 
@@ -41,9 +126,9 @@ func main() {
 	defer db.Close()
 
 	// 연결 풀 설정
-	db.SetMaxOpenConns(50)
-	db.SetMaxIdleConns(10)
-	db.SetConnMaxLifetime(time.Hour)
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns)
+	db.SetConnMaxLifetime(connMaxLifetime)
 
 	// DB 연결 확인
 	if err := db.Ping(); err != nil {
@@ -52,49 +137,140 @@ func main() {
 
 	log.Println("Successfully connected to PostgreSQL")
 
+	if *ensureIndexes {
+		if err := load.EnsureIndexes(context.Background(), db); err != nil {
+			log.Fatalf("Failed to ensure indexes: %v", err)
+		}
+	}
+
 	// 메트릭 컬렉터 초기화
 	collector := metrics.NewCollector()
+	collector.SetDB(db)
 
 	// 부하 생성기 초기화
 	defaultConfig := load.DefaultConfig()
+	if *configPath != "" {
+		defaultConfig, err = load.LoadConfigFile(*configPath)
+		if err != nil {
+			log.Fatalf("Failed to load config: %v", err)
+		}
+		log.Printf("Loaded load config from %s", *configPath)
+	}
 	generator := load.NewGenerator(db, defaultConfig, collector)
+	generator.SetPoolDefaults(load.PoolSettings{
+		MaxOpenConns:    maxOpenConns,
+		MaxIdleConns:    maxIdleConns,
+		ConnMaxLifetime: connMaxLifetime,
+	})
+	collector.SetTargetRate(generator.TargetRate)
+	if *metricsCSVPath != "" {
+		generator.SetMetricsCSV(*metricsCSVPath)
+		log.Printf("Writing per-second metrics CSV to %s", *metricsCSVPath)
+	}
+
+	// 감사 로그 초기화 (AUDIT_LOG_FILE 미지정 시 표준 출력)
+	auditLog, err := audit.Open(auditLogFile)
+	if err != nil {
+		log.Fatalf("Failed to open audit log: %v", err)
+	}
+	defer auditLog.Close()
+
+	// /debug/config에 보여 줄 실제 설정 (DB 비밀번호 제외)
+	runtimeConfig := handler.RuntimeConfig{
+		Database: handler.DatabaseSettings{
+			Host:            dbHost,
+			Port:            dbPort,
+			Name:            dbName,
+			User:            dbUser,
+			MaxOpenConns:    maxOpenConns,
+			MaxIdleConns:    maxIdleConns,
+			ConnMaxLifetime: connMaxLifetime.String(),
+			Params:          paramMap(dbParams),
+		},
+		Server: handler.ServerSettings{
+			HTTPPort:     serverPort,
+			GRPCPort:     grpcPort,
+			ReadTimeout:  readTimeout.String(),
+			WriteTimeout: writeTimeout.String(),
+			IdleTimeout:  idleTimeout.String(),
+			AuditLogFile: auditLogFile,
+			ConfigFile:   *configPath,
+		},
+		Limits: handler.LimitSettings{
+			MaxResultLimit:       maxResultLimit,
+			MaxConcurrentQueries: maxConcurrentQueries,
+		},
+		Env: handler.EnvSnapshot(envKeys),
+	}
 
 	// 핸들러 초기화
-	readHandler := handler.NewReadHandler(db, collector)
-	loadHandler := handler.NewLoadHandler(generator, collector)
+	readHandler := handler.NewReadHandler(db, collector, maxResultLimit)
+	limiter := handler.NewConcurrencyLimiter(maxConcurrentQueries, collector)
+	loadHandler := handler.NewLoadHandler(generator, collector, auditLog)
+	dbHandler := handler.NewDBHandler(db)
+	healthChecker := handler.NewHealthChecker(db, collector, healthThresholds)
+	healthChecker.Start()
+
+	// HTTP 라우트별 요청 수/처리 시간 (/metrics/http, /debug/vars)
+	httpMetrics := metrics.NewHTTPMetrics()
+	expvar.Publish("http_routes", expvar.Func(func() any { return httpMetrics.Snapshot() }))
 
 	// 라우터 설정
 	router := mux.NewRouter()
+	router.Use(handler.HTTPMetricsMiddleware(httpMetrics))
+	// 없는 경로/메서드도 다른 에러와 같은 JSON 형식으로 응답
+	router.NotFoundHandler = http.HandlerFunc(handler.NotFound)
+	router.MethodNotAllowedHandler = http.HandlerFunc(handler.MethodNotAllowed)
 
 	// 로그 조회 API
-	router.HandleFunc("/logs", readHandler.GetLogs).Methods("GET")
-	router.HandleFunc("/logs/search", readHandler.SearchLogs).Methods("GET")
-	router.HandleFunc("/logs/stats", readHandler.GetStats).Methods("GET")
+	router.HandleFunc("/logs", limiter.Wrap(readHandler.GetLogs)).Methods("GET")
+	router.HandleFunc("/logs/search", limiter.Wrap(readHandler.SearchLogs)).Methods("GET")
+	router.HandleFunc("/logs/stats", limiter.Wrap(readHandler.GetStats)).Methods("GET")
+	router.HandleFunc("/logs/bulk-get", limiter.Wrap(readHandler.BulkGetLogs)).Methods("POST")
 
 	// 부하 제어 API
 	router.HandleFunc("/load/start", loadHandler.Start).Methods("POST")
 	router.HandleFunc("/load/stop", loadHandler.Stop).Methods("POST")
 	router.HandleFunc("/load/config", loadHandler.GetConfig).Methods("GET")
 	router.HandleFunc("/load/config", loadHandler.UpdateConfig).Methods("POST")
+	router.HandleFunc("/load/config/profile", loadHandler.UpdateConfigFromProfile).Methods("POST")
+	router.HandleFunc("/load/qps", loadHandler.SetQPS).Methods("POST")
 	router.HandleFunc("/load/status", loadHandler.GetStatus).Methods("GET")
+	router.HandleFunc("/load/status/history", loadHandler.GetStatusHistory).Methods("GET")
+	router.HandleFunc("/load/run/export", loadHandler.ExportRun).Methods("GET")
 
 	// 메트릭 API
 	router.HandleFunc("/metrics", loadHandler.GetMetrics).Methods("GET")
 	router.HandleFunc("/metrics/reset", loadHandler.ResetMetrics).Methods("POST")
+	router.HandleFunc("/metrics/baseline", loadHandler.SaveBaseline).Methods("POST")
+	router.HandleFunc("/metrics/compare/stats", loadHandler.CompareStats).Methods("GET")
+	router.HandleFunc("/metrics/compare/import", loadHandler.CompareImport).Methods("POST")
+	router.HandleFunc("/metrics/heatmap", loadHandler.GetHeatmap).Methods("GET")
+	router.HandleFunc("/metrics/prometheus", loadHandler.GetPrometheusMetrics).Methods("GET")
+	router.HandleFunc("/metrics/http", handler.HTTPMetricsHandler(httpMetrics)).Methods("GET")
+	router.Handle("/debug/vars", expvar.Handler()).Methods("GET")
+	router.HandleFunc("/debug/config", handler.DebugConfigHandler(runtimeConfig, generator)).Methods("GET")
+
+	// DB 통계 API (PostgreSQL 쪽 집계)
+	router.HandleFunc("/db/query-stats", dbHandler.QueryStats).Methods("GET")
+	router.HandleFunc("/db/table-stats", dbHandler.TableStats).Methods("GET")
+	router.HandleFunc("/db/maintenance", dbHandler.Maintenance).Methods("POST")
+	router.HandleFunc("/db/locks", dbHandler.Locks).Methods("GET")
 
 	// 헬스체크
 	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintf(w, "OK")
 	}).Methods("GET")
+	router.HandleFunc("/readyz", healthChecker.Readyz).Methods("GET")
 
 	// HTTP 서버 시작
 	srv := &http.Server{
 		Addr:         ":" + serverPort,
 		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+		IdleTimeout:  idleTimeout,
 	}
 
 	// Graceful shutdown 설정
@@ -105,13 +281,36 @@ func main() {
 		}
 	}()
 
+	// gRPC 부하 제어 서버 시작 (HTTP와 별도 포트)
+	grpcServer := grpc.NewServer()
+	pb.RegisterLoadControlServer(grpcServer, grpcapi.NewServer(generator, collector))
+
+	go func() {
+		lis, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatalf("Failed to listen on gRPC port: %v", err)
+		}
+		log.Printf("gRPC load control listening on port %s", grpcPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}()
+
 	// 종료 시그널 대기
+	// 종료 시그널을 받을 때까지 SIGHUP마다 설정 파일을 다시 적용
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-	<-sigCh
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigCh {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		reloadConfig(generator, auditLog, *configPath)
+	}
 
 	log.Println("Shutting down server...")
 
+	grpcServer.GracefulStop()
+
 	// 부하 생성기 중지
 	if generator.IsRunning() {
 		log.Println("Stopping load generator...")
@@ -121,6 +320,65 @@ func main() {
 	log.Println("Server stopped")
 }
 
+// reloadConfig는 설정 파일을 다시 읽어 부하 생성기에 적용하고 결과를 로그로 남깁니다.
+// 실행 중이면 새 설정으로 재시작하며, 누적 메트릭은 유지됩니다.
+func reloadConfig(generator *load.Generator, auditLog *audit.Logger, path string) {
+	if path == "" {
+		log.Println("Received SIGHUP but no -config file was given, ignoring")
+		return
+	}
+
+	config, err := load.LoadConfigFile(path)
+	if err != nil {
+		log.Printf("Config reload failed, keeping current config: %v", err)
+		return
+	}
+
+	before := *generator.GetConfig()
+	restarted, err := generator.Reload(config)
+	if err != nil {
+		log.Printf("Config reload failed, keeping current config: %v", err)
+		return
+	}
+
+	auditLog.Record(audit.Event{
+		Action: "config_reload",
+		Remote: "SIGHUP",
+		Detail: path,
+		Before: before,
+		After:  *config,
+	})
+
+	if restarted {
+		log.Printf("Config reloaded from %s, load generator restarted with new config", path)
+	} else {
+		log.Printf("Config reloaded from %s", path)
+	}
+}
+
+// envKeys는 서버가 읽는 환경 변수 목록입니다 (/debug/config의 env).
+var envKeys = []string{
+	"DB_HOST",
+	"DB_PORT",
+	"DB_NAME",
+	"DB_USER",
+	"DB_PASSWORD",
+	"DB_MAX_IDLE_CONNS",
+	"DB_CONN_MAX_LIFETIME",
+	"SERVER_PORT",
+	"GRPC_PORT",
+	"AUDIT_LOG_FILE",
+	"HTTP_READ_TIMEOUT",
+	"HTTP_WRITE_TIMEOUT",
+	"HTTP_IDLE_TIMEOUT",
+	"READYZ_WINDOW",
+	"READYZ_DEGRADED_ERROR_RATE",
+	"READYZ_UNHEALTHY_ERROR_RATE",
+	"READYZ_DEGRADED_PING",
+	"MAX_RESULT_LIMIT",
+	"MAX_CONCURRENT_QUERIES",
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -128,3 +386,15 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// durationEnv는 Go duration 형식(예: 30s, 5m)의 환경 변수를 읽습니다. 음수는 에러입니다.
+func durationEnv(key, defaultValue string) (time.Duration, error) {
+	d, err := time.ParseDuration(getEnv(key, defaultValue))
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative, got %s", d)
+	}
+	return d, nil
+}
@@ -1,116 +1,338 @@
 package metrics
 
 import (
-	"sort"
+	"database/sql"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type Metrics struct {
-	TotalRequests   int64         `json:"total_requests"`
-	SuccessRequests int64         `json:"success_requests"`
-	FailedRequests  int64         `json:"failed_requests"`
-	QPS             float64       `json:"qps"`
-	AvgLatency      float64       `json:"avg_latency_ms"`
-	P50Latency      float64       `json:"p50_latency_ms"`
-	P95Latency      float64       `json:"p95_latency_ms"`
-	P99Latency      float64       `json:"p99_latency_ms"`
-	StartTime       time.Time     `json:"start_time"`
-	Elapsed         float64       `json:"elapsed_seconds"`
+	TotalRequests   int64   `json:"total_requests"`
+	SuccessRequests int64   `json:"success_requests"`
+	FailedRequests  int64   `json:"failed_requests"`
+	QPS             float64 `json:"qps"`
+	RowsRead        int64   `json:"rows_read"`
+	RowsPerSecond   float64 `json:"rows_per_second"`
+	AvgLatency      float64 `json:"avg_latency_ms"`
+	P50Latency      float64 `json:"p50_latency_ms"`
+	P95Latency      float64 `json:"p95_latency_ms"`
+	P99Latency      float64 `json:"p99_latency_ms"`
+	MinLatency      float64 `json:"min_latency_ms"` // 기록된 지연시간 중 가장 짧은 값 (ms 미만 포함, 샘플이 없으면 0)
+	MaxLatency      float64 `json:"max_latency_ms"` // 가장 긴 값 (한 번의 극단적인 지연을 찾는 용도)
+	// 설정(percentiles)으로 지정한 백분위수(ms, 키는 "p75", "p99.9" 형식). 기본값은 위 p50/p95/p99와 같은 값
+	Percentiles map[string]float64 `json:"percentiles"`
+	StartTime   time.Time          `json:"start_time"`
+	Elapsed     float64            `json:"elapsed_seconds"`
+
+	// 쿼리 타임아웃 (statement_timeout 또는 QueryTimeout 초과로 취소된 요청, FailedRequests와 별도)
+	TimeoutRequests int64   `json:"timeout_requests"`
+	TimeoutRate     float64 `json:"timeout_rate"` // TimeoutRequests / TotalRequests
+
+	// 연결 끊김 (백엔드 종료, 네트워크 에러 등으로 실패한 요청, FailedRequests와 별도)
+	ConnectionErrors int64 `json:"connection_errors"`
+
+	// 강제 중지(POST /load/stop?force=true)로 진행 중에 취소된 요청. 끝나지 않은 요청이므로 TotalRequests와 라벨별 메트릭에 포함하지 않음
+	AbortedRequests int64 `json:"aborted_requests"`
+
+	// 데이터베이스 연결이 끊겼다가(예: PostgreSQL 재시작) 부하 생성기의 요청이 다시 성공한 횟수와 끊겨 있던 시간의 합 (진행 중인 구간 포함)
+	Reconnects      int64   `json:"reconnects"`
+	DowntimeSeconds float64 `json:"downtime_seconds"`
+
+	LatencyBuckets []LatencyBucket `json:"latency_buckets"` // 지연시간 히스토그램 (LatencyBucketEdges 기준)
+
+	// 지연시간 샘플링 (LatencySampleRate < 1이면 백분위수는 표본 기준, latency_buckets는 1/rate 배로 보정한 추정치)
+	LatencySampleRate float64 `json:"latency_sample_rate"`
+	LatencySamples    int     `json:"latency_samples"` // 기록된 지연시간 샘플 수
+	// 기록하지 못하고 버린 지연시간 샘플 수. 히스토그램은 샘플을 버리지 않으므로 항상 0 (샘플 수 상한이 있던 때와 같은 응답 형식 유지)
+	DroppedSamples int64 `json:"dropped_samples"`
+
+	// 워밍업 제외 (WarmupExclude를 지정한 경우). 위의 지연시간 통계에는 워밍업 구간에 시작한 요청이 빠져 있음
+	Warmup *WarmupStats `json:"warmup,omitempty"`
+
+	// 첫 행까지 vs 마지막 행까지 지연시간 (부하 생성기의 fetch_latency 설정 시, 기록된 쿼리가 없으면 생략)
+	FetchLatency *FetchLatency `json:"fetch_latency,omitempty"`
+
+	// 라벨별 메트릭 (위의 최상위 필드는 모든 라벨의 합계)
+	ByLabel map[string]LabelMetrics `json:"by_label,omitempty"`
+
+	// 쿼리 타입별 지연시간 (RecordSuccessTyped로 기록한 라벨만, by_label의 같은 라벨과 같은 값)
+	ByQueryType map[string]LatencyStats `json:"by_query_type,omitempty"`
+
+	// 일반 실패(failed_requests)의 원인별 건수. 키는 PostgreSQL 에러 조건 이름(serialization_failure, deadlock_detected,
+	// too_many_connections 등)이며 원인을 알 수 없으면 "unknown". 실패가 없으면 생략
+	FailuresByCode map[string]int64 `json:"failures_by_code,omitempty"`
+
+	// 목표 대비 실제 처리율 (target_rate 0 = 무제한). achieved_ratio가 1보다 한참 작으면 목표에 도달하지 못하는 중
+	TargetRate    float64 `json:"target_rate"`    // 부하 설정에서 계산한 목표 초당 쿼리 수
+	AchievedRate  float64 `json:"achieved_rate"`  // 실제 초당 쿼리 수 (qps와 같음)
+	AchievedRatio float64 `json:"achieved_ratio"` // achieved_rate / target_rate
+
+	// 처리율 제한 대기 (qps/tps를 지정한 부하 생성기 워커만). 비율이 높으면 목표가 처리 능력보다 낮아 워커가 놀고 있고,
+	// 0에 가까우면 워커가 쉬지 않고 일하는 포화 상태
+	LimiterWaitSeconds float64 `json:"limiter_wait_seconds"` // 워커들이 다음 틱을 기다린 시간의 합
+	LimiterWaitRatio   float64 `json:"limiter_wait_ratio"`   // 워커 시간 중 대기 비율 (대기 / (대기 + 작업))
+
+	Pool *PoolMetrics `json:"pool,omitempty"` // DB 연결 풀 상태 (SetDB로 지정한 경우)
+
+	// 조회 API 동시 실행 제한 (부하 생성기 쿼리는 포함하지 않음)
+	InFlightRequests int64 `json:"in_flight_requests"` // 현재 처리 중인 요청 수
+	RejectedRequests int64 `json:"rejected_requests"`  // 동시 실행 한도 초과로 거부된 요청 수
 }
 
 type Collector struct {
-	mu              sync.RWMutex
-	totalRequests   int64
-	successRequests int64
-	failedRequests  int64
-	latencies       []time.Duration
-	startTime       time.Time
-	maxLatencies    int
+	// mu는 샤드 밖의 상태(연결 풀, 목표 처리율, 분포 비교 기준, 연결 끊김 구간)를 보호합니다.
+	// sampleRate, warmupUntil과 current의 교체(Reset)는 mu와 모든 샤드를 잠그고 하므로, 둘 중 하나만 잡아도 일관되게 읽을 수 있습니다.
+	mu sync.RWMutex
+
+	// 마지막 Reset 이후의 카운터 (window.go). 기록 경로는 잠금 없이 읽고 더함
+	current atomic.Pointer[window]
+	// 지금까지 만든 워커별 기록기 수 (NewRecorder가 카운터 묶음을 고르는 데 씀)
+	recorders atomic.Int64
+
+	// 지연시간 히스토그램, 히트맵, 라벨별 지연시간 (shard.go)
+	shards       []*shard
+	maxLatencies int // 샘플을 그대로 보관하는 보조 분포의 샤드당 최대 샘플 수 (메모리 제한, maxLatencySamples를 샤드 수로 나눈 값)
+
+	inFlight atomic.Int64 // 현재 처리 중인 조회 API 요청 수 (게이지, Reset 대상 아님)
+	rejected atomic.Int64 // 동시 실행 한도 초과로 거부된 요청 수
+
+	// 지연시간 샘플링 비율 (SetLatencySampleRate, Reset 대상 아님)
+	sampleRate float64
+
+	// Metrics.Percentiles로 보고할 백분위수 (SetPercentiles, Reset 대상 아님)
+	percentiles []float64
+
+	// 성공/실패를 함께 전달할 추가 출력 (AddSink, Reset 대상 아님). 기록 경로에서 잠금 없이 읽음
+	sinks atomic.Pointer[[]Sink]
+
+	// RecordSuccessTyped로 기록한 라벨 (Metrics.ByQueryType 대상, Reset 대상 아님)
+	queryTypes sync.Map
+
+	// 워밍업 제외 (SetWarmupExclude, Reset 대상 아님). warmupUntil 이전에 시작한 요청의 지연시간은 따로 기록
+	warmup      time.Duration
+	warmupUntil time.Time
+
+	// 목표 처리율 (SetTargetRate, 스냅샷마다 호출)
+	targetRate func() float64
+
+	// 연결 풀 메트릭 (SetDB, poolBase는 Reset 시점의 누적 카운터)
+	db       *sql.DB
+	poolBase sql.DBStats
+
+	// 연결이 끊긴 구간 (RecordDisconnect/RecordRecovered, outage.go)
+	outage outage
+
+	// 분포 비교 기준 (SaveBaseline, Reset 대상 아님)
+	baseline        *latencyHistogram
+	baselineSavedAt time.Time
 }
 
+// maxLatencySamples는 샘플을 그대로 보관하는 보조 분포에서 전체 샤드에 저장할 최대 샘플 수입니다 (최대 10만개).
+// 주 지연시간과 라벨별/워밍업 지연시간은 히스토그램(histogram.go)에 누적하므로 이 상한이 없습니다.
+const maxLatencySamples = 100000
+
 func NewCollector() *Collector {
-	return &Collector{
-		latencies:    make([]time.Duration, 0, 100000),
-		startTime:    time.Now(),
-		maxLatencies: 100000,
+	n := shardCount()
+	maxLatencies := maxLatencySamples / n
+	c := &Collector{
+		shards:       newShards(n),
+		maxLatencies: maxLatencies,
+		sampleRate:   1,
+		percentiles:  DefaultPercentiles,
 	}
+	c.current.Store(newWindow(time.Now(), n))
+	return c
 }
 
-func (c *Collector) RecordSuccess(latency time.Duration) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// RecordSuccess는 성공한 쿼리의 지연시간과 읽은 행 수를 label(쿼리 타입 등)별로 기록합니다.
+// 100행을 반환하는 쿼리와 1행을 반환하는 쿼리를 구분하기 위해 행 수도 함께 누적합니다.
+// 빈 label은 합계에만 반영됩니다. 지연시간을 샤드 하나에 바로 합치므로, 부하 생성기 워커는 대신 Recorder를 씁니다.
+func (c *Collector) RecordSuccess(label string, latency time.Duration, rows int) {
+	now := time.Now()
+	if c.countSuccess(-1, label, now, latency, rows) {
+		c.addSamples([]latencySample{{label: label, end: now, latency: latency}}, nil)
+	}
+}
 
-	c.totalRequests++
-	c.successRequests++
+// countSuccess는 now에 끝난 성공한 쿼리를 stripe번째 카운터 묶음(window.counters)에 잠금 없이 세고, 지연시간을 기록해야 하면 true를 반환합니다.
+// 마지막 Reset 이전에 시작된 작업은 세지 않고 false를 반환합니다
+// (실행 중 초기화 시 이전 구간에 걸친 작업이 새 구간에 섞이지 않도록).
+func (c *Collector) countSuccess(stripe int, label string, now time.Time, latency time.Duration, rows int) bool {
+	c.sinkSuccess(label, latency, rows)
+
+	w := c.current.Load()
+	if w.startedBefore(now, latency) {
+		return false
+	}
 
-	if len(c.latencies) < c.maxLatencies {
-		c.latencies = append(c.latencies, latency)
+	cs := w.counters(stripe)
+	cs.totalRequests.Add(1)
+	cs.successRequests.Add(1)
+	cs.rowsRead.Add(int64(rows))
+	if lc := cs.label(label); lc != nil {
+		lc.totalRequests.Add(1)
+		lc.successRequests.Add(1)
+		lc.rowsRead.Add(int64(rows))
 	}
+	return true
 }
 
-func (c *Collector) RecordFailure() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// RecordFailure는 원인을 모르는 실패한 쿼리를 기록합니다 (failures_by_code의 "unknown").
+// elapsed는 쿼리를 시작한 뒤 실패할 때까지 걸린 시간입니다 (마지막 Reset 이전에 시작한 쿼리를 가려내는 데 씀).
+func (c *Collector) RecordFailure(label string, elapsed time.Duration) {
+	c.RecordFailureWithError(label, elapsed, nil)
+}
+
+// RecordTimeout은 타임아웃(57014 query_canceled 또는 context deadline)으로 취소된 쿼리를 기록합니다.
+// "DB가 느리지만 동작 중"과 "쿼리가 강제로 취소되는 중"을 구분하기 위해 일반 실패와 따로 셉니다.
+func (c *Collector) RecordTimeout(label string, elapsed time.Duration) {
+	c.sinkFailure(label, elapsed)
 
-	c.totalRequests++
-	c.failedRequests++
+	w := c.current.Load()
+	if w.startedBefore(time.Now(), elapsed) {
+		return
+	}
+
+	cs := w.counters(-1)
+	cs.totalRequests.Add(1)
+	cs.timeoutRequests.Add(1)
+	if lc := cs.label(label); lc != nil {
+		lc.totalRequests.Add(1)
+		lc.timeoutRequests.Add(1)
+	}
+}
+
+// RecordConnError는 연결이 끊겨(백엔드 종료, 네트워크 에러 등) 실패한 쿼리를 기록합니다.
+// 쿼리 자체의 실패가 아니라 연결을 다시 맺으면 회복되는 일시적 실패이므로 일반 실패와 따로 셉니다.
+func (c *Collector) RecordConnError(label string, elapsed time.Duration) {
+	c.sinkFailure(label, elapsed)
+
+	w := c.current.Load()
+	if w.startedBefore(time.Now(), elapsed) {
+		return
+	}
+
+	cs := w.counters(-1)
+	cs.totalRequests.Add(1)
+	cs.connErrors.Add(1)
+	if lc := cs.label(label); lc != nil {
+		lc.totalRequests.Add(1)
+		lc.connErrors.Add(1)
+	}
+}
+
+// RecordAborted는 강제 중지로 실행 컨텍스트가 취소되어 중단된 쿼리를 기록합니다.
+// 쿼리나 DB의 문제가 아니므로 실패, 타임아웃과 따로 세며 요청 수와 처리율에도 넣지 않습니다.
+func (c *Collector) RecordAborted(elapsed time.Duration) {
+	w := c.current.Load()
+	if w.startedBefore(time.Now(), elapsed) {
+		return
+	}
+
+	w.counters(-1).abortedRequests.Add(1)
+}
+
+// startedBeforeReset는 now보다 elapsed 전에 시작한 작업이 마지막 Reset 이전에 시작했는지 확인합니다.
+// 모든 Record*는 이런 작업을 버리므로, 실행 중 초기화 시 이전 구간에 걸친 작업이 이전 구간과 새 구간 어디에도 두 번 섞이지 않습니다.
+// 카운터를 더할 window와 같은 window로 판단해야 하는 기록 경로는 c.current.Load()의 startedBefore를 직접 씁니다.
+// 샤드에 샘플을 합칠 때처럼 Reset과 겹치지 않아야 하면 c.mu나 샤드 잠금 중 하나를 잡은 상태에서 호출해야 합니다.
+func (c *Collector) startedBeforeReset(now time.Time, elapsed time.Duration) bool {
+	return c.current.Load().startedBefore(now, elapsed)
+}
+
+// AddInFlight는 처리 중인 조회 API 요청 수를 delta만큼 변경합니다.
+func (c *Collector) AddInFlight(delta int64) {
+	c.inFlight.Add(delta)
+}
+
+// RecordRejected는 동시 실행 한도 초과로 거부된 요청을 기록합니다.
+func (c *Collector) RecordRejected() {
+	c.rejected.Add(1)
 }
 
 func (c *Collector) GetMetrics() Metrics {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	elapsed := time.Since(c.startTime).Seconds()
+	t := c.merged()
+	w := c.current.Load()
+	n := w.totals()
+	elapsed := time.Since(w.start).Seconds()
 	qps := 0.0
+	rowsPerSecond := 0.0
 	if elapsed > 0 {
-		qps = float64(c.totalRequests) / elapsed
+		qps = float64(n.totalRequests) / elapsed
+		rowsPerSecond = float64(n.rowsRead) / elapsed
 	}
 
-	avgLatency := 0.0
-	p50Latency := 0.0
-	p95Latency := 0.0
-	p99Latency := 0.0
-
-	if len(c.latencies) > 0 {
-		var sum time.Duration
-		for _, lat := range c.latencies {
-			sum += lat
-		}
-		avgLatency = float64(sum.Milliseconds()) / float64(len(c.latencies))
-
-		sortedLatencies := make([]time.Duration, len(c.latencies))
-		copy(sortedLatencies, c.latencies)
-		sort.Slice(sortedLatencies, func(i, j int) bool {
-			return sortedLatencies[i] < sortedLatencies[j]
-		})
-
-		p50Latency = float64(sortedLatencies[len(sortedLatencies)*50/100].Milliseconds())
-		p95Latency = float64(sortedLatencies[len(sortedLatencies)*95/100].Milliseconds())
-		p99Latency = float64(sortedLatencies[len(sortedLatencies)*99/100].Milliseconds())
+	timeoutRate := 0.0
+	if n.totalRequests > 0 {
+		timeoutRate = float64(n.timeoutRequests) / float64(n.totalRequests)
 	}
 
+	avgLatency, p50Latency, p95Latency, p99Latency := t.latencies.summarize()
+	minLatency, maxLatency := t.latencies.extremes()
+	targetRate, achievedRatio := c.rateMetrics(qps)
+	reconnects, downtime := c.outageMetrics(time.Now())
+	limiterWait, limiterRatio := limiterMetrics(n.limiterWait, n.limiterTotal)
+
+	byLabel := labelMetrics(n.labels, t)
 	return Metrics{
-		TotalRequests:   c.totalRequests,
-		SuccessRequests: c.successRequests,
-		FailedRequests:  c.failedRequests,
-		QPS:             qps,
-		AvgLatency:      avgLatency,
-		P50Latency:      p50Latency,
-		P95Latency:      p95Latency,
-		P99Latency:      p99Latency,
-		StartTime:       c.startTime,
-		Elapsed:         elapsed,
+		TotalRequests:      n.totalRequests,
+		SuccessRequests:    n.successRequests,
+		FailedRequests:     n.failedRequests,
+		TimeoutRequests:    n.timeoutRequests,
+		TimeoutRate:        timeoutRate,
+		ConnectionErrors:   n.connErrors,
+		AbortedRequests:    n.abortedRequests,
+		Reconnects:         reconnects,
+		DowntimeSeconds:    downtime,
+		QPS:                qps,
+		RowsRead:           n.rowsRead,
+		RowsPerSecond:      rowsPerSecond,
+		AvgLatency:         avgLatency,
+		P50Latency:         p50Latency,
+		P95Latency:         p95Latency,
+		P99Latency:         p99Latency,
+		MinLatency:         minLatency,
+		MaxLatency:         maxLatency,
+		Percentiles:        t.latencies.percentiles(c.percentiles),
+		StartTime:          w.start,
+		Elapsed:            elapsed,
+		TargetRate:         targetRate,
+		AchievedRate:       qps,
+		AchievedRatio:      achievedRatio,
+		LimiterWaitSeconds: limiterWait,
+		LimiterWaitRatio:   limiterRatio,
+		LatencyBuckets:     scaleBuckets(t.latencies.latencyBuckets(), c.sampleScale()),
+		LatencySampleRate:  c.sampleRate,
+		LatencySamples:     int(t.latencies.count),
+		Warmup:             c.warmupStats(&t.warmupLatencies),
+		FetchLatency:       fetchLatency(t.firstRowLatencies, t.lastRowLatencies),
+		ByLabel:            byLabel,
+		ByQueryType:        c.queryTypeMetrics(byLabel),
+		FailuresByCode:     w.failureReasonCounts(),
+		Pool:               c.poolMetrics(elapsed),
+		InFlightRequests:   c.inFlight.Load(),
+		RejectedRequests:   c.rejected.Load(),
 	}
 }
 
+// Reset은 모든 메트릭을 초기화합니다. 부하 생성 중에도 호출할 수 있으며,
+// 이후 메트릭은 초기화 시점 이후에 시작된 작업만 반영합니다.
 func (c *Collector) Reset() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.lockShards()
+	defer c.unlockShards()
 
-	c.totalRequests = 0
-	c.successRequests = 0
-	c.failedRequests = 0
-	c.latencies = make([]time.Duration, 0, 100000)
-	c.startTime = time.Now()
+	for _, s := range c.shards {
+		s.reset()
+	}
+	c.rejected.Store(0)
+	c.resetPoolBase()
+	now := time.Now()
+	c.current.Store(newWindow(now, len(c.shards)))
+	c.resetOutage(now)
 }
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Reset 이전에 시작한 요청은 성공이든 실패든 기록되지 않고, 이후에 시작한 요청만 기록되는지 확인합니다.
+func TestResetDropsRequestsStartedBefore(t *testing.T) {
+	c := NewCollector()
+	c.Reset()
+	before := time.Since(c.current.Load().start) + time.Hour // 확실히 Reset 이전에 시작한 요청
+
+	c.RecordSuccess("simple", before, 1)
+	c.RecordFailure("simple", before)
+	c.RecordTimeout("simple", before)
+	c.RecordConnError("simple", before)
+	c.RecordFailureWithError("simple", before, errors.New("boom"))
+	c.RecordAborted(before)
+
+	m := c.GetMetrics()
+	if m.TotalRequests != 0 || m.FailedRequests != 0 || m.TimeoutRequests != 0 || m.ConnectionErrors != 0 || m.AbortedRequests != 0 {
+		t.Fatalf("requests started before Reset were recorded: %+v", m)
+	}
+	if len(m.FailuresByCode) != 0 {
+		t.Fatalf("failures_by_code = %v, want empty", m.FailuresByCode)
+	}
+
+	c.RecordSuccess("simple", 0, 1)
+	c.RecordTimeout("simple", 0)
+	c.RecordConnError("simple", 0)
+	c.RecordFailureWithError("simple", 0, errors.New("boom"))
+	c.RecordAborted(0)
+
+	m = c.GetMetrics()
+	if m.TotalRequests != 4 || m.SuccessRequests != 1 || m.FailedRequests != 1 || m.TimeoutRequests != 1 || m.ConnectionErrors != 1 || m.AbortedRequests != 1 {
+		t.Fatalf("requests started after Reset: %+v", m)
+	}
+}
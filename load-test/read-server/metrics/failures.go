@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// FailureUnknown은 원인을 알 수 없는 실패(PostgreSQL 에러가 아니거나 RecordFailure로 기록)의 failures_by_code 키입니다.
+const FailureUnknown = "unknown"
+
+// failureReason은 실패 원인을 failures_by_code 키로 분류합니다.
+// PostgreSQL 에러는 SQLSTATE의 조건 이름(예: 40001 → serialization_failure, 40P01 → deadlock_detected,
+// 53300 → too_many_connections)이며, lib/pq가 모르는 코드는 코드 그대로 씁니다.
+func failureReason(err error) string {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return FailureUnknown
+	}
+	if name := pqErr.Code.Name(); name != "" {
+		return name
+	}
+	return string(pqErr.Code)
+}
+
+// RecordFailureWithError는 실패한 쿼리를 기록하면서 err의 원인별 건수(failures_by_code)도 셉니다.
+// 타임아웃과 연결 끊김은 RecordTimeout, RecordConnError로 따로 기록하므로 여기에는 그 밖의 실패만 전달합니다.
+// elapsed는 쿼리를 시작한 뒤 실패할 때까지 걸린 시간입니다.
+func (c *Collector) RecordFailureWithError(label string, elapsed time.Duration, err error) {
+	c.sinkFailure(label, elapsed)
+
+	w := c.current.Load()
+	if w.startedBefore(time.Now(), elapsed) {
+		return
+	}
+
+	cs := w.counters(-1)
+	cs.totalRequests.Add(1)
+	cs.failedRequests.Add(1)
+	w.addFailureReason(failureReason(err))
+	if lc := cs.label(label); lc != nil {
+		lc.totalRequests.Add(1)
+		lc.failedRequests.Add(1)
+	}
+}
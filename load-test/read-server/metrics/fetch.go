@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+)
+
+// FetchLatency는 부하 생성기 쿼리의 지연시간을 첫 행까지와 마지막 행까지로 나눈 분포입니다 (FetchLatency 설정 시).
+// 첫 행까지는 쿼리 계획과 실행 시작 비용, 첫 행부터 마지막 행까지는 결과 전송(스트리밍)과 스캔 비용을 보여줍니다.
+// 두 값 모두 쿼리를 보낸 시점부터 재며 커밋은 포함하지 않습니다. 1ms 미만도 흔하므로 ms 단위 소수로 보고합니다.
+type FetchLatency struct {
+	Samples  int              `json:"samples"`   // 기록된 쿼리 샘플 수 (LatencySampleRate, 워밍업 제외 적용)
+	FirstRow FetchPercentiles `json:"first_row"` // QueryContext 호출부터 첫 rows.Next() 반환까지
+	LastRow  FetchPercentiles `json:"last_row"`  // QueryContext 호출부터 결과를 모두 읽을 때까지
+	// StreamShare는 (평균 마지막 행 - 평균 첫 행) / 평균 마지막 행입니다.
+	// 1에 가까우면 결과 전송이, 0에 가까우면 쿼리 실행 시작까지가 대부분입니다.
+	StreamShare float64 `json:"stream_share"`
+}
+
+// FetchPercentiles는 FetchLatency의 한 분포입니다.
+type FetchPercentiles struct {
+	AvgLatency float64 `json:"avg_latency_ms"`
+	P50Latency float64 `json:"p50_latency_ms"`
+	P95Latency float64 `json:"p95_latency_ms"`
+	P99Latency float64 `json:"p99_latency_ms"`
+}
+
+// RecordFetch는 성공한 쿼리의 첫 행까지, 마지막 행까지 시간을 기록합니다.
+// latency는 RecordSuccess에 넘긴 전체 지연시간으로, 마지막 Reset 이전이나 워밍업 구간에 시작한 쿼리를 가려내는 데 씁니다.
+// 샤드 하나에 바로 합치므로, 부하 생성기 워커는 대신 Recorder를 씁니다.
+func (c *Collector) RecordFetch(latency, firstRow, lastRow time.Duration) {
+	c.addSamples(nil, []fetchSample{{end: time.Now(), latency: latency, firstRow: firstRow, lastRow: lastRow}})
+}
+
+// fetchLatency는 첫 행/마지막 행 분포를 계산합니다. 기록된 쿼리가 없으면 nil입니다.
+func fetchLatency(firstRow, lastRow []time.Duration) *FetchLatency {
+	if len(firstRow) == 0 {
+		return nil
+	}
+
+	result := &FetchLatency{
+		Samples:  len(firstRow),
+		FirstRow: fetchPercentiles(firstRow),
+		LastRow:  fetchPercentiles(lastRow),
+	}
+	if result.LastRow.AvgLatency > 0 {
+		result.StreamShare = (result.LastRow.AvgLatency - result.FirstRow.AvgLatency) / result.LastRow.AvgLatency
+	}
+	return result
+}
+
+// fetchPercentiles는 샘플을 정렬해 백분위수를 계산하며, /metrics의 지연시간과 달리 ms 단위를 정수로 자르지 않습니다.
+func fetchPercentiles(latencies []time.Duration) FetchPercentiles {
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	var sum time.Duration
+	for _, lat := range sorted {
+		sum += lat
+	}
+
+	return FetchPercentiles{
+		AvgLatency: durationMs(sum) / float64(len(sorted)),
+		P50Latency: durationMs(sorted[percentileIndex(len(sorted), 50)]),
+		P95Latency: durationMs(sorted[percentileIndex(len(sorted), 95)]),
+		P99Latency: durationMs(sorted[percentileIndex(len(sorted), 99)]),
+	}
+}
+
+// durationMs는 d를 소수 ms로 변환합니다.
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import "time"
+
+// 히트맵 시간 버킷 설정. LatencyBucketEdges처럼 필요하면 서버 시작 시 변경할 수 있습니다.
+var (
+	HeatmapInterval   = time.Second // 시간 버킷 하나의 길이
+	MaxHeatmapBuckets = 600         // 유지할 최대 시간 버킷 수 (넘으면 오래된 버킷부터 버림, 0이면 기록 안 함)
+)
+
+// Heatmap은 시간 버킷 × 지연시간 버킷의 성공 요청 수 행렬입니다.
+// 누적 백분위수에 묻히는 일시적인 지연 급증을 시간 축으로 펼쳐 보기 위한 데이터입니다.
+type Heatmap struct {
+	IntervalSeconds float64             `json:"interval_seconds"`
+	LatencyBuckets  []string            `json:"latency_buckets"` // 열 이름 (LatencyBucketEdges 기준, 예: "1-5", "500+")
+	TimeBuckets     []HeatmapTimeBucket `json:"time_buckets"`    // 행 (오래된 순, 요청이 없던 구간도 0으로 포함)
+	DroppedBuckets  int64               `json:"dropped_buckets"` // MaxHeatmapBuckets를 넘어 버린 시간 버킷 수
+}
+
+// HeatmapTimeBucket은 히트맵의 한 행입니다. Counts[i]는 LatencyBuckets[i]에 속한 요청 수입니다.
+type HeatmapTimeBucket struct {
+	Start  time.Time `json:"start"`
+	Counts []int64   `json:"counts"`
+}
+
+// heatmap은 완료 시각 기준으로 지연시간을 시간 버킷별로 집계합니다. 속한 샤드의 mu로 보호됩니다.
+// rows[i]는 (first+i)번째 HeatmapInterval 구간이며, 길이는 MaxHeatmapBuckets를 넘지 않습니다.
+type heatmap struct {
+	first   int64
+	rows    [][]int64
+	dropped int64
+}
+
+// record는 now에 끝난 요청의 latency를 해당 시간 버킷에 더합니다.
+func (h *heatmap) record(now time.Time, latency time.Duration) {
+	if MaxHeatmapBuckets <= 0 {
+		return
+	}
+	idx := now.UnixNano() / int64(HeatmapInterval)
+
+	if len(h.rows) == 0 {
+		h.first = idx
+	}
+	if idx < h.first {
+		return // 이미 버린 구간
+	}
+
+	// 새 구간까지 빈 행을 채우되, MaxHeatmapBuckets를 넘는 오래된 행은 버림
+	if n := idx - h.first + 1; n > int64(len(h.rows)) {
+		if overflow := n - int64(MaxHeatmapBuckets); overflow > 0 {
+			h.dropped += overflow
+			if overflow >= int64(len(h.rows)) {
+				h.rows = h.rows[:0]
+			} else {
+				h.rows = append(h.rows[:0], h.rows[overflow:]...)
+			}
+			h.first += overflow
+		}
+		for int64(len(h.rows)) < idx-h.first+1 {
+			h.rows = append(h.rows, make([]int64, len(LatencyBucketEdges)+1))
+		}
+	}
+
+	h.rows[idx-h.first][bucketIndex(latency)]++
+}
+
+// mergeHeatmaps는 샤드별 히트맵을 시간 버킷 기준으로 합칩니다.
+// 합친 범위가 MaxHeatmapBuckets를 넘으면 오래된 버킷을 버리고, 버린 수는 가장 먼저 기록을 시작한 샤드 기준으로 셉니다.
+func mergeHeatmaps(hs []*heatmap) heatmap {
+	var out heatmap
+	var origin, first, last int64
+	found := false
+	for _, h := range hs {
+		if len(h.rows) == 0 {
+			continue
+		}
+		hLast := h.first + int64(len(h.rows)) - 1
+		if !found || h.first-h.dropped < origin {
+			origin = h.first - h.dropped
+		}
+		if !found || h.first < first {
+			first = h.first
+		}
+		if !found || hLast > last {
+			last = hLast
+		}
+		found = true
+	}
+	if !found {
+		return out
+	}
+
+	if n := last - first + 1; n > int64(MaxHeatmapBuckets) {
+		first = last - int64(MaxHeatmapBuckets) + 1
+	}
+	out.first = first
+	out.dropped = first - origin
+	out.rows = make([][]int64, last-first+1)
+	for i := range out.rows {
+		out.rows[i] = make([]int64, len(LatencyBucketEdges)+1)
+	}
+	for _, h := range hs {
+		for i, row := range h.rows {
+			idx := h.first + int64(i) - first
+			if idx < 0 {
+				continue
+			}
+			for j, n := range row {
+				out.rows[idx][j] += n
+			}
+		}
+	}
+	return out
+}
+
+// snapshot은 현재까지 집계한 히트맵을 복사해 반환합니다. 건수에는 scale을 곱합니다 (지연시간 샘플링 보정).
+func (h *heatmap) snapshot(scale float64) Heatmap {
+	buckets := newLatencyBuckets()
+	ranges := make([]string, len(buckets))
+	for i, b := range buckets {
+		ranges[i] = b.Range
+	}
+
+	timeBuckets := make([]HeatmapTimeBucket, len(h.rows))
+	for i, row := range h.rows {
+		counts := make([]int64, len(row))
+		for j, n := range row {
+			counts[j] = int64(float64(n)*scale + 0.5)
+		}
+		timeBuckets[i] = HeatmapTimeBucket{
+			Start:  time.Unix(0, (h.first+int64(i))*int64(HeatmapInterval)),
+			Counts: counts,
+		}
+	}
+
+	return Heatmap{
+		IntervalSeconds: HeatmapInterval.Seconds(),
+		LatencyBuckets:  ranges,
+		TimeBuckets:     timeBuckets,
+		DroppedBuckets:  h.dropped,
+	}
+}
+
+// GetHeatmap은 마지막 Reset 이후의 지연시간 히트맵을 반환합니다.
+func (c *Collector) GetHeatmap() Heatmap {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	c.lockShards()
+	heatmaps := make([]*heatmap, len(c.shards))
+	for i, s := range c.shards {
+		heatmaps[i] = &s.heatmap
+	}
+	merged := mergeHeatmaps(heatmaps)
+	c.unlockShards()
+
+	return merged.snapshot(c.sampleScale())
+}
@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"math/bits"
+	"time"
+)
+
+// 지연시간 히스토그램 버킷 구성 (HDR 히스토그램과 같은 로그-선형 방식)
+// µs 단위 값이 histogramSubBuckets 미만이면 1µs 폭 버킷에 그대로 넣고, 그 이상은 2의 거듭제곱 구간마다
+// histogramSubBuckets개의 같은 폭 버킷으로 나눕니다. 버킷 폭이 값의 1/64 이하이므로 백분위수의 상대 오차는 1.6% 이내입니다.
+// 1µs 미만은 첫 버킷, histogramMaxValue(60초)를 넘는 값은 마지막 버킷에 넣습니다.
+const (
+	histogramSubBucketBits = 6
+	histogramSubBuckets    = 1 << histogramSubBucketBits
+	histogramMaxValue      = int64(60 * time.Second / time.Microsecond)
+)
+
+// histogramBucketCount는 히스토그램 하나의 버킷 수입니다 (1µs ~ 60초에 약 1300개).
+var histogramBucketCount = histogramIndex(histogramMaxValue) + 1
+
+// histogramIndex는 µs 단위 값 us가 속하는 버킷 인덱스를 반환합니다.
+func histogramIndex(us int64) int {
+	if us < histogramSubBuckets {
+		if us < 0 {
+			return 0
+		}
+		return int(us)
+	}
+	if us > histogramMaxValue {
+		us = histogramMaxValue
+	}
+	// us >> shift가 [histogramSubBuckets, 2*histogramSubBuckets) 범위가 되도록 자름
+	shift := bits.Len64(uint64(us)) - histogramSubBucketBits - 1
+	return shift*histogramSubBuckets + int(us>>shift)
+}
+
+// histogramBucketRange는 버킷 i의 하한과 폭(µs)을 반환합니다.
+func histogramBucketRange(i int) (lower, width int64) {
+	if i < 2*histogramSubBuckets {
+		return int64(i), 1
+	}
+	shift := i/histogramSubBuckets - 1
+	sub := i%histogramSubBuckets + histogramSubBuckets
+	return int64(sub) << shift, int64(1) << shift
+}
+
+// latencyHistogram은 지연시간 분포를 고정 버킷으로 누적합니다.
+// 샘플을 보관하지 않으므로 기록 수에 상한이 없고, 백분위수 계산은 버킷 수에 비례합니다 (정렬 없음).
+// 평균, 최솟값, 최댓값은 버킷과 별도로 정확한 값을 유지합니다.
+type latencyHistogram struct {
+	counts []int64 // 길이 histogramBucketCount, 처음 기록할 때 할당
+	count  int64
+	sum    time.Duration
+	min    time.Duration
+	max    time.Duration
+}
+
+func (h *latencyHistogram) record(latency time.Duration) {
+	if h.counts == nil {
+		h.counts = make([]int64, histogramBucketCount)
+	}
+	h.counts[histogramIndex(int64(latency/time.Microsecond))]++
+	if h.count == 0 || latency < h.min {
+		h.min = latency
+	}
+	if latency > h.max {
+		h.max = latency
+	}
+	h.count++
+	h.sum += latency
+}
+
+// merge는 다른 히스토그램(다른 샤드의 같은 분포)을 더합니다.
+func (h *latencyHistogram) merge(o *latencyHistogram) {
+	if o.count == 0 {
+		return
+	}
+	if h.counts == nil {
+		h.counts = make([]int64, histogramBucketCount)
+	}
+	for i, n := range o.counts {
+		h.counts[i] += n
+	}
+	if h.count == 0 || o.min < h.min {
+		h.min = o.min
+	}
+	if o.max > h.max {
+		h.max = o.max
+	}
+	h.count += o.count
+	h.sum += o.sum
+}
+
+// bucketValue는 버킷 i의 대표값(버킷 가운데)입니다. 실제로 기록된 최솟값과 최댓값을 벗어나지 않도록 자릅니다.
+func (h *latencyHistogram) bucketValue(i int) time.Duration {
+	lower, width := histogramBucketRange(i)
+	v := time.Duration(lower)*time.Microsecond + time.Duration(width)*time.Microsecond/2
+	if v < h.min {
+		return h.min
+	}
+	if v > h.max {
+		return h.max
+	}
+	return v
+}
+
+// percentile은 p번째 백분위수입니다 (99.9처럼 소수도 가능). 정렬한 샘플의 count*p/100번째(0부터) 값이 속한 버킷의 대표값으로,
+// 샘플을 정렬하던 이전 계산과 같은 순위를 쓰며 오차는 버킷 폭 이내입니다.
+func (h *latencyHistogram) percentile(p float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	rank := int64(float64(h.count) * p / 100)
+	var seen int64
+	for i, n := range h.counts {
+		seen += n
+		if seen > rank {
+			return h.bucketValue(i)
+		}
+	}
+	return h.max
+}
+
+// percentileIndex는 정렬한 샘플 n개에서 p번째 백분위수의 인덱스(n*p/100)입니다.
+// p가 100 이상이거나 범위를 벗어나도 [0, n-1]로 잘라 인덱스가 슬라이스 밖을 가리키지 않습니다 (n이 0이면 0).
+func percentileIndex(n, p int) int {
+	i := n * p / 100
+	if i >= n {
+		i = n - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
+
+// summarize는 평균과 p50/p95/p99(ms)를 계산합니다. 다른 지연시간 필드처럼 ms 미만은 버립니다.
+func (h *latencyHistogram) summarize() (avg, p50, p95, p99 float64) {
+	if h.count == 0 {
+		return 0, 0, 0, 0
+	}
+	avg = float64(h.sum.Milliseconds()) / float64(h.count)
+	p50 = float64(h.percentile(50).Milliseconds())
+	p95 = float64(h.percentile(95).Milliseconds())
+	p99 = float64(h.percentile(99).Milliseconds())
+	return avg, p50, p95, p99
+}
+
+// extremes는 기록된 최솟값과 최댓값(ms)입니다. 버킷과 별도로 정확한 값이므로 ms 미만도 그대로 둡니다.
+func (h *latencyHistogram) extremes() (min, max float64) {
+	if h.count == 0 {
+		return 0, 0
+	}
+	return float64(h.min) / float64(time.Millisecond), float64(h.max) / float64(time.Millisecond)
+}
+
+// latencyBuckets는 분포를 LatencyBucketEdges 기준으로 다시 집계합니다.
+// 히스토그램 버킷은 대표값으로 분류하므로, 경계 근처의 요청은 버킷 폭(값의 1.6%) 안에서 옆 버킷으로 갈 수 있습니다.
+func (h *latencyHistogram) latencyBuckets() []LatencyBucket {
+	buckets := newLatencyBuckets()
+	for i, n := range h.counts {
+		if n > 0 {
+			buckets[bucketIndex(h.bucketValue(i))].Count += n
+		}
+	}
+	return buckets
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+// 알려진 분포의 p50/p95/p99가 정확한 값에서 버킷 폭 하나 이내인지 확인합니다.
+// 정확한 값은 샘플을 정렬했을 때 count*p/100번째(0부터) 값입니다 (percentile과 같은 순위).
+func TestHistogramPercentilesWithinBucketWidth(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample func(i int) time.Duration // i번째(0부터) 샘플, i에 대해 증가해야 함 (정렬된 샘플)
+		n      int
+	}{
+		{"uniform 7us..140ms", func(i int) time.Duration { return time.Duration(i+1) * 7 * time.Microsecond }, 20000},
+		{"sub-bucket range 0..63us", func(i int) time.Duration { return time.Duration(i) * time.Microsecond }, 64},
+		{"quadratic 1us..10s", func(i int) time.Duration { return time.Duration(1+i*i/10) * time.Microsecond }, 10000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h latencyHistogram
+			for i := 0; i < tt.n; i++ {
+				h.record(tt.sample(i))
+			}
+			for _, p := range []float64{50, 95, 99} {
+				exact := tt.sample(int(float64(tt.n) * p / 100))
+				_, width := histogramBucketRange(histogramIndex(int64(exact / time.Microsecond)))
+				got := h.percentile(p)
+				if diff := got - exact; diff < -time.Duration(width)*time.Microsecond || diff > time.Duration(width)*time.Microsecond {
+					t.Errorf("p%v = %v, want %v ± %dµs", p, got, exact, width)
+				}
+			}
+		})
+	}
+}
+
+// min/max_latency_ms는 기록이 없으면 0이고, 기록한 뒤에는 정확한 최솟값과 최댓값(ms 미만 포함)인지 확인합니다.
+func TestMinMaxLatency(t *testing.T) {
+	c := NewCollector()
+	m := c.GetMetrics()
+	if m.MinLatency != 0 || m.MaxLatency != 0 {
+		t.Fatalf("min/max = %v/%v with nothing recorded, want 0/0", m.MinLatency, m.MaxLatency)
+	}
+
+	time.Sleep(50 * time.Millisecond) // 기록할 요청이 Collector를 만든 뒤에 시작하도록 (가장 긴 지연시간보다 길게)
+	for _, latency := range []time.Duration{1500 * time.Microsecond, 250 * time.Microsecond, 40 * time.Millisecond, 3 * time.Millisecond} {
+		c.RecordSuccess("simple", latency, 1)
+	}
+
+	m = c.GetMetrics()
+	if m.MinLatency != 0.25 || m.MaxLatency != 40 {
+		t.Fatalf("min/max = %v/%v, want 0.25/40", m.MinLatency, m.MaxLatency)
+	}
+
+	c.Reset()
+	m = c.GetMetrics()
+	if m.MinLatency != 0 || m.MaxLatency != 0 {
+		t.Fatalf("min/max = %v/%v after Reset, want 0/0", m.MinLatency, m.MaxLatency)
+	}
+}
+
+// percentileIndex가 샘플 수와 백분위수의 경계 조합에서 항상 [0, n-1] 안의 인덱스를 반환하는지 확인합니다.
+func TestPercentileIndexInRange(t *testing.T) {
+	tests := []struct {
+		n, p int
+		want int
+	}{
+		{1, 0, 0}, {1, 50, 0}, {1, 100, 0},
+		{2, 0, 0}, {2, 50, 1}, {2, 100, 1},
+		{99, 0, 0}, {99, 50, 49}, {99, 100, 98},
+		{100, 0, 0}, {100, 50, 50}, {100, 100, 99},
+		{101, 0, 0}, {101, 50, 50}, {101, 100, 100},
+	}
+	for _, tt := range tests {
+		got := percentileIndex(tt.n, tt.p)
+		if got != tt.want {
+			t.Errorf("percentileIndex(%d, %d) = %d, want %d", tt.n, tt.p, got, tt.want)
+		}
+		// 실제 슬라이스를 인덱싱해도 패닉이 없어야 함
+		sorted := make([]time.Duration, tt.n)
+		_ = sorted[got]
+	}
+
+	if got := percentileIndex(0, 50); got != 0 {
+		t.Errorf("percentileIndex(0, 50) = %d, want 0", got)
+	}
+}
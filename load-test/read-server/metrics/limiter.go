@@ -0,0 +1,26 @@
+package metrics
+
+import "time"
+
+// RecordLimiterWait는 처리율 제한(qps/tps)이 있는 워커가 다음 틱을 기다린 시간을 기록합니다.
+// busy는 직전 틱을 받은 뒤 이번 대기를 시작하기까지, 즉 워커가 쿼리를 실행하는 등 일한 시간입니다.
+// 워커가 제한에 막혀 노는지, DB를 기다리느라 바쁜지를 limiter_wait_ratio로 구분할 수 있습니다.
+// 마지막 Reset 이전에 시작한 구간(busy + wait)은 버립니다.
+func (c *Collector) RecordLimiterWait(wait, busy time.Duration) {
+	w := c.current.Load()
+	if w.startedBefore(time.Now(), wait+busy) {
+		return
+	}
+
+	cs := w.counters(-1)
+	cs.limiterWait.Add(int64(wait))
+	cs.limiterTotal.Add(int64(wait + busy))
+}
+
+// limiterMetrics는 누적 대기 시간(초)과 워커 시간 중 대기 비율을 계산합니다 (기록이 없으면 0).
+func limiterMetrics(wait, total time.Duration) (seconds, ratio float64) {
+	if total <= 0 {
+		return 0, 0
+	}
+	return wait.Seconds(), float64(wait) / float64(total)
+}
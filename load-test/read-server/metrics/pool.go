@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"database/sql"
+	"time"
+)
+
+// PoolMetrics는 DB 연결 풀 상태입니다. 누적 카운터는 마지막 Reset(= 실행 시작) 이후의 증가분입니다.
+// ConnMaxLifetime/MaxIdleConns 설정 때문에 연결이 자주 닫히고 다시 열리면(churn) 지연시간이 튈 수 있으므로,
+// 지연시간 급증과 MaxLifetimeClosed 증가를 함께 보면 풀 설정을 조정하는 데 도움이 됩니다.
+type PoolMetrics struct {
+	OpenConnections int `json:"open_connections"` // 현재 열린 연결 수
+	InUse           int `json:"in_use"`
+	Idle            int `json:"idle"`
+
+	WaitCount         int64   `json:"wait_count"`           // 연결을 기다린 횟수
+	WaitDurationMs    float64 `json:"wait_duration_ms"`     // 연결을 기다린 총 시간
+	MaxIdleClosed     int64   `json:"max_idle_closed"`      // MaxIdleConns 초과로 닫힌 연결 수
+	MaxIdleTimeClosed int64   `json:"max_idle_time_closed"` // ConnMaxIdleTime 초과로 닫힌 연결 수
+	MaxLifetimeClosed int64   `json:"max_lifetime_closed"`  // ConnMaxLifetime 초과로 닫힌 연결 수
+
+	LifetimeClosedPerMinute float64 `json:"lifetime_closed_per_minute"` // MaxLifetimeClosed / 경과 시간(분)
+}
+
+// SetDB는 연결 풀 메트릭을 수집할 DB를 지정합니다. 지정하지 않으면 Metrics.Pool은 nil입니다.
+func (c *Collector) SetDB(db *sql.DB) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.db = db
+	c.poolBase = db.Stats()
+}
+
+// resetPoolBase는 풀 누적 카운터의 기준점을 현재 값으로 옮깁니다. c.mu를 잡은 상태에서 호출해야 합니다.
+func (c *Collector) resetPoolBase() {
+	if c.db != nil {
+		c.poolBase = c.db.Stats()
+	}
+}
+
+// poolMetrics는 현재 풀 상태와 기준점 이후 증가분을 계산합니다. c.mu를 잡은 상태에서 호출해야 합니다.
+func (c *Collector) poolMetrics(elapsed float64) *PoolMetrics {
+	if c.db == nil {
+		return nil
+	}
+
+	stats := c.db.Stats()
+	base := c.poolBase
+	pool := &PoolMetrics{
+		OpenConnections:   stats.OpenConnections,
+		InUse:             stats.InUse,
+		Idle:              stats.Idle,
+		WaitCount:         stats.WaitCount - base.WaitCount,
+		WaitDurationMs:    float64(stats.WaitDuration-base.WaitDuration) / float64(time.Millisecond),
+		MaxIdleClosed:     stats.MaxIdleClosed - base.MaxIdleClosed,
+		MaxIdleTimeClosed: stats.MaxIdleTimeClosed - base.MaxIdleTimeClosed,
+		MaxLifetimeClosed: stats.MaxLifetimeClosed - base.MaxLifetimeClosed,
+	}
+	if elapsed > 0 {
+		pool.LifetimeClosedPerMinute = float64(pool.MaxLifetimeClosed) / (elapsed / 60)
+	}
+	return pool
+}
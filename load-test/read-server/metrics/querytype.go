@@ -0,0 +1,53 @@
+package metrics
+
+import "time"
+
+// LatencyStats는 쿼리 타입 하나의 성공 요청 수와 지연시간 통계입니다 (Metrics.ByQueryType).
+type LatencyStats struct {
+	Count      int64   `json:"count"` // 성공한 요청 수
+	AvgLatency float64 `json:"avg_latency_ms"`
+	P50Latency float64 `json:"p50_latency_ms"`
+	P95Latency float64 `json:"p95_latency_ms"`
+	P99Latency float64 `json:"p99_latency_ms"`
+}
+
+// RecordSuccessTyped는 성공한 쿼리의 지연시간을 쿼리 타입(simple/filter/aggregate 또는 사용자 지정 쿼리 이름)별로 기록합니다.
+// 쿼리 타입은 라벨로 기록하므로 by_label에도 함께 나오며, by_query_type은 그중 이 경로로 기록한 라벨만 모은 것입니다.
+func (c *Collector) RecordSuccessTyped(queryType string, latency time.Duration) {
+	c.markQueryType(queryType)
+	c.RecordSuccess(queryType, latency, 0)
+}
+
+// RecordSuccessTyped는 Collector.RecordSuccessTyped와 같지만 읽은 행 수도 함께 기록하고 지연시간을 버퍼에 모읍니다.
+func (r *Recorder) RecordSuccessTyped(queryType string, latency time.Duration, rows int) {
+	r.c.markQueryType(queryType)
+	r.RecordSuccess(queryType, latency, rows)
+}
+
+// markQueryType은 label을 쿼리 타입으로 표시합니다. 이미 표시한 라벨은 잠금 없이 확인합니다.
+func (c *Collector) markQueryType(label string) {
+	if _, ok := c.queryTypes.Load(label); !ok {
+		c.queryTypes.Store(label, struct{}{})
+	}
+}
+
+// queryTypeMetrics는 byLabel 중 쿼리 타입으로 기록한 라벨의 지연시간 통계입니다. 없으면 nil입니다.
+func (c *Collector) queryTypeMetrics(byLabel map[string]LabelMetrics) map[string]LatencyStats {
+	var byType map[string]LatencyStats
+	for label, lm := range byLabel {
+		if _, ok := c.queryTypes.Load(label); !ok {
+			continue
+		}
+		if byType == nil {
+			byType = make(map[string]LatencyStats)
+		}
+		byType[label] = LatencyStats{
+			Count:      lm.SuccessRequests,
+			AvgLatency: lm.AvgLatency,
+			P50Latency: lm.P50Latency,
+			P95Latency: lm.P95Latency,
+			P99Latency: lm.P99Latency,
+		}
+	}
+	return byType
+}
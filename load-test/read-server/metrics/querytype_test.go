@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+// RecordSuccessTyped로 기록한 타입마다 by_query_type에 독립된 백분위수가 나오고, 일반 라벨은 섞이지 않는지 확인합니다.
+func TestRecordSuccessTyped(t *testing.T) {
+	c := NewCollector()
+	time.Sleep(50 * time.Millisecond) // 기록할 요청이 Collector를 만든 뒤에 시작하도록 (가장 긴 지연시간보다 길게)
+	delays := map[string]time.Duration{"simple": time.Millisecond, "filter": 5 * time.Millisecond, "aggregate": 20 * time.Millisecond}
+	for i := 0; i < 100; i++ {
+		for queryType, delay := range delays {
+			c.RecordSuccessTyped(queryType, delay)
+		}
+		c.RecordSuccess("GET /logs", 40*time.Millisecond, 1)
+	}
+
+	m := c.GetMetrics()
+	if len(m.ByQueryType) != len(delays) {
+		t.Fatalf("by_query_type has %d types, want %d: %+v", len(m.ByQueryType), len(delays), m.ByQueryType)
+	}
+	for queryType, delay := range delays {
+		stats := m.ByQueryType[queryType]
+		if stats.Count != 100 {
+			t.Errorf("%s: count = %d, want 100", queryType, stats.Count)
+		}
+		want := float64(delay.Milliseconds())
+		if stats.P50Latency != want || stats.P95Latency != want || stats.P99Latency != want {
+			t.Errorf("%s: p50/p95/p99 = %v/%v/%v, want %v", queryType, stats.P50Latency, stats.P95Latency, stats.P99Latency, want)
+		}
+		lm := m.ByLabel[queryType]
+		if stats.P50Latency != lm.P50Latency || stats.P99Latency != lm.P99Latency {
+			t.Errorf("%s: by_query_type %+v differs from by_label %+v", queryType, stats, lm)
+		}
+	}
+	if _, ok := m.ByLabel["GET /logs"]; !ok {
+		t.Fatalf("by_label has no untyped label: %+v", m.ByLabel)
+	}
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import "time"
+
+// 워커별 기록기(Recorder)의 버퍼를 Collector에 합치는 조건
+const (
+	recorderFlushInterval = 100 * time.Millisecond // 마지막으로 합친 뒤 이만큼 지나면 합침
+	recorderBufferSize    = 1024                   // 그 전이라도 샘플이 이만큼 쌓이면 합침
+)
+
+// latencySample은 아직 샤드에 합치지 않은 성공 요청의 지연시간입니다. end는 요청이 끝난 시각입니다.
+type latencySample struct {
+	label   string
+	end     time.Time
+	latency time.Duration
+}
+
+// fetchSample은 아직 샤드에 합치지 않은 첫 행/마지막 행까지 시간입니다 (RecordFetch).
+type fetchSample struct {
+	end      time.Time
+	latency  time.Duration
+	firstRow time.Duration
+	lastRow  time.Duration
+}
+
+// Recorder는 부하 생성기 워커 하나가 쓰는 기록기입니다. 한 고루틴에서만 사용해야 합니다.
+// 카운터는 Collector에 잠금 없이 바로 더하고(/metrics의 요청 수는 항상 최신), 지연시간 샘플은 자기 버퍼에 모았다가
+// recorderFlushInterval마다(또는 recorderBufferSize만큼 쌓이면) 샤드 하나를 잠그고 한꺼번에 합칩니다.
+// 워커가 많아도 요청마다 잠금을 잡지 않으므로 기록 경로에서 서로 기다리지 않습니다.
+// 대신 지연시간 통계는 최대 recorderFlushInterval(쿼리 하나가 그보다 길면 그 쿼리가 끝날 때까지) 늦게 반영되므로,
+// 쿼리 사이에 기다리기 전에는 FlushIfDue를, 워커가 끝날 때는 Flush를 호출해야 합니다.
+type Recorder struct {
+	c         *Collector
+	stripe    int // 카운터를 더할 묶음 (window.counters, 워커마다 다르게)
+	samples   []latencySample
+	fetches   []fetchSample
+	lastFlush time.Time
+}
+
+// NewRecorder는 c에 기록하는 워커별 기록기를 만듭니다.
+func (c *Collector) NewRecorder() *Recorder {
+	stripe := int(c.recorders.Add(1) % int64(len(c.shards)))
+	return &Recorder{c: c, stripe: stripe, lastFlush: time.Now()}
+}
+
+// RecordSuccess는 Collector.RecordSuccess와 같지만 지연시간을 버퍼에 모읍니다.
+func (r *Recorder) RecordSuccess(label string, latency time.Duration, rows int) {
+	now := time.Now()
+	if !r.c.countSuccess(r.stripe, label, now, latency, rows) {
+		return
+	}
+	r.samples = append(r.samples, latencySample{label: label, end: now, latency: latency})
+	r.flushIfDue(now)
+}
+
+// RecordFetch는 Collector.RecordFetch와 같지만 버퍼에 모읍니다.
+func (r *Recorder) RecordFetch(latency, firstRow, lastRow time.Duration) {
+	now := time.Now()
+	r.fetches = append(r.fetches, fetchSample{end: now, latency: latency, firstRow: firstRow, lastRow: lastRow})
+	r.flushIfDue(now)
+}
+
+// FlushIfDue는 마지막으로 합친 뒤 recorderFlushInterval이 지났으면 버퍼를 합칩니다.
+// 워커가 쿼리 사이에 기다리기 전에 호출하면, 쿼리가 뜸해도 샘플이 주기를 넘겨 버퍼에 남지 않습니다.
+func (r *Recorder) FlushIfDue() {
+	r.flushIfDue(time.Now())
+}
+
+func (r *Recorder) flushIfDue(now time.Time) {
+	if len(r.samples)+len(r.fetches) >= recorderBufferSize || now.Sub(r.lastFlush) >= recorderFlushInterval {
+		r.Flush()
+	}
+}
+
+// Flush는 버퍼에 모은 샘플을 Collector에 합칩니다. 워커가 끝날 때 호출합니다.
+func (r *Recorder) Flush() {
+	if len(r.samples) > 0 || len(r.fetches) > 0 {
+		r.c.addSamples(r.samples, r.fetches)
+	}
+	r.samples = r.samples[:0]
+	r.fetches = r.fetches[:0]
+	r.lastFlush = time.Now()
+}
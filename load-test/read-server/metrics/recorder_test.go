@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// 카운터는 바로 반영되고, 지연시간은 Flush(또는 주기)에서 합쳐지는지 확인합니다.
+func TestRecorderFlush(t *testing.T) {
+	c := NewCollector()
+	r := c.NewRecorder()
+	time.Sleep(5 * time.Millisecond) // 기록할 요청이 Collector를 만든 뒤에 시작하도록
+
+	r.RecordSuccess("simple", time.Millisecond, 10)
+	r.RecordSuccess("filter", 2*time.Millisecond, 5)
+
+	m := c.GetMetrics()
+	if m.SuccessRequests != 2 || m.RowsRead != 15 {
+		t.Fatalf("success/rows = %d/%d before Flush, want 2/15", m.SuccessRequests, m.RowsRead)
+	}
+	if m.LatencySamples != 0 {
+		t.Fatalf("latency_samples = %d before Flush, want 0 (buffered)", m.LatencySamples)
+	}
+
+	r.Flush()
+	m = c.GetMetrics()
+	if m.LatencySamples != 2 {
+		t.Fatalf("latency_samples = %d after Flush, want 2", m.LatencySamples)
+	}
+	if m.ByLabel["simple"].SuccessRequests != 1 || m.ByLabel["filter"].RowsRead != 5 {
+		t.Fatalf("by_label = %+v", m.ByLabel)
+	}
+}
+
+// Reset 이전에 끝나 버퍼에 남아 있던 샘플은 Reset 뒤에 합쳐도 버려지는지 확인합니다.
+func TestRecorderDropsSamplesFromBeforeReset(t *testing.T) {
+	c := NewCollector()
+	r := c.NewRecorder()
+	time.Sleep(5 * time.Millisecond) // 기록할 요청이 Collector를 만든 뒤에 시작하도록
+
+	r.RecordSuccess("simple", time.Millisecond, 1)
+	time.Sleep(time.Millisecond)
+	c.Reset()
+	r.Flush()
+
+	m := c.GetMetrics()
+	if m.TotalRequests != 0 || m.LatencySamples != 0 {
+		t.Fatalf("total/latency_samples = %d/%d, want 0/0", m.TotalRequests, m.LatencySamples)
+	}
+}
+
+// 워커 64개가 동시에 기록하고 조회와 겹쳐도 경합이 없는지 확인합니다 (go test -race로 실행).
+func TestRecorderConcurrent(t *testing.T) {
+	const workers, perWorker = 64, 1000
+	c := NewCollector()
+
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	readers.Add(1)
+	go func() {
+		defer readers.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				c.GetMetrics()
+				c.RequestCounts()
+				c.GetHeatmap()
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := c.NewRecorder()
+			defer r.Flush()
+			for j := 0; j < perWorker; j++ {
+				r.RecordSuccess("simple", 0, 1)
+				r.RecordFetch(0, 0, 0)
+				c.RecordTimeout("simple", 0)
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	readers.Wait()
+
+	m := c.GetMetrics()
+	if m.SuccessRequests != workers*perWorker || m.TimeoutRequests != workers*perWorker {
+		t.Fatalf("success/timeout = %d/%d, want %d each", m.SuccessRequests, m.TimeoutRequests, workers*perWorker)
+	}
+	if m.LatencySamples != workers*perWorker {
+		t.Fatalf("latency_samples = %d, want %d", m.LatencySamples, workers*perWorker)
+	}
+}
+
+// 워커 64개가 각자 Recorder로 기록하는 부하 생성기의 기록 경로입니다.
+func BenchmarkRecorder64Workers(b *testing.B) {
+	benchmarkWorkers(b, 64, func(c *Collector) func(time.Duration) {
+		r := c.NewRecorder()
+		return func(latency time.Duration) { r.RecordSuccess("simple", latency, 100) }
+	})
+}
+
+// 비교용: 같은 부하를 Collector.RecordSuccess(요청마다 샤드 잠금)로 기록합니다.
+func BenchmarkCollector64Workers(b *testing.B) {
+	benchmarkWorkers(b, 64, func(c *Collector) func(time.Duration) {
+		return func(latency time.Duration) { c.RecordSuccess("simple", latency, 100) }
+	})
+}
+
+// benchmarkWorkers는 b.N번의 기록을 workers개 고루틴에 나눠 동시에 실행합니다.
+func benchmarkWorkers(b *testing.B, workers int, newWorker func(c *Collector) func(time.Duration)) {
+	c := NewCollector()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		n := b.N / workers
+		if i < b.N%workers {
+			n++
+		}
+		record := newWorker(c)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				record(time.Duration(j%1000) * time.Microsecond)
+			}
+		}()
+	}
+	wg.Wait()
+}
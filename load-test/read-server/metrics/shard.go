@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// shard는 Collector의 지연시간 상태(지연시간 히스토그램, 히트맵, 라벨별 지연시간)를 나눠 담습니다 (카운터는 window.go).
+// 샘플은 워커별 기록기(Recorder)가 모았다가 무작위로 고른 샤드 하나를 잠그고 한꺼번에 합치므로 하나의 잠금에 몰리지 않고,
+// 조회(GetMetrics, GetHeatmap 등)는 모든 샤드를 잠근 뒤 합칩니다.
+type shard struct {
+	mu              sync.Mutex
+	latencies       latencyHistogram
+	warmupLatencies latencyHistogram // 워밍업 구간에 시작한 요청의 지연시간 (latencies와 별도, warmup.go)
+	// 첫 행/마지막 행까지 시간 (fetch.go, 같은 위치끼리 같은 쿼리)
+	firstRowLatencies []time.Duration
+	lastRowLatencies  []time.Duration
+	heatmap           heatmap
+	labels            map[string]*latencyHistogram // 라벨별 지연시간 (labels.go)
+}
+
+// shardCount는 샤드 수입니다. 워커가 CPU 수보다 많아도 같은 샤드를 고를 확률이 낮도록 GOMAXPROCS의 4배를 씁니다.
+func shardCount() int {
+	return runtime.GOMAXPROCS(0) * 4
+}
+
+func newShards(n int) []*shard {
+	shards := make([]*shard, n)
+	for i := range shards {
+		shards[i] = &shard{}
+	}
+	return shards
+}
+
+// reset은 샤드의 모든 값을 초기화합니다. s.mu를 잡은 상태에서 호출해야 합니다.
+func (s *shard) reset() {
+	s.latencies = latencyHistogram{}
+	s.warmupLatencies = latencyHistogram{}
+	s.firstRowLatencies = nil
+	s.lastRowLatencies = nil
+	s.heatmap = heatmap{}
+	s.labels = nil
+}
+
+// lockShard는 샘플을 합칠 샤드를 무작위로 골라 잠근 채 반환합니다.
+// math/rand의 전역 함수는 Seed를 호출하지 않으면 잠금 없이 동작하므로 여기서 경합이 생기지 않습니다.
+func (c *Collector) lockShard() *shard {
+	s := c.shards[rand.Intn(len(c.shards))]
+	s.mu.Lock()
+	return s
+}
+
+// lockShards는 모든 샤드를 순서대로 잠급니다. c.mu를 먼저 잡은 경우에도 이 순서를 지켜야 교착이 생기지 않습니다.
+func (c *Collector) lockShards() {
+	for _, s := range c.shards {
+		s.mu.Lock()
+	}
+}
+
+func (c *Collector) unlockShards() {
+	for _, s := range c.shards {
+		s.mu.Unlock()
+	}
+}
+
+// addSamples는 성공 요청의 지연시간과 첫 행/마지막 행까지 시간을 샤드 하나에 합칩니다.
+// 마지막 Reset 이전에 시작한 요청은 버리고, LatencySampleRate와 워밍업 제외도 여기서 적용합니다.
+func (c *Collector) addSamples(samples []latencySample, fetches []fetchSample) {
+	s := c.lockShard()
+	defer s.mu.Unlock()
+
+	for _, sample := range samples {
+		if c.startedBeforeReset(sample.end, sample.latency) {
+			continue
+		}
+
+		// 지연시간은 LatencySampleRate 비율로만 기록 (카운터는 항상 정확)
+		// 워밍업 구간에 시작한 요청은 주 백분위수에서 빼고 따로 모음 (히트맵에는 포함)
+		if !c.sampleLatency() {
+			continue
+		}
+		s.heatmap.record(sample.end, sample.latency)
+		if c.inWarmup(sample.end, sample.latency) {
+			s.warmupLatencies.record(sample.latency)
+			continue
+		}
+		s.latencies.record(sample.latency)
+		if h := s.labelLatencies(sample.label); h != nil {
+			h.record(sample.latency)
+		}
+	}
+
+	for _, f := range fetches {
+		if c.startedBeforeReset(f.end, f.latency) {
+			continue
+		}
+		if !c.sampleLatency() || c.inWarmup(f.end, f.latency) {
+			continue
+		}
+		if len(s.firstRowLatencies) < c.maxLatencies {
+			s.firstRowLatencies = append(s.firstRowLatencies, f.firstRow)
+			s.lastRowLatencies = append(s.lastRowLatencies, f.lastRow)
+		}
+	}
+}
+
+// merged는 모든 샤드의 지연시간 상태를 합친 사본을 반환합니다 (히트맵 제외, GetHeatmap 참고).
+func (c *Collector) merged() *shard {
+	c.lockShards()
+	defer c.unlockShards()
+
+	total := &shard{}
+	for _, s := range c.shards {
+		total.latencies.merge(&s.latencies)
+		total.warmupLatencies.merge(&s.warmupLatencies)
+		total.firstRowLatencies = append(total.firstRowLatencies, s.firstRowLatencies...)
+		total.lastRowLatencies = append(total.lastRowLatencies, s.lastRowLatencies...)
+		for label, h := range s.labels {
+			total.labelLatencies(label).merge(h)
+		}
+	}
+	return total
+}
+
+// RequestCounts는 누적 요청 수와 에러 수(실패 + 타임아웃 + 연결 끊김)를 반환합니다.
+// GetMetrics와 달리 잠금 없이 카운터만 읽으므로 매초 호출해도 부담이 없습니다 (/readyz).
+func (c *Collector) RequestCounts() (total, errors int64) {
+	w := c.current.Load()
+	for i := range w.stripes {
+		cs := &w.stripes[i]
+		total += cs.totalRequests.Load()
+		errors += cs.failedRequests.Load() + cs.timeoutRequests.Load() + cs.connErrors.Load()
+	}
+	return total, errors
+}
+
+// mergedLatencies는 모든 샤드의 지연시간 히스토그램을 합친 사본을 반환합니다.
+func (c *Collector) mergedLatencies() *latencyHistogram {
+	c.lockShards()
+	defer c.unlockShards()
+
+	var total latencyHistogram
+	for _, s := range c.shards {
+		total.merge(&s.latencies)
+	}
+	return &total
+}
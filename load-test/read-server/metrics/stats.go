@@ -0,0 +1,189 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// MinComparisonSamples는 분포 비교에 필요한 최소 샘플 수입니다 (정규 근사가 성립하도록).
+const MinComparisonSamples = 20
+
+// DistributionComparison은 두 지연시간 분포의 Mann-Whitney U 검정 결과입니다.
+// 평균/백분위수만 비교하는 대신 "두 분포가 실제로 다른가"를 p-value로 판단합니다.
+type DistributionComparison struct {
+	Test         string  `json:"test"` // "mann-whitney-u"
+	SamplesA     int     `json:"samples_a"`
+	SamplesB     int     `json:"samples_b"`
+	MedianA      float64 `json:"median_a_ms"`
+	MedianB      float64 `json:"median_b_ms"`
+	U            float64 `json:"u"`
+	Z            float64 `json:"z"`
+	PValue       float64 `json:"p_value"`       // 양측 검정
+	Significant  bool    `json:"significant"`   // p < 0.05
+	ProbabilityB float64 `json:"probability_b"` // 무작위로 고른 B 샘플이 A 샘플보다 느릴 확률 (0.5 = 차이 없음)
+}
+
+// rankGroup은 같은 지연시간(히스토그램이면 같은 버킷)에 속한 A, B 샘플 수입니다. 순위를 매길 때 하나의 동점 그룹이 됩니다.
+type rankGroup struct {
+	latency time.Duration
+	a, b    int64
+}
+
+// CompareDistributions는 두 지연시간 샘플 집합에 Mann-Whitney U 검정(정규 근사, 동점 보정)을 수행합니다.
+// 분포 형태를 가정하지 않으므로 꼬리가 긴 지연시간 분포에도 쓸 수 있습니다.
+func CompareDistributions(a, b []time.Duration) (DistributionComparison, error) {
+	sortedA, sortedB := sortedCopy(a), sortedCopy(b)
+
+	// 두 정렬된 집합을 합치면서 같은 값끼리 묶음
+	var groups []rankGroup
+	i, j := 0, 0
+	for i < len(sortedA) || j < len(sortedB) {
+		var latency time.Duration
+		if j == len(sortedB) || (i < len(sortedA) && sortedA[i] <= sortedB[j]) {
+			latency = sortedA[i]
+		} else {
+			latency = sortedB[j]
+		}
+		g := rankGroup{latency: latency}
+		for i < len(sortedA) && sortedA[i] == latency {
+			g.a++
+			i++
+		}
+		for j < len(sortedB) && sortedB[j] == latency {
+			g.b++
+			j++
+		}
+		groups = append(groups, g)
+	}
+	return mannWhitney(groups)
+}
+
+// compareHistograms는 CompareDistributions와 같지만 히스토그램 버킷 하나를 동점 그룹으로 봅니다.
+// 버킷 폭(값의 1.6%)보다 작은 차이는 구분하지 않으며, 중앙값은 버킷 가운데 값입니다.
+func compareHistograms(a, b *latencyHistogram) (DistributionComparison, error) {
+	var groups []rankGroup
+	for i := 0; i < histogramBucketCount; i++ {
+		var g rankGroup
+		if a.counts != nil {
+			g.a = a.counts[i]
+		}
+		if b.counts != nil {
+			g.b = b.counts[i]
+		}
+		if g.a+g.b > 0 {
+			lower, width := histogramBucketRange(i)
+			g.latency = time.Duration(2*lower+width) * time.Microsecond / 2
+			groups = append(groups, g)
+		}
+	}
+	return mannWhitney(groups)
+}
+
+// mannWhitney는 지연시간 오름차순의 동점 그룹으로 U 검정을 계산합니다.
+func mannWhitney(groups []rankGroup) (DistributionComparison, error) {
+	var n1, n2 int64
+	for _, g := range groups {
+		n1 += g.a
+		n2 += g.b
+	}
+	if n1 < MinComparisonSamples || n2 < MinComparisonSamples {
+		return DistributionComparison{}, fmt.Errorf("need at least %d samples in each set, got %d and %d", MinComparisonSamples, n1, n2)
+	}
+
+	// 동점은 평균 순위를 부여하고, 분산 보정을 위해 동점 그룹 크기를 누적
+	n := float64(n1 + n2)
+	var rankSumA, tieSum float64
+	var before int64
+	for _, g := range groups {
+		t := g.a + g.b
+		rank := float64(2*before+t+1) / 2 // 1부터 시작하는 순위 before+1..before+t의 평균
+		rankSumA += rank * float64(g.a)
+		ft := float64(t)
+		tieSum += ft*ft*ft - ft
+		before += t
+	}
+
+	f1, f2 := float64(n1), float64(n2)
+	u1 := rankSumA - f1*(f1+1)/2
+	mean := f1 * f2 / 2
+	sigma := math.Sqrt(f1 * f2 / 12 * ((n + 1) - tieSum/(n*(n-1))))
+
+	z := 0.0
+	if sigma > 0 {
+		// 연속성 보정
+		diff := u1 - mean
+		switch {
+		case diff > 0.5:
+			diff -= 0.5
+		case diff < -0.5:
+			diff += 0.5
+		default:
+			diff = 0
+		}
+		z = diff / sigma
+	}
+	pValue := math.Erfc(math.Abs(z) / math.Sqrt2)
+
+	return DistributionComparison{
+		Test:         "mann-whitney-u",
+		SamplesA:     int(n1),
+		SamplesB:     int(n2),
+		MedianA:      groupMedianMs(groups, n1, func(g rankGroup) int64 { return g.a }),
+		MedianB:      groupMedianMs(groups, n2, func(g rankGroup) int64 { return g.b }),
+		U:            math.Min(u1, f1*f2-u1),
+		Z:            z,
+		PValue:       pValue,
+		Significant:  pValue < 0.05,
+		ProbabilityB: 1 - u1/(f1*f2),
+	}, nil
+}
+
+// groupMedianMs는 한쪽 집합(count로 고른 샘플 수, 전체 total개)의 중앙값(정렬한 total/2번째 값)입니다.
+func groupMedianMs(groups []rankGroup, total int64, count func(rankGroup) int64) float64 {
+	var seen int64
+	for _, g := range groups {
+		seen += count(g)
+		if seen > total/2 {
+			return float64(g.latency) / float64(time.Millisecond)
+		}
+	}
+	return 0
+}
+
+func sortedCopy(latencies []time.Duration) []time.Duration {
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+// SaveBaseline은 현재 지연시간 분포를 분포 비교의 기준(A)으로 저장하고 저장한 샘플 수를 반환합니다.
+// 기준은 Reset으로 지워지지 않으므로, 다음 실행의 샘플(B)과 비교할 수 있습니다.
+func (c *Collector) SaveBaseline() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.baseline = c.mergedLatencies()
+	c.baselineSavedAt = time.Now()
+	return int(c.baseline.count)
+}
+
+// CompareWithBaseline은 저장된 기준 분포(A)와 현재 분포(B)를 비교합니다.
+func (c *Collector) CompareWithBaseline() (DistributionComparison, time.Time, error) {
+	c.mu.RLock()
+	baseline := c.baseline
+	savedAt := c.baselineSavedAt
+	c.mu.RUnlock()
+	current := c.mergedLatencies()
+
+	if baseline == nil {
+		return DistributionComparison{}, time.Time{}, fmt.Errorf("no baseline saved, call POST /metrics/baseline first")
+	}
+
+	result, err := compareHistograms(baseline, current)
+	return result, savedAt, err
+}
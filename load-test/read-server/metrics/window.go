@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// window는 마지막 Reset 이후의 요청 카운터입니다 (지연시간 분포는 샤드, shard.go).
+// 기록 경로는 잠금 없이 atomic으로 더하고, Reset은 값을 지우는 대신 새 window로 바꿉니다.
+// 바꾸기 직전에 이전 window를 읽은 기록은 버려지는 이전 window에 더해지므로, 초기화 도중 끼어든 기록이 새 구간에 섞이지 않습니다.
+type window struct {
+	start time.Time
+
+	// 카운터 묶음 여러 벌. 워커마다 다른 묶음에 더하므로 같은 캐시 라인을 두고 다투지 않고, 조회할 때 모두 합칩니다.
+	stripes []counters
+
+	failureReasons sync.Map // 실패 원인 → *atomic.Int64 (failures.go, 실패에서만 쓰므로 나누지 않음)
+}
+
+// counters는 window의 카운터 한 벌입니다.
+type counters struct {
+	totalRequests   atomic.Int64
+	successRequests atomic.Int64
+	failedRequests  atomic.Int64
+	timeoutRequests atomic.Int64
+	connErrors      atomic.Int64
+	abortedRequests atomic.Int64
+	rowsRead        atomic.Int64
+	limiterWait     atomic.Int64 // 처리율 제한 대기 시간 합 (ns, limiter.go)
+	limiterTotal    atomic.Int64 // 처리율 제한이 있는 워커의 대기 + 작업 시간 합 (ns)
+	labels          sync.Map     // 라벨 → *labelCounters (labels.go)
+
+	_ [64]byte // 이웃한 묶음과 캐시 라인을 나눠 쓰지 않도록
+}
+
+// windowTotals는 window의 모든 카운터 묶음을 합친 값입니다.
+type windowTotals struct {
+	totalRequests   int64
+	successRequests int64
+	failedRequests  int64
+	timeoutRequests int64
+	connErrors      int64
+	abortedRequests int64
+	rowsRead        int64
+	limiterWait     time.Duration
+	limiterTotal    time.Duration
+	labels          map[string]*labelTotals
+}
+
+func newWindow(start time.Time, stripes int) *window {
+	return &window{start: start, stripes: make([]counters, stripes)}
+}
+
+// startedBefore는 now보다 elapsed 전에 시작한 작업이 이 window가 시작되기(마지막 Reset) 전에 시작했는지 확인합니다.
+func (w *window) startedBefore(now time.Time, elapsed time.Duration) bool {
+	return now.Add(-elapsed).Before(w.start)
+}
+
+// counters는 stripe번째 카운터 묶음을 반환합니다. stripe가 음수면(워커별 기록기 밖의 기록) 무작위로 고릅니다.
+func (w *window) counters(stripe int) *counters {
+	if stripe < 0 {
+		stripe = rand.Intn(len(w.stripes))
+	}
+	return &w.stripes[stripe%len(w.stripes)]
+}
+
+// label은 label의 카운터를 반환하며, 처음 보는 라벨이면 새로 만듭니다.
+// 빈 라벨은 합계에만 반영하므로 nil을 반환합니다. 이미 있는 라벨은 잠금 없이 찾습니다.
+func (cs *counters) label(label string) *labelCounters {
+	if label == "" {
+		return nil
+	}
+	if lc, ok := cs.labels.Load(label); ok {
+		return lc.(*labelCounters)
+	}
+	lc, _ := cs.labels.LoadOrStore(label, &labelCounters{})
+	return lc.(*labelCounters)
+}
+
+// totals는 모든 카운터 묶음을 합칩니다. 기록과 동시에 읽으므로 묶음 사이의 값은 같은 순간의 값이 아닐 수 있습니다.
+func (w *window) totals() windowTotals {
+	var t windowTotals
+	for i := range w.stripes {
+		cs := &w.stripes[i]
+		t.totalRequests += cs.totalRequests.Load()
+		t.successRequests += cs.successRequests.Load()
+		t.failedRequests += cs.failedRequests.Load()
+		t.timeoutRequests += cs.timeoutRequests.Load()
+		t.connErrors += cs.connErrors.Load()
+		t.abortedRequests += cs.abortedRequests.Load()
+		t.rowsRead += cs.rowsRead.Load()
+		t.limiterWait += time.Duration(cs.limiterWait.Load())
+		t.limiterTotal += time.Duration(cs.limiterTotal.Load())
+		cs.labels.Range(func(key, value interface{}) bool {
+			if t.labels == nil {
+				t.labels = make(map[string]*labelTotals)
+			}
+			label := key.(string)
+			lt, ok := t.labels[label]
+			if !ok {
+				lt = &labelTotals{}
+				t.labels[label] = lt
+			}
+			lt.add(value.(*labelCounters))
+			return true
+		})
+	}
+	return t
+}
+
+// addFailureReason은 실패 원인별 건수를 하나 늘립니다.
+func (w *window) addFailureReason(reason string) {
+	n, ok := w.failureReasons.Load(reason)
+	if !ok {
+		n, _ = w.failureReasons.LoadOrStore(reason, new(atomic.Int64))
+	}
+	n.(*atomic.Int64).Add(1)
+}
+
+// failureReasonCounts는 실패 원인별 건수의 사본을 반환합니다. 실패가 없으면 nil입니다.
+func (w *window) failureReasonCounts() map[string]int64 {
+	var counts map[string]int64
+	w.failureReasons.Range(func(reason, n interface{}) bool {
+		if counts == nil {
+			counts = make(map[string]int64)
+		}
+		counts[reason.(string)] = n.(*atomic.Int64).Load()
+		return true
+	})
+	return counts
+}
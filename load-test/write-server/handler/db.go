@@ -0,0 +1,381 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// DB 통계 조회 기본값
+const (
+	defaultQueryStatsLimit = 10
+	maxQueryStatsLimit     = 100
+	dbStatsTimeout         = 5 * time.Second
+	maintenanceTimeout     = 10 * time.Minute
+)
+
+// DBHandler는 PostgreSQL 자체 통계(pg_stat_* 뷰)를 조회하고, 실행 사이의 테이블 정리(VACUUM/ANALYZE)를 실행합니다.
+// 클라이언트가 측정한 지연시간을 DB 쪽 집계와 비교하기 위한 API입니다.
+type DBHandler struct {
+	db *sql.DB
+}
+
+func NewDBHandler(db *sql.DB) *DBHandler {
+	return &DBHandler{db: db}
+}
+
+// QueryStat은 pg_stat_statements의 정규화된 쿼리 하나에 대한 통계입니다.
+type QueryStat struct {
+	Query          string  `json:"query"`
+	Calls          int64   `json:"calls"`
+	TotalExecTime  float64 `json:"total_exec_time_ms"`
+	MeanExecTime   float64 `json:"mean_exec_time_ms"`
+	MaxExecTime    float64 `json:"max_exec_time_ms"`
+	StddevExecTime float64 `json:"stddev_exec_time_ms"`
+	Rows           int64   `json:"rows"`
+	SharedBlksHit  int64   `json:"shared_blks_hit"`
+	SharedBlksRead int64   `json:"shared_blks_read"`
+}
+
+// queryStatsOrder는 QueryStats의 order 파라미터로 허용하는 정렬 기준입니다.
+var queryStatsOrder = map[string]string{
+	"total": "total_exec_time",
+	"mean":  "mean_exec_time",
+	"calls": "calls",
+}
+
+// GET /db/query-stats - pg_stat_statements 상위 쿼리 (?order=total|mean|calls&limit=10)
+// 현재 데이터베이스의 쿼리만 반환하며, 확장이 설치되어 있지 않으면 503을 반환합니다.
+func (h *DBHandler) QueryStats(w http.ResponseWriter, r *http.Request) {
+	order := r.URL.Query().Get("order")
+	if order == "" {
+		order = "total"
+	}
+	column, ok := queryStatsOrder[order]
+	if !ok {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid order %q: must be one of total, mean, calls", order))
+		return
+	}
+
+	limit := defaultQueryStatsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+	if limit > maxQueryStatsLimit {
+		limit = maxQueryStatsLimit
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), dbStatsTimeout)
+	defer cancel()
+
+	var installed bool
+	err := h.db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_stat_statements')",
+	).Scan(&installed)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to check pg_stat_statements: %v", err))
+		return
+	}
+	if !installed {
+		writeError(w, r, http.StatusServiceUnavailable, "pg_stat_statements extension is not installed: add it to shared_preload_libraries and run CREATE EXTENSION pg_stat_statements")
+		return
+	}
+
+	// column은 queryStatsOrder의 값만 사용하므로 쿼리에 직접 넣어도 안전
+	query := fmt.Sprintf(`
+		SELECT COALESCE(query, ''), calls, total_exec_time, mean_exec_time, max_exec_time, stddev_exec_time,
+		       rows, shared_blks_hit, shared_blks_read
+		FROM pg_stat_statements
+		WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+		ORDER BY %s DESC
+		LIMIT $1
+	`, column)
+
+	rows, err := h.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		// 확장은 있지만 shared_preload_libraries에 없으면 여기서 실패 (55000)
+		writeError(w, r, http.StatusServiceUnavailable, fmt.Sprintf("Failed to query pg_stat_statements: %v", err))
+		return
+	}
+	defer rows.Close()
+
+	stats := make([]QueryStat, 0, limit)
+	for rows.Next() {
+		var s QueryStat
+		if err := rows.Scan(&s.Query, &s.Calls, &s.TotalExecTime, &s.MeanExecTime, &s.MaxExecTime, &s.StddevExecTime,
+			&s.Rows, &s.SharedBlksHit, &s.SharedBlksRead); err != nil {
+			writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to scan query stats: %v", err))
+			return
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to read query stats: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"order":   order,
+		"queries": stats,
+	})
+}
+
+// TableStat은 테이블 하나의 튜플 수, vacuum 이력, 크기입니다.
+// 쓰기/삭제 부하 중 dead tuple이 쌓이고 autovacuum이 정리하는 과정을 관찰하기 위한 값입니다.
+type TableStat struct {
+	Table           string     `json:"table"`
+	Schema          string     `json:"schema"`
+	LiveTuples      int64      `json:"n_live_tup"`
+	DeadTuples      int64      `json:"n_dead_tup"`
+	DeadRatio       float64    `json:"dead_ratio"` // n_dead_tup / (n_live_tup + n_dead_tup)
+	Inserted        int64      `json:"n_tup_ins"`
+	Updated         int64      `json:"n_tup_upd"`
+	Deleted         int64      `json:"n_tup_del"`
+	ModSinceAnalyze int64      `json:"n_mod_since_analyze"`
+	LastVacuum      *time.Time `json:"last_vacuum"`
+	LastAutovacuum  *time.Time `json:"last_autovacuum"`
+	LastAnalyze     *time.Time `json:"last_analyze"`
+	LastAutoanalyze *time.Time `json:"last_autoanalyze"`
+	VacuumCount     int64      `json:"vacuum_count"`
+	AutovacuumCount int64      `json:"autovacuum_count"`
+	TableSizeBytes  int64      `json:"table_size_bytes"` // 힙 (pg_relation_size)
+	IndexSizeBytes  int64      `json:"index_size_bytes"` // 모든 인덱스 (pg_indexes_size)
+	TotalSizeBytes  int64      `json:"total_size_bytes"` // 힙 + 인덱스 + TOAST (pg_total_relation_size)
+
+	// 현재 데이터베이스의 세션 기준 (테이블과 무관). 오래 열린 트랜잭션이 xmin horizon을 묶으면
+	// 그 이후에 생긴 dead tuple은 VACUUM이 회수하지 못하므로, n_dead_tup이 줄지 않을 때 함께 확인합니다.
+	IdleInTransaction int64   `json:"idle_in_transaction"` // state = 'idle in transaction'인 세션 수
+	OldestIdleXactMs  float64 `json:"oldest_idle_xact_ms"` // 그중 가장 오래 열린 트랜잭션의 경과 시간
+	XminHorizonAge    int64   `json:"xmin_horizon_age"`    // 세션이 붙잡은 가장 오래된 xmin의 나이 (트랜잭션 수, age(backend_xmin))
+}
+
+// GET /db/table-stats - 테이블 dead tuple, vacuum 이력, 크기 (?table=logs)
+func (h *DBHandler) TableStats(w http.ResponseWriter, r *http.Request) {
+	table := r.URL.Query().Get("table")
+	if table == "" {
+		table = "logs"
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), dbStatsTimeout)
+	defer cancel()
+
+	// 테이블 이름은 바인딩 파라미터로만 비교하고, 크기 함수에는 뷰의 relid를 넘김
+	var s TableStat
+	var lastVacuum, lastAutovacuum, lastAnalyze, lastAutoanalyze sql.NullTime
+	err := h.db.QueryRowContext(ctx, `
+		SELECT relname, schemaname, n_live_tup, n_dead_tup, n_tup_ins, n_tup_upd, n_tup_del, n_mod_since_analyze,
+		       last_vacuum, last_autovacuum, last_analyze, last_autoanalyze, vacuum_count, autovacuum_count,
+		       pg_relation_size(relid), pg_indexes_size(relid), pg_total_relation_size(relid)
+		FROM pg_stat_user_tables
+		WHERE relname = $1
+		ORDER BY schemaname = current_schema() DESC
+		LIMIT 1
+	`, table).Scan(&s.Table, &s.Schema, &s.LiveTuples, &s.DeadTuples, &s.Inserted, &s.Updated, &s.Deleted, &s.ModSinceAnalyze,
+		&lastVacuum, &lastAutovacuum, &lastAnalyze, &lastAutoanalyze, &s.VacuumCount, &s.AutovacuumCount,
+		&s.TableSizeBytes, &s.IndexSizeBytes, &s.TotalSizeBytes)
+	if err == sql.ErrNoRows {
+		writeError(w, r, http.StatusNotFound, fmt.Sprintf("table %q not found", table))
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to query table stats: %v", err))
+		return
+	}
+
+	// idle in transaction 세션과 xmin horizon은 pg_stat_activity에서 따로 조회
+	err = h.db.QueryRowContext(ctx, `
+		SELECT count(*) FILTER (WHERE state = 'idle in transaction'),
+		       COALESCE(MAX(EXTRACT(EPOCH FROM now() - xact_start) * 1000) FILTER (WHERE state = 'idle in transaction'), 0),
+		       COALESCE(MAX(age(backend_xmin)), 0)
+		FROM pg_stat_activity
+		WHERE datname = current_database()
+	`).Scan(&s.IdleInTransaction, &s.OldestIdleXactMs, &s.XminHorizonAge)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to query session stats: %v", err))
+		return
+	}
+
+	s.LastVacuum = nullTime(lastVacuum)
+	s.LastAutovacuum = nullTime(lastAutovacuum)
+	s.LastAnalyze = nullTime(lastAnalyze)
+	s.LastAutoanalyze = nullTime(lastAutoanalyze)
+	if total := s.LiveTuples + s.DeadTuples; total > 0 {
+		s.DeadRatio = float64(s.DeadTuples) / float64(total)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s)
+}
+
+// maintenanceOps는 Maintenance의 op 파라미터로 허용하는 명령입니다.
+var maintenanceOps = map[string]string{
+	"vacuum":         "VACUUM",
+	"analyze":        "ANALYZE",
+	"vacuum_analyze": "VACUUM ANALYZE",
+}
+
+// MaintenanceResult는 수동 VACUUM/ANALYZE 실행 결과입니다.
+type MaintenanceResult struct {
+	Op               string  `json:"op"`
+	Table            string  `json:"table"`
+	Schema           string  `json:"schema"`
+	DurationMs       float64 `json:"duration_ms"`
+	DeadTuplesBefore int64   `json:"dead_tuples_before"`
+	DeadTuplesAfter  int64   `json:"dead_tuples_after"`
+	LiveTuplesBefore int64   `json:"live_tuples_before"`
+	LiveTuplesAfter  int64   `json:"live_tuples_after"`
+}
+
+// POST /db/maintenance - 수동 VACUUM/ANALYZE (?op=vacuum|analyze|vacuum_analyze&table=logs)
+// 변경이 많은 실행 뒤 부풀어 오른 테이블과 오래된 통계를 정리해, 다음 비교 실행을 같은 상태에서 시작하기 위한 API입니다.
+// VACUUM은 트랜잭션 안에서 실행할 수 없으므로 전용 연결에서 자동 커밋으로 실행합니다.
+func (h *DBHandler) Maintenance(w http.ResponseWriter, r *http.Request) {
+	op := r.URL.Query().Get("op")
+	command, ok := maintenanceOps[op]
+	if !ok {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid op %q: must be one of vacuum, analyze, vacuum_analyze", op))
+		return
+	}
+	table := r.URL.Query().Get("table")
+	if table == "" {
+		table = "logs"
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), maintenanceTimeout)
+	defer cancel()
+
+	// 전후 통계를 같은 연결에서 읽도록 연결 하나를 잡아 둠
+	conn, err := h.db.Conn(ctx)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to get connection: %v", err))
+		return
+	}
+	defer conn.Close()
+
+	result := MaintenanceResult{Op: op}
+	err = conn.QueryRowContext(ctx, `
+		SELECT relname, schemaname, n_dead_tup, n_live_tup
+		FROM pg_stat_user_tables
+		WHERE relname = $1
+		ORDER BY schemaname = current_schema() DESC
+		LIMIT 1
+	`, table).Scan(&result.Table, &result.Schema, &result.DeadTuplesBefore, &result.LiveTuplesBefore)
+	if err == sql.ErrNoRows {
+		writeError(w, r, http.StatusNotFound, fmt.Sprintf("table %q not found", table))
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to query table stats: %v", err))
+		return
+	}
+
+	// 테이블 이름은 pg_stat_user_tables에서 확인한 값만 식별자로 인용해 사용
+	target := pq.QuoteIdentifier(result.Schema) + "." + pq.QuoteIdentifier(result.Table)
+	start := time.Now()
+	if _, err := conn.ExecContext(ctx, command+" "+target); err != nil {
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to run %s: %v", command, err))
+		return
+	}
+	result.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+
+	// VACUUM/ANALYZE는 결과를 통계에 바로 반영하므로 같은 연결에서 다시 읽으면 됨
+	err = conn.QueryRowContext(ctx, `
+		SELECT n_dead_tup, n_live_tup
+		FROM pg_stat_user_tables
+		WHERE schemaname = $1 AND relname = $2
+	`, result.Schema, result.Table).Scan(&result.DeadTuplesAfter, &result.LiveTuplesAfter)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to query table stats: %v", err))
+		return
+	}
+
+	log.Printf("%s %s finished in %.1fms (dead tuples %d -> %d)",
+		command, target, result.DurationMs, result.DeadTuplesBefore, result.DeadTuplesAfter)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// LockWait는 잠금을 기다리는 세션(blocked)과 그 세션을 막고 있는 세션(blocking) 한 쌍입니다.
+// 한 세션이 여러 세션에게 막혀 있으면 막고 있는 세션마다 한 쌍씩 나옵니다.
+type LockWait struct {
+	Database       string  `json:"database"`
+	BlockedPID     int     `json:"blocked_pid"`
+	BlockedApp     string  `json:"blocked_application_name"`
+	BlockedQuery   string  `json:"blocked_query"`
+	BlockedMs      float64 `json:"blocked_ms"` // 막힌 쿼리가 시작된 뒤 지난 시간
+	LockType       string  `json:"lock_type"`  // relation, tuple, transactionid 등 (행 잠금 대기는 보통 transactionid)
+	LockMode       string  `json:"lock_mode"`
+	Relation       string  `json:"relation,omitempty"` // 테이블 잠금 대기일 때만
+	BlockingPID    int     `json:"blocking_pid"`
+	BlockingApp    string  `json:"blocking_application_name"`
+	BlockingState  string  `json:"blocking_state"`   // "idle in transaction"이면 잠금을 쥔 채 커밋하지 않고 멈춘 트랜잭션
+	BlockingQuery  string  `json:"blocking_query"`   // 막고 있는 세션의 마지막 쿼리 (잠금을 잡은 쿼리가 아닐 수 있음)
+	BlockingXactMs float64 `json:"blocking_xact_ms"` // 막고 있는 트랜잭션이 시작된 뒤 지난 시간
+}
+
+// GET /db/locks - 현재 잠금 대기 중인 세션과 막고 있는 세션 쌍 (오래 기다린 순)
+// 경합 실험 중 무엇이 막고 있는지, 실행이 왜 멈췄는지 확인하기 위한 API입니다.
+// 같은 PostgreSQL의 다른 데이터베이스(예: lost-update 데모)도 포함하며, 대기가 없으면 빈 배열을 반환합니다.
+func (h *DBHandler) Locks(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), dbStatsTimeout)
+	defer cancel()
+
+	// 기다리는 잠금(granted = false)은 pg_locks에서, 막고 있는 세션은 pg_blocking_pids로 찾음
+	rows, err := h.db.QueryContext(ctx, `
+		SELECT COALESCE(blocked.datname, ''), blocked.pid, blocked.application_name, COALESCE(blocked.query, ''),
+		       COALESCE(EXTRACT(EPOCH FROM now() - blocked.query_start) * 1000, 0),
+		       blocked_lock.locktype, blocked_lock.mode, COALESCE(blocked_lock.relation::regclass::text, ''),
+		       blocking.pid, blocking.application_name, COALESCE(blocking.state, ''), COALESCE(blocking.query, ''),
+		       COALESCE(EXTRACT(EPOCH FROM now() - blocking.xact_start) * 1000, 0)
+		FROM pg_stat_activity AS blocked
+		JOIN pg_locks AS blocked_lock ON blocked_lock.pid = blocked.pid AND NOT blocked_lock.granted
+		JOIN pg_stat_activity AS blocking ON blocking.pid = ANY(pg_blocking_pids(blocked.pid))
+		ORDER BY blocked.query_start, blocked.pid, blocking.pid
+	`)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to query lock waits: %v", err))
+		return
+	}
+	defer rows.Close()
+
+	waits := make([]LockWait, 0)
+	for rows.Next() {
+		var l LockWait
+		if err := rows.Scan(&l.Database, &l.BlockedPID, &l.BlockedApp, &l.BlockedQuery, &l.BlockedMs,
+			&l.LockType, &l.LockMode, &l.Relation,
+			&l.BlockingPID, &l.BlockingApp, &l.BlockingState, &l.BlockingQuery, &l.BlockingXactMs); err != nil {
+			writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to scan lock waits: %v", err))
+			return
+		}
+		waits = append(waits, l)
+	}
+	if err := rows.Err(); err != nil {
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to read lock waits: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"count":      len(waits),
+		"lock_waits": waits,
+	})
+}
+
+// nullTime은 NULL이면 nil을 반환합니다 (JSON에서 null로 표시).
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"write-server/load"
+)
+
+// redacted는 비밀 값 대신 보여 주는 문자열입니다.
+const redacted = "[REDACTED]"
+
+// DatabaseSettings는 DB 연결과 연결 풀 설정입니다. 비밀번호는 담지 않습니다.
+// /debug/config는 연결 풀 값을 요청마다 부하 생성기가 지금 적용한 값으로 바꿔 보여 줍니다.
+type DatabaseSettings struct {
+	Host            string `json:"host"`
+	Port            string `json:"port"`
+	Name            string `json:"name"`
+	User            string `json:"user"`
+	MaxOpenConns    int    `json:"max_open_conns"`
+	MaxIdleConns    int    `json:"max_idle_conns"`
+	ConnMaxLifetime string `json:"conn_max_lifetime"`
+
+	// 연결 문자열의 나머지 파라미터 (sslmode, application_name, DB_EXTRA_PARAMS)
+	Params map[string]string `json:"params"`
+}
+
+// ServerSettings는 HTTP/gRPC 서버 설정입니다. 시간 값은 사람이 읽기 쉽도록 문자열(예: "15s")로 표시합니다.
+type ServerSettings struct {
+	HTTPPort     string `json:"http_port"`
+	GRPCPort     string `json:"grpc_port"`
+	ReadTimeout  string `json:"read_timeout"`
+	WriteTimeout string `json:"write_timeout"`
+	IdleTimeout  string `json:"idle_timeout"`
+	AuditLogFile string `json:"audit_log_file"` // 빈 값 = 표준 출력
+	ConfigFile   string `json:"config_file"`    // -config 플래그 (빈 값 = 사용 안 함)
+}
+
+// RuntimeConfig는 프로세스가 시작할 때 정해진 설정 전체입니다 (환경 변수, 플래그, 연결 풀, 서버 타임아웃).
+type RuntimeConfig struct {
+	Database     DatabaseSettings  `json:"database"`
+	ReadDatabase *DatabaseSettings `json:"read_database,omitempty"` // read-your-writes 검증용 DB (READ_DB_HOST 지정 시)
+	Server       ServerSettings    `json:"server"`
+	Limits       BatchLimits       `json:"limits"` // 쓰기 API 요청 크기 제한
+	Env          map[string]string `json:"env"`    // 설정된 환경 변수 (비밀 값은 가림)
+}
+
+// EnvSnapshot은 keys 중 설정된 환경 변수를 반환합니다. 이름에 PASSWORD, SECRET, TOKEN이 들어간 값은 가립니다.
+func EnvSnapshot(keys []string) map[string]string {
+	env := make(map[string]string)
+	for _, key := range keys {
+		value, ok := os.LookupEnv(key)
+		if !ok {
+			continue
+		}
+		if isSecretKey(key) {
+			value = redacted
+		}
+		env[key] = value
+	}
+	return env
+}
+
+func isSecretKey(key string) bool {
+	upper := strings.ToUpper(key)
+	for _, word := range []string{"PASSWORD", "SECRET", "TOKEN"} {
+		if strings.Contains(upper, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// GET /debug/config - 프로세스가 실제로 사용 중인 설정 전체 조회 (DB 비밀번호는 가림)
+// 환경 변수로 정해진 값은 다른 API로 볼 수 없으므로, 보고된 동작을 재현할 때 이 응답을 함께 받습니다.
+func DebugConfigHandler(rc RuntimeConfig, generator *load.Generator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 부하 설정(max_open_conns 등)이 실행 중에 풀을 바꾸므로 시작할 때의 값 대신 지금 적용된 값을 보여 줌
+		current := rc
+		pool := generator.PoolSettings()
+		current.Database.MaxOpenConns = pool.MaxOpenConns
+		current.Database.MaxIdleConns = pool.MaxIdleConns
+		current.Database.ConnMaxLifetime = pool.ConnMaxLifetime.String()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"runtime":    current,
+			"load":       generator.GetConfig(),
+			"running":    generator.IsRunning(),
+			"go_version": runtime.Version(),
+			"gomaxprocs": runtime.GOMAXPROCS(0),
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// RequestIDHeader는 클라이언트가 요청을 추적하기 위해 보내는 상관관계 ID 헤더입니다.
+const RequestIDHeader = "X-Request-ID"
+
+// ErrorResponse는 모든 HTTP API의 에러 응답 본문입니다.
+// 성공 응답과 같이 JSON으로 반환하므로 클라이언트는 상태 코드와 message만 보면 됩니다.
+type ErrorResponse struct {
+	Code      int    `json:"code"`                 // HTTP 상태 코드
+	Message   string `json:"message"`              // 사람이 읽을 수 있는 에러 메시지
+	RequestID string `json:"request_id,omitempty"` // 요청의 X-Request-ID (보낸 경우에만)
+}
+
+// writeError는 상태 코드와 JSON 에러 본문을 씁니다. 헤더는 여기서 한 번만 쓰므로,
+// 핸들러는 응답 본문을 쓰기 전에 모든 에러를 처리하고 writeError 호출 뒤 바로 반환해야 합니다.
+func writeError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	writeErrorBody(w, r, status, errorResponse(r, status, message))
+}
+
+// errorResponse는 요청의 X-Request-ID를 채운 에러 응답 본문을 만듭니다.
+// 에러 응답에 필드를 덧붙이는 핸들러(예: 배치 INSERT의 청크별 결과)가 ErrorResponse를 포함한 본문을 만들 때 씁니다.
+func errorResponse(r *http.Request, status int, message string) ErrorResponse {
+	return ErrorResponse{
+		Code:      status,
+		Message:   message,
+		RequestID: r.Header.Get(RequestIDHeader),
+	}
+}
+
+// writeErrorBody는 writeError와 같지만 ErrorResponse를 포함한 임의의 본문을 씁니다.
+func writeErrorBody(w http.ResponseWriter, r *http.Request, status int, body interface{}) {
+	if requestID := r.Header.Get(RequestIDHeader); requestID != "" {
+		w.Header().Set(RequestIDHeader, requestID)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// NotFound는 등록되지 않은 경로에 JSON 404를 반환합니다 (mux Router.NotFoundHandler).
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, http.StatusNotFound, "no route for "+r.URL.Path)
+}
+
+// MethodNotAllowed는 경로는 있지만 메서드가 다를 때 JSON 405를 반환합니다 (mux Router.MethodNotAllowedHandler).
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, http.StatusMethodNotAllowed, r.Method+" is not allowed for "+r.URL.Path)
+}
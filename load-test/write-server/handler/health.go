@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+	"write-server/metrics"
+)
+
+// 헬스 상태 (/readyz)
+const (
+	HealthHealthy   = "healthy"   // 200
+	HealthDegraded  = "degraded"  // 200: 요청은 처리하지만 에러율이나 DB 지연이 기준을 넘음
+	HealthUnhealthy = "unhealthy" // 503: DB에 닿지 않거나 대부분의 요청이 실패함
+)
+
+// 헬스체크 기준 기본값
+const (
+	DefaultHealthWindow        = 30 * time.Second
+	DefaultDegradedErrorRate   = 0.05
+	DefaultUnhealthyErrorRate  = 0.5
+	DefaultDegradedPingLatency = 100 * time.Millisecond
+
+	// minHealthWindow는 윈도우의 최소 길이입니다 (샘플 간격보다 짧으면 에러율을 계산할 수 없음).
+	minHealthWindow = time.Second
+	// healthSampleInterval은 누적 요청 수/에러 수를 기록하는 간격입니다.
+	healthSampleInterval = time.Second
+	// minHealthRequests는 에러율을 판단하기 위해 윈도우 안에 필요한 최소 요청 수입니다 (fail-fast와 같음).
+	minHealthRequests = 10
+	// healthPingTimeout은 DB ping 제한 시간입니다. 넘으면 unhealthy입니다.
+	healthPingTimeout = 2 * time.Second
+)
+
+// HealthThresholds는 /readyz의 판정 기준입니다.
+type HealthThresholds struct {
+	Window              time.Duration // 에러율을 계산하는 최근 구간
+	DegradedErrorRate   float64       // 윈도우 에러율이 이보다 크면 degraded
+	UnhealthyErrorRate  float64       // 윈도우 에러율이 이보다 크면 unhealthy
+	DegradedPingLatency time.Duration // DB ping이 이보다 오래 걸리면 degraded (0 = 사용 안 함)
+}
+
+// Validate는 기준 값의 범위를 검증합니다.
+func (t HealthThresholds) Validate() error {
+	switch {
+	case t.Window < minHealthWindow:
+		return fmt.Errorf("window must be at least %s, got %s", minHealthWindow, t.Window)
+	case t.DegradedErrorRate < 0 || t.DegradedErrorRate > 1:
+		return fmt.Errorf("degraded error rate must be between 0 and 1, got %g", t.DegradedErrorRate)
+	case t.UnhealthyErrorRate < 0 || t.UnhealthyErrorRate > 1:
+		return fmt.Errorf("unhealthy error rate must be between 0 and 1, got %g", t.UnhealthyErrorRate)
+	case t.DegradedErrorRate > t.UnhealthyErrorRate:
+		return fmt.Errorf("degraded error rate (%g) must not exceed unhealthy error rate (%g)", t.DegradedErrorRate, t.UnhealthyErrorRate)
+	}
+	return nil
+}
+
+// HealthThresholdsView는 응답에 담는 판정 기준입니다. 시간 값은 문자열(예: "30s")로 표시합니다.
+type HealthThresholdsView struct {
+	Window              string  `json:"window"`
+	DegradedErrorRate   float64 `json:"degraded_error_rate"`
+	UnhealthyErrorRate  float64 `json:"unhealthy_error_rate"`
+	DegradedPingLatency string  `json:"degraded_ping_latency"` // "0s" = 사용 안 함
+}
+
+// HealthResponse는 GET /readyz 응답 본문입니다.
+type HealthResponse struct {
+	Status  string   `json:"status"`
+	Reasons []string `json:"reasons"` // healthy가 아닌 이유 (healthy면 빈 배열)
+
+	// 최근 윈도우의 요청 (부하 생성기 + API). 서버가 막 시작했으면 윈도우가 설정보다 짧음
+	WindowSeconds  float64 `json:"window_seconds"`
+	WindowRequests int64   `json:"window_requests"`
+	WindowErrors   int64   `json:"window_errors"` // 실패 + 타임아웃 + 연결 끊김
+	ErrorRate      float64 `json:"error_rate"`
+	// 요청이 minHealthRequests보다 적으면 에러율로 판정하지 않음
+	ErrorRateJudged bool `json:"error_rate_judged"`
+
+	DBPingMs   float64              `json:"db_ping_ms"`
+	Thresholds HealthThresholdsView `json:"thresholds"`
+}
+
+// healthSample은 한 시점의 누적 요청 수와 에러 수입니다.
+type healthSample struct {
+	time     time.Time
+	requests int64
+	errors   int64
+}
+
+// HealthChecker는 최근 윈도우의 에러율과 DB ping으로 서버 상태를 세 단계(healthy/degraded/unhealthy)로 판정합니다.
+// /health는 프로세스가 살아 있는지만 알려 주므로, 로드 밸런서나 오케스트레이터가 "느려졌지만 살아 있음"을
+// 구분할 수 있도록 별도 엔드포인트로 제공합니다.
+type HealthChecker struct {
+	db         *sql.DB
+	collector  *metrics.Collector
+	thresholds HealthThresholds
+
+	mu      sync.Mutex
+	samples []healthSample // 오래된 순, 윈도우보다 오래된 샘플은 버림
+}
+
+func NewHealthChecker(db *sql.DB, collector *metrics.Collector, thresholds HealthThresholds) *HealthChecker {
+	return &HealthChecker{
+		db:         db,
+		collector:  collector,
+		thresholds: thresholds,
+	}
+}
+
+// Start는 누적 요청 수/에러 수를 healthSampleInterval마다 기록하는 고루틴을 시작합니다 (프로세스가 끝날 때까지).
+func (h *HealthChecker) Start() {
+	h.sample(time.Now())
+	go func() {
+		ticker := time.NewTicker(healthSampleInterval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			h.sample(now)
+		}
+	}()
+}
+
+func (h *HealthChecker) sample(now time.Time) {
+	requests, errors := h.collector.RequestCounts()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// 메트릭이 초기화되어 누적 값이 줄었으면 이전 샘플은 비교할 수 없음
+	if n := len(h.samples); n > 0 && requests < h.samples[n-1].requests {
+		h.samples = h.samples[:0]
+	}
+	h.samples = append(h.samples, healthSample{time: now, requests: requests, errors: errors})
+
+	// 윈도우 시작 시점 이전의 샘플은 가장 최근 것 하나만 남김 (윈도우 전체를 덮는 기준점)
+	cutoff := now.Add(-h.thresholds.Window)
+	drop := 0
+	for drop+1 < len(h.samples) && !h.samples[drop+1].time.After(cutoff) {
+		drop++
+	}
+	h.samples = h.samples[drop:]
+}
+
+// windowCounts는 윈도우 시작 기준점부터 지금까지의 요청 수와 에러 수, 실제 구간 길이를 반환합니다.
+func (h *HealthChecker) windowCounts(now time.Time) (requests, errors int64, elapsed time.Duration) {
+	requests, errors = h.collector.RequestCounts()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) == 0 {
+		return 0, 0, 0
+	}
+	base := h.samples[0]
+	if requests < base.requests {
+		// 마지막 샘플 이후 메트릭이 초기화됨: 초기화 이후 값이 곧 최근 값
+		return requests, errors, now.Sub(h.samples[len(h.samples)-1].time)
+	}
+	return requests - base.requests, errors - base.errors, now.Sub(base.time)
+}
+
+// GET /readyz - 최근 에러율과 DB ping으로 판정한 서버 상태 (healthy/degraded = 200, unhealthy = 503)
+func (h *HealthChecker) Readyz(w http.ResponseWriter, r *http.Request) {
+	t := h.thresholds
+	resp := HealthResponse{
+		Status:  HealthHealthy,
+		Reasons: []string{},
+		Thresholds: HealthThresholdsView{
+			Window:              t.Window.String(),
+			DegradedErrorRate:   t.DegradedErrorRate,
+			UnhealthyErrorRate:  t.UnhealthyErrorRate,
+			DegradedPingLatency: t.DegradedPingLatency.String(),
+		},
+	}
+	degrade := func(status, reason string) {
+		if status == HealthUnhealthy || resp.Status == HealthHealthy {
+			resp.Status = status
+		}
+		resp.Reasons = append(resp.Reasons, reason)
+	}
+
+	// DB ping: 닿지 않으면 unhealthy, 느리면 degraded
+	ctx, cancel := context.WithTimeout(r.Context(), healthPingTimeout)
+	start := time.Now()
+	err := h.db.PingContext(ctx)
+	ping := time.Since(start)
+	cancel()
+	resp.DBPingMs = float64(ping) / float64(time.Millisecond)
+	switch {
+	case err != nil:
+		degrade(HealthUnhealthy, fmt.Sprintf("database ping failed: %v", err))
+	case t.DegradedPingLatency > 0 && ping > t.DegradedPingLatency:
+		degrade(HealthDegraded, fmt.Sprintf("database ping took %.1fms (threshold %s)", resp.DBPingMs, t.DegradedPingLatency))
+	}
+
+	// 최근 윈도우 에러율
+	requests, errors, elapsed := h.windowCounts(time.Now())
+	resp.WindowSeconds = elapsed.Seconds()
+	resp.WindowRequests = requests
+	resp.WindowErrors = errors
+	if requests > 0 {
+		resp.ErrorRate = float64(errors) / float64(requests)
+	}
+	if requests >= minHealthRequests {
+		resp.ErrorRateJudged = true
+		switch {
+		case resp.ErrorRate > t.UnhealthyErrorRate:
+			degrade(HealthUnhealthy, fmt.Sprintf("error rate %.1f%% over last %.0fs exceeds unhealthy threshold %.1f%% (%d/%d requests)",
+				resp.ErrorRate*100, resp.WindowSeconds, t.UnhealthyErrorRate*100, errors, requests))
+		case resp.ErrorRate > t.DegradedErrorRate:
+			degrade(HealthDegraded, fmt.Sprintf("error rate %.1f%% over last %.0fs exceeds degraded threshold %.1f%% (%d/%d requests)",
+				resp.ErrorRate*100, resp.WindowSeconds, t.DegradedErrorRate*100, errors, requests))
+		}
+	}
+
+	status := http.StatusOK
+	if resp.Status == HealthUnhealthy {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
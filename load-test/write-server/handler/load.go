@@ -2,7 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
+	"write-server/audit"
 	"write-server/load"
 	"write-server/metrics"
 )
@@ -10,27 +14,35 @@ import (
 type LoadHandler struct {
 	generator *load.Generator
 	collector *metrics.Collector
+	auditLog  *audit.Logger
 }
 
-func NewLoadHandler(generator *load.Generator, collector *metrics.Collector) *LoadHandler {
+func NewLoadHandler(generator *load.Generator, collector *metrics.Collector, auditLog *audit.Logger) *LoadHandler {
 	return &LoadHandler{
 		generator: generator,
 		collector: collector,
+		auditLog:  auditLog,
 	}
 }
 
 // POST /load/start - 부하 생성 시작
 func (h *LoadHandler) Start(w http.ResponseWriter, r *http.Request) {
 	if h.generator.IsRunning() {
-		http.Error(w, "Load generator is already running", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, "Load generator is already running")
 		return
 	}
 
 	if err := h.generator.Start(); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	h.auditLog.Record(audit.Event{
+		Action: "start",
+		Remote: r.RemoteAddr,
+		After:  *h.generator.GetConfig(),
+	})
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{
 		"status":  "started",
@@ -38,19 +50,34 @@ func (h *LoadHandler) Start(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// POST /load/stop - 부하 생성 중지
+// POST /load/stop - 부하 생성 중지 (진행 중인 쿼리는 취소, 응답에 최종 메트릭 포함, ?force=true 시 긴급 중지로 기록)
 func (h *LoadHandler) Stop(w http.ResponseWriter, r *http.Request) {
 	if !h.generator.IsRunning() {
-		http.Error(w, "Load generator is not running", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, "Load generator is not running")
 		return
 	}
 
-	h.generator.Stop()
+	force := r.URL.Query().Get("force") == "true"
+	action := "stop"
+	var finalMetrics metrics.Metrics
+	if force {
+		action = "force_stop"
+		finalMetrics = h.generator.ForceStop()
+	} else {
+		finalMetrics = h.generator.Stop()
+	}
+
+	h.auditLog.Record(audit.Event{
+		Action: action,
+		Remote: r.RemoteAddr,
+		After:  *h.generator.GetConfig(),
+	})
 
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{
+	json.NewEncoder(w).Encode(map[string]interface{}{
 		"status":  "stopped",
 		"message": "Load generation stopped successfully",
+		"metrics": finalMetrics,
 	})
 }
 
@@ -68,26 +95,34 @@ func (h *LoadHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
 // POST /load/config - 부하 설정 변경
 func (h *LoadHandler) UpdateConfig(w http.ResponseWriter, r *http.Request) {
 	if h.generator.IsRunning() {
-		http.Error(w, "Cannot update config while generator is running. Stop it first.", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, "Cannot update config while generator is running. Stop it first.")
 		return
 	}
 
 	var config load.Config
-	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := load.DecodeConfig(r.Body, &config); err != nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
 		return
 	}
 
 	if err := config.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	before := *h.generator.GetConfig()
 	if err := h.generator.UpdateConfig(&config); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	h.auditLog.Record(audit.Event{
+		Action: "config",
+		Remote: r.RemoteAddr,
+		Before: before,
+		After:  config,
+	})
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"status": "updated",
@@ -95,13 +130,131 @@ func (h *LoadHandler) UpdateConfig(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// POST /load/config/profile?name=heavy - 이름이 지정된 프로파일로 부하 설정 변경
+func (h *LoadHandler) UpdateConfigFromProfile(w http.ResponseWriter, r *http.Request) {
+	if h.generator.IsRunning() {
+		writeError(w, r, http.StatusBadRequest, "Cannot update config while generator is running. Stop it first.")
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		writeError(w, r, http.StatusBadRequest, "Query parameter 'name' is required")
+		return
+	}
+
+	config, err := load.ProfileConfig(name)
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err := config.Validate(); err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	before := *h.generator.GetConfig()
+	if err := h.generator.UpdateConfig(config); err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.auditLog.Record(audit.Event{
+		Action: "config_profile",
+		Remote: r.RemoteAddr,
+		Detail: name,
+		Before: before,
+		After:  *config,
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "updated",
+		"profile": name,
+		"config":  config,
+	})
+}
+
 // GET /load/status - 부하 생성 상태 조회
 func (h *LoadHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	status := map[string]interface{}{
 		"running": h.generator.IsRunning(),
 		"config":  h.generator.GetConfig(),
 		"metrics": h.collector.GetMetrics(),
+	}
+	if comparison := h.generator.IsolationComparison(); comparison != nil {
+		status["isolation_comparison"] = comparison
+	}
+	if connComparison := h.generator.ConnComparison(); connComparison != nil {
+		status["conn_comparison"] = connComparison
+	}
+	if convergence := h.generator.Convergence(); convergence != nil {
+		status["convergence"] = convergence
+	}
+	if failFast := h.generator.FailFast(); failFast != nil {
+		status["fail_fast"] = failFast
+	}
+	if chaos := h.generator.Chaos(); chaos != nil {
+		status["chaos"] = chaos
+	}
+	if dutyCycle := h.generator.DutyCycle(); dutyCycle != nil {
+		status["duty_cycle"] = dutyCycle
+	}
+	if held := h.generator.HeldTransactions(); held != nil {
+		status["held_transactions"] = held
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// GET /load/status/history - 최근 상태 샘플 조회 (?since=RFC3339 시각 이후만)
+func (h *LoadHandler) GetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if s := r.URL.Query().Get("since"); s != "" {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "Invalid since parameter (RFC 3339 timestamp expected)")
+			return
+		}
+		since = t
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.generator.StatusHistory(since))
+}
+
+// POST /load/tps - 실행 중인 부하의 목표 TPS 변경 (본문: {"tps": 2000}, 0 = 무제한). 워커와 연결 풀은 그대로 유지
+func (h *LoadHandler) SetTPS(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		TPS *int `json:"tps"`
+	}
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&body); err != nil || body.TPS == nil {
+		writeError(w, r, http.StatusBadRequest, `Invalid request body: expected {"tps": <int>}`)
+		return
+	}
+
+	previous, err := h.generator.SetTPS(*body.TPS)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.auditLog.Record(audit.Event{
+		Action: "tps",
+		Remote: r.RemoteAddr,
+		Detail: fmt.Sprintf("%d -> %d", previous, *body.TPS),
+	})
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":      "updated",
+		"previous":    previous,
+		"tps":         *body.TPS,
+		"target_rate": h.generator.TargetRate(),
 	})
 }
 
@@ -113,10 +266,11 @@ func (h *LoadHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(metrics)
 }
 
-// POST /metrics/reset - 메트릭 초기화
+// POST /metrics/reset - 메트릭 초기화 (?force=true 시 부하 생성 중에도 초기화, 예: 워밍업 제외)
 func (h *LoadHandler) ResetMetrics(w http.ResponseWriter, r *http.Request) {
-	if h.generator.IsRunning() {
-		http.Error(w, "Cannot reset metrics while generator is running", http.StatusBadRequest)
+	force := r.URL.Query().Get("force") == "true"
+	if h.generator.IsRunning() && !force {
+		writeError(w, r, http.StatusBadRequest, "Cannot reset metrics while generator is running (use ?force=true to reset mid-run)")
 		return
 	}
 
@@ -128,3 +282,68 @@ func (h *LoadHandler) ResetMetrics(w http.ResponseWriter, r *http.Request) {
 		"message": "Metrics reset successfully",
 	})
 }
+
+// POST /metrics/baseline - 현재 지연시간 샘플을 분포 비교 기준(A)으로 저장
+func (h *LoadHandler) SaveBaseline(w http.ResponseWriter, r *http.Request) {
+	samples := h.collector.SaveBaseline()
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "saved",
+		"samples": samples,
+	})
+}
+
+// GET /metrics/heatmap - 시간 버킷 × 지연시간 버킷 요청 수 (히트맵용)
+func (h *LoadHandler) GetHeatmap(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.collector.GetHeatmap())
+}
+
+// GET /metrics/compare/stats - 저장된 기준(A)과 현재 실행(B)의 지연시간 분포 유의성 검정
+func (h *LoadHandler) CompareStats(w http.ResponseWriter, r *http.Request) {
+	result, savedAt, err := h.collector.CompareWithBaseline()
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"baseline_saved_at": savedAt,
+		"comparison":        result,
+	})
+}
+
+// maxRunImportBody는 POST /metrics/compare/import 본문의 최대 크기입니다 (상태 이력 300개와 라벨별 메트릭에 충분).
+const maxRunImportBody = 8 << 20
+
+// GET /load/run/export - 설정, 메트릭, 에러 분류, 상태 이력을 하나의 JSON 문서로 내보냄 (공유/버그 리포트용)
+func (h *LoadHandler) ExportRun(w http.ResponseWriter, r *http.Request) {
+	export := h.generator.ExportRun()
+
+	filename := fmt.Sprintf("%s-run-%s.json", export.Server, export.ExportedAt.UTC().Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(export)
+}
+
+// POST /metrics/compare/import - 내보낸 실행 문서(A)와 현재 실행(B)의 주요 지표와 설정 비교
+func (h *LoadHandler) CompareImport(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRunImportBody)
+	imported, err := load.DecodeRunExport(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body exceeds %d bytes", maxBytesErr.Limit))
+			return
+		}
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid export: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(load.CompareRuns(*imported, h.generator.ExportRun()))
+}
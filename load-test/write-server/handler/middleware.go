@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+	"write-server/metrics"
+
+	"github.com/gorilla/mux"
+)
+
+// statusRecorder는 핸들러가 쓴 HTTP 상태 코드를 기록합니다.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// HTTPMetricsMiddleware는 라우트별 요청 수와 처리 시간을 httpMetrics에 기록하는 mux 미들웨어를 반환합니다.
+// 경로는 실제 URL이 아닌 라우트 템플릿(예: /logs/search)으로 묶습니다.
+func HTTPMetricsMiddleware(httpMetrics *metrics.HTTPMetrics) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			path := r.URL.Path
+			if route := mux.CurrentRoute(r); route != nil {
+				if tmpl, err := route.GetPathTemplate(); err == nil {
+					path = tmpl
+				}
+			}
+			httpMetrics.Observe(r.Method, path, rec.status, time.Since(start))
+		})
+	}
+}
+
+// GET /metrics/http - HTTP 라우트별 요청 수/처리 시간 조회
+func HTTPMetricsHandler(httpMetrics *metrics.HTTPMetrics) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"routes": httpMetrics.Snapshot(),
+		})
+	}
+}
@@ -3,22 +3,85 @@ package handler
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 	"write-server/metrics"
 )
 
+// 쓰기 API 메트릭 라벨 (부하 생성기의 배치 라벨과 구분)
+const (
+	labelInsertLog   = "api_logs"
+	labelInsertBatch = "api_logs_batch"
+)
+
+// 배치 INSERT 기본 제한
+const (
+	DefaultMaxBodyBytes     = 10 << 20 // 요청 본문 최대 크기 (10MB)
+	DefaultMaxBatchRows     = 1000     // 배치 한 번의 최대 행 수
+	DefaultBatchChunkSize   = 500      // 이보다 큰 배치는 이 크기씩 나눠 별도 트랜잭션으로 INSERT
+	DefaultBatchParallelism = 1        // 청크를 동시에 INSERT하는 고루틴 수 (1 = 순서대로)
+	MaxBatchParallelism     = 16       // 요청 하나가 동시에 쓰는 연결 수 상한 (풀 최대 50의 일부만 사용)
+)
+
+// BatchLimits는 쓰기 API의 요청 크기 제한입니다. 0 이하 값은 기본값을 사용합니다.
+type BatchLimits struct {
+	MaxBodyBytes int64 `json:"max_body_bytes"`
+	MaxRows      int   `json:"max_batch_rows"`
+	ChunkSize    int   `json:"batch_chunk_size"`
+	Parallelism  int   `json:"batch_parallelism"`
+}
+
 type WriteHandler struct {
 	db        *sql.DB
 	collector *metrics.Collector
+	limits    BatchLimits
 }
 
-func NewWriteHandler(db *sql.DB, collector *metrics.Collector) *WriteHandler {
+func NewWriteHandler(db *sql.DB, collector *metrics.Collector, limits BatchLimits) *WriteHandler {
+	if limits.MaxBodyBytes <= 0 {
+		limits.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if limits.MaxRows <= 0 {
+		limits.MaxRows = DefaultMaxBatchRows
+	}
+	if limits.ChunkSize <= 0 {
+		limits.ChunkSize = DefaultBatchChunkSize
+	}
+	if limits.Parallelism <= 0 {
+		limits.Parallelism = DefaultBatchParallelism
+	}
+	if limits.Parallelism > MaxBatchParallelism {
+		limits.Parallelism = MaxBatchParallelism
+	}
 	return &WriteHandler{
 		db:        db,
 		collector: collector,
+		limits:    limits,
+	}
+}
+
+// Limits는 기본값이 적용된 실제 제한을 반환합니다.
+func (h *WriteHandler) Limits() BatchLimits {
+	return h.limits
+}
+
+// decodeBody는 본문을 MaxBodyBytes까지만 읽어 v로 디코딩합니다.
+// 크기를 넘으면 413, 잘못된 JSON이면 400을 응답하고 false를 반환합니다.
+func (h *WriteHandler) decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, h.limits.MaxBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body exceeds %d bytes", maxBytesErr.Limit))
+			return false
+		}
+		writeError(w, r, http.StatusBadRequest, "Invalid request body")
+		return false
 	}
+	return true
 }
 
 type LogEntry struct {
@@ -28,15 +91,44 @@ type LogEntry struct {
 	Metadata string `json:"metadata"`
 }
 
+// size는 로그 한 건의 쓰기 크기를 필드 길이 합으로 추정합니다.
+func (l LogEntry) size() int64 {
+	return int64(len(l.Level) + len(l.Service) + len(l.Message) + len(l.Metadata))
+}
+
 type BatchLogRequest struct {
 	Logs []LogEntry `json:"logs"`
 }
 
+// 청크 처리 결과 (ChunkResult.Status)
+const (
+	ChunkCommitted = "committed" // 커밋됨
+	ChunkFailed    = "failed"    // INSERT 또는 커밋 실패 (롤백됨)
+	ChunkSkipped   = "skipped"   // 다른 청크가 실패해 시작하지 않음
+)
+
+// ChunkResult는 /logs/batch에서 나눠 INSERT한 청크 하나의 결과입니다.
+type ChunkResult struct {
+	Index     int     `json:"index"`  // 요청 안에서의 청크 순서 (0부터)
+	Offset    int     `json:"offset"` // 청크 첫 로그의 요청 내 위치
+	Rows      int     `json:"rows"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms,omitempty"` // 트랜잭션 시작부터 커밋(또는 실패)까지
+	Error     string  `json:"error,omitempty"`
+}
+
+// BatchErrorResponse는 청크 일부가 실패한 /logs/batch의 500 응답 본문입니다.
+// 이미 커밋된 청크는 롤백되지 않으므로, 클라이언트는 chunk_results에서 failed/skipped 청크만 다시 보내면 됩니다.
+type BatchErrorResponse struct {
+	ErrorResponse
+	Inserted     int           `json:"inserted"`
+	ChunkResults []ChunkResult `json:"chunk_results"`
+}
+
 // POST /logs - 단일 로그 INSERT
 func (h *WriteHandler) InsertLog(w http.ResponseWriter, r *http.Request) {
 	var log LogEntry
-	if err := json.NewDecoder(r.Body).Decode(&log); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !h.decodeBody(w, r, &log) {
 		return
 	}
 
@@ -51,12 +143,12 @@ func (h *WriteHandler) InsertLog(w http.ResponseWriter, r *http.Request) {
 	latency := time.Since(start)
 
 	if err != nil {
-		h.collector.RecordFailure(1)
-		http.Error(w, fmt.Sprintf("Failed to insert log: %v", err), http.StatusInternalServerError)
+		h.collector.RecordFailureWithError(labelInsertLog, latency, 1, err)
+		writeError(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to insert log: %v", err))
 		return
 	}
 
-	h.collector.RecordSuccess(latency, 1)
+	h.collector.RecordSuccess(labelInsertLog, latency, 1, log.size())
 
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]string{
@@ -65,33 +157,141 @@ func (h *WriteHandler) InsertLog(w http.ResponseWriter, r *http.Request) {
 }
 
 // POST /logs/batch - 배치 로그 INSERT
+// MaxRows를 넘는 배치는 400으로 거부하고, ChunkSize보다 큰 배치는 ChunkSize씩 나눠 별도 트랜잭션으로 INSERT합니다.
+// Parallelism이 1보다 크면 청크를 그 수만큼의 고루틴이 동시에 INSERT합니다.
+// 나눠 INSERT하다 실패하면 앞서(또는 동시에) 커밋된 청크는 그대로 남고, 아직 시작하지 않은 청크는 건너뜁니다
+// (응답의 chunk_results에 청크별 결과 포함).
 func (h *WriteHandler) InsertBatchLogs(w http.ResponseWriter, r *http.Request) {
 	var req BatchLogRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !h.decodeBody(w, r, &req) {
 		return
 	}
 
 	if len(req.Logs) == 0 {
-		http.Error(w, "Empty logs array", http.StatusBadRequest)
+		writeError(w, r, http.StatusBadRequest, "Empty logs array")
+		return
+	}
+	if len(req.Logs) > h.limits.MaxRows {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("Batch has %d logs, max is %d", len(req.Logs), h.limits.MaxRows))
 		return
 	}
 
+	results, firstErr := h.insertChunks(req.Logs)
+
+	inserted := 0
+	for _, result := range results {
+		if result.Status == ChunkCommitted {
+			inserted += result.Rows
+		}
+	}
+
+	if firstErr != nil {
+		status := http.StatusInternalServerError
+		writeErrorBody(w, r, status, BatchErrorResponse{
+			ErrorResponse: errorResponse(r, status, fmt.Sprintf("Failed to insert logs (%d already committed): %v", inserted, firstErr)),
+			Inserted:      inserted,
+			ChunkResults:  results,
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":        "success",
+		"inserted":      inserted,
+		"chunks":        len(results),
+		"chunk_results": results,
+	})
+}
+
+// insertChunks는 logs를 ChunkSize씩 나눠 청크마다 별도 트랜잭션으로 INSERT하고 청크별 결과와 첫 에러를 반환합니다.
+// 청크는 최대 Parallelism개의 고루틴이 앞에서부터 하나씩 가져가 실행합니다 (1이면 순서대로).
+// 한 청크가 실패하면 그 뒤로는 새 청크를 시작하지 않으며, 이미 실행 중인 청크는 끝까지 실행됩니다.
+func (h *WriteHandler) insertChunks(logs []LogEntry) ([]ChunkResult, error) {
+	var results []ChunkResult
+	for offset := 0; offset < len(logs); offset += h.limits.ChunkSize {
+		end := offset + h.limits.ChunkSize
+		if end > len(logs) {
+			end = len(logs)
+		}
+		results = append(results, ChunkResult{Index: len(results), Offset: offset, Rows: end - offset, Status: ChunkSkipped})
+	}
+
+	workers := h.limits.Parallelism
+	if workers > len(results) {
+		workers = len(results)
+	}
+
+	var (
+		mu       sync.Mutex
+		next     int
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	// take는 다음에 실행할 청크 번호를 반환합니다. 남은 청크가 없거나 실패한 청크가 있으면 false입니다.
+	take := func() (int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr != nil || next >= len(results) {
+			return 0, false
+		}
+		next++
+		return next - 1, true
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				idx, ok := take()
+				if !ok {
+					return
+				}
+				result := &results[idx]
+				chunk := logs[result.Offset : result.Offset+result.Rows]
+
+				start := time.Now()
+				err := h.insertChunk(chunk)
+				elapsed := time.Since(start)
+				result.LatencyMs = float64(elapsed) / float64(time.Millisecond)
+				if err == nil {
+					result.Status = ChunkCommitted
+					continue
+				}
+
+				h.collector.RecordFailureWithError(labelInsertBatch, elapsed, len(chunk), err)
+				result.Status = ChunkFailed
+				result.Error = err.Error()
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results, firstErr
+}
+
+// insertChunk는 logs를 하나의 트랜잭션에서 배치 INSERT하고 성공 메트릭을 기록합니다.
+func (h *WriteHandler) insertChunk(logs []LogEntry) error {
 	start := time.Now()
 
 	tx, err := h.db.Begin()
 	if err != nil {
-		h.collector.RecordFailure(len(req.Logs))
-		http.Error(w, fmt.Sprintf("Failed to begin transaction: %v", err), http.StatusInternalServerError)
-		return
+		return fmt.Errorf("begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	// 배치 INSERT 쿼리 생성
 	query := "INSERT INTO logs (level, service, message, metadata) VALUES "
-	args := make([]interface{}, 0, len(req.Logs)*4)
+	args := make([]interface{}, 0, len(logs)*4)
+	var bytes int64
 
-	for i, log := range req.Logs {
+	for i, log := range logs {
 		if i > 0 {
 			query += ", "
 		}
@@ -99,27 +299,17 @@ func (h *WriteHandler) InsertBatchLogs(w http.ResponseWriter, r *http.Request) {
 		query += fmt.Sprintf("($%d, $%d, $%d, $%d)", offset+1, offset+2, offset+3, offset+4)
 
 		args = append(args, log.Level, log.Service, log.Message, log.Metadata)
+		bytes += log.size()
 	}
 
-	_, err = tx.Exec(query, args...)
-	if err != nil {
-		h.collector.RecordFailure(len(req.Logs))
-		http.Error(w, fmt.Sprintf("Failed to insert logs: %v", err), http.StatusInternalServerError)
-		return
+	if _, err := tx.Exec(query, args...); err != nil {
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
-		h.collector.RecordFailure(len(req.Logs))
-		http.Error(w, fmt.Sprintf("Failed to commit transaction: %v", err), http.StatusInternalServerError)
-		return
+		return fmt.Errorf("commit transaction: %w", err)
 	}
 
-	latency := time.Since(start)
-	h.collector.RecordSuccess(latency, len(req.Logs))
-
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":   "success",
-		"inserted": len(req.Logs),
-	})
+	h.collector.RecordSuccess(labelInsertBatch, time.Since(start), len(logs), bytes)
+	return nil
 }
@@ -0,0 +1,179 @@
+package load
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// 컬럼 값 생성기 종류 (ColumnGenerator.Type)
+const (
+	GeneratorInt       = "int"       // Min~Max(포함) 사이의 정수
+	GeneratorString    = "string"    // 길이 Length의 영숫자 문자열
+	GeneratorTimestamp = "timestamp" // 현재 시각 (Spread > 0이면 현재부터 Spread 이전 사이에서 무작위)
+	GeneratorEnum      = "enum"      // Values 중 하나
+	GeneratorJSON      = "json"      // 키 Keys개의 JSON 객체 ({"k1": 정수, ...})
+)
+
+// 생성기 기본값과 한도
+const (
+	DefaultStringLength = 16
+	MaxStringLength     = 1 << 20 // 1MiB
+	DefaultJSONKeys     = 3
+	MaxJSONKeys         = 100
+)
+
+// columnNamePattern은 컬럼 이름 형식입니다 (소문자 식별자). 따옴표로 감싸 인라인합니다.
+var columnNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// alphanumeric은 GeneratorString이 쓰는 문자입니다.
+const alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// ColumnGenerator는 Config.Columns의 같은 위치 컬럼에 넣을 값을 만드는 방법입니다.
+// Type에 해당하는 필드만 사용하고 나머지는 무시합니다.
+type ColumnGenerator struct {
+	Type   string        `json:"type"`
+	Min    int64         `json:"min,omitempty"`    // int (Min과 Max가 모두 0이면 0~MaxInt32)
+	Max    int64         `json:"max,omitempty"`    // int
+	Length int           `json:"length,omitempty"` // string (기본 16)
+	Values []string      `json:"values,omitempty"` // enum
+	Spread time.Duration `json:"spread,omitempty"` // timestamp (0 = 현재 시각)
+	Keys   int           `json:"keys,omitempty"`   // json (기본 3)
+}
+
+// validate는 생성기 설정을 검증하고 기본값을 채웁니다.
+func (cg *ColumnGenerator) validate() error {
+	switch cg.Type {
+	case GeneratorInt:
+		if cg.Min == 0 && cg.Max == 0 {
+			cg.Max = math.MaxInt32
+		}
+		if cg.Max < cg.Min {
+			return fmt.Errorf("max (%d) must be at least min (%d)", cg.Max, cg.Min)
+		}
+		if cg.Max-cg.Min < 0 || cg.Max-cg.Min == math.MaxInt64 {
+			return fmt.Errorf("range %d..%d is too large", cg.Min, cg.Max)
+		}
+	case GeneratorString:
+		if cg.Length == 0 {
+			cg.Length = DefaultStringLength
+		}
+		if cg.Length < 0 || cg.Length > MaxStringLength {
+			return fmt.Errorf("length must be between 1 and %d, got %d", MaxStringLength, cg.Length)
+		}
+	case GeneratorTimestamp:
+		if cg.Spread < 0 {
+			cg.Spread = 0
+		}
+	case GeneratorEnum:
+		if len(cg.Values) == 0 {
+			return fmt.Errorf("enum requires at least one value")
+		}
+	case GeneratorJSON:
+		if cg.Keys == 0 {
+			cg.Keys = DefaultJSONKeys
+		}
+		if cg.Keys < 0 || cg.Keys > MaxJSONKeys {
+			return fmt.Errorf("keys must be between 1 and %d, got %d", MaxJSONKeys, cg.Keys)
+		}
+	default:
+		return fmt.Errorf("unknown type %q (must be %s, %s, %s, %s or %s)",
+			cg.Type, GeneratorInt, GeneratorString, GeneratorTimestamp, GeneratorEnum, GeneratorJSON)
+	}
+	return nil
+}
+
+// value는 값 하나와 그 추정 쓰기 크기(바이트)를 반환합니다. 크기는 rowBytes처럼 저렴한 근사치입니다.
+func (cg *ColumnGenerator) value(now time.Time) (interface{}, int64) {
+	switch cg.Type {
+	case GeneratorInt:
+		return cg.Min + rand.Int63n(cg.Max-cg.Min+1), 8
+	case GeneratorString:
+		b := make([]byte, cg.Length)
+		for i := range b {
+			b[i] = alphanumeric[rand.Intn(len(alphanumeric))]
+		}
+		return string(b), int64(len(b))
+	case GeneratorTimestamp:
+		if cg.Spread > 0 {
+			return randomTimestamp(now, cg.Spread), 8
+		}
+		return now, 8
+	case GeneratorEnum:
+		v := cg.Values[rand.Intn(len(cg.Values))]
+		return v, int64(len(v))
+	default: // GeneratorJSON
+		var b strings.Builder
+		b.WriteByte('{')
+		for i := 1; i <= cg.Keys; i++ {
+			if i > 1 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, `"k%d": %d`, i, rand.Intn(1000000))
+		}
+		b.WriteByte('}')
+		return b.String(), int64(b.Len())
+	}
+}
+
+// validateColumns는 Columns와 ColumnGenerators를 검증합니다. 두 목록은 같은 위치끼리 짝을 이룹니다.
+func (c *Config) validateColumns() error {
+	if len(c.Columns) != len(c.ColumnGenerators) {
+		return fmt.Errorf("columns has %d entries but column_generators has %d", len(c.Columns), len(c.ColumnGenerators))
+	}
+	if len(c.Columns) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(c.Columns))
+	for i, column := range c.Columns {
+		if !columnNamePattern.MatchString(column) {
+			return fmt.Errorf("columns: %q is not a valid column name (lowercase identifier)", column)
+		}
+		if seen[column] {
+			return fmt.Errorf("columns: %q is listed more than once", column)
+		}
+		seen[column] = true
+		if err := c.ColumnGenerators[i].validate(); err != nil {
+			return fmt.Errorf("column_generators[%d] (%s): %w", i, column, err)
+		}
+	}
+
+	// 아래 모드는 logs 테이블의 컬럼(level, service, message, metadata, timestamp)을 전제로 함
+	switch {
+	case len(c.Tables) == 0:
+		return fmt.Errorf("columns requires tables (the target tables for the custom columns)")
+	case c.ReadYourWrites:
+		return fmt.Errorf("columns cannot be combined with read_your_writes")
+	case c.ReplayFile != "":
+		return fmt.Errorf("columns cannot be combined with replay_file")
+	case c.TimestampSpread > 0:
+		return fmt.Errorf("columns cannot be combined with timestamp_spread (use a timestamp generator with spread)")
+	}
+	return nil
+}
+
+// customColumns는 Columns를 인용해 INSERT 컬럼 목록으로 만듭니다.
+func customColumns(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = pq.QuoteIdentifier(column)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// appendColumnValues는 Columns 한 행의 값을 args에 붙이고 추정 쓰기 크기를 반환합니다.
+func (c *Config) appendColumnValues(args []interface{}, now time.Time) ([]interface{}, int64) {
+	var bytes int64
+	for i := range c.ColumnGenerators {
+		v, n := c.ColumnGenerators[i].value(now)
+		args = append(args, v)
+		bytes += n
+	}
+	return args, bytes
+}
@@ -1,7 +1,10 @@
 package load
 
 import (
+	"fmt"
+	"net/url"
 	"time"
+	"write-server/metrics"
 )
 
 type Config struct {
@@ -10,15 +13,125 @@ type Config struct {
 	Workers        int           `json:"workers"`         // 동시 워커 수
 	Duration       time.Duration `json:"duration"`        // 테스트 지속 시간 (0 = 무제한)
 	IsolationLevel string        `json:"isolation_level"` // READ COMMITTED, REPEATABLE READ, SERIALIZABLE
+
+	// 쿼리 타임아웃 (0 = 무제한). 초과하면 쿼리를 취소하고 실패가 아닌 타임아웃으로 집계
+	QueryTimeout time.Duration `json:"query_timeout"`
+
+	// 워커 전용 연결 모드: 각 워커가 풀에서 연결 하나를 꺼내 실행이 끝날 때까지 고정 사용 (연결 획득 경합 제거)
+	// 워커 수가 풀의 최대 연결 수(max_open_conns, 기본값 50)를 넘으면 남는 워커는 연결을 얻지 못해 대기합니다.
+	DedicatedConns bool `json:"dedicated_conns"`
+
+	// 연결 풀 설정: Start할 때 부하 생성기의 풀에 적용 (0 = 서버 기본값, 즉 50 / DB_MAX_IDLE_CONNS / DB_CONN_MAX_LIFETIME)
+	// 실행 중에는 바꿀 수 없으며(SIGHUP 리로드도 거부), 풀은 서버의 다른 요청과 공유하므로 실행이 끝나면 서버 기본값으로 되돌립니다 (pool.go)
+	MaxOpenConns    int           `json:"max_open_conns"`
+	MaxIdleConns    int           `json:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
+
+	// 연결 방식 비교 모드: 각 워커가 전용 연결 하나를 잡아 두고, 트랜잭션마다 공유 풀(db.BeginTx)과
+	// 전용 연결을 번갈아 사용해 트랜잭션 준비/커밋 오버헤드 차이를 측정 (/load/status의 conn_comparison)
+	CompareConns bool `json:"compare_conns"`
+
+	// 워커 시작 간격: i번째 워커를 i × WorkerStartStagger 뒤에 시작 (0 = 모든 워커 동시 시작)
+	// 시작 직후 모든 워커가 한꺼번에 연결을 만드는 연결 폭주를 완화합니다.
+	WorkerStartStagger time.Duration `json:"worker_start_stagger"`
+
+	// 램프업: 워커를 RampUp 구간에 고르게 나눠 시작 (i번째 워커는 RampUp × i / Workers 뒤, 0 = 사용 안 함)
+	// 워커 수와 상관없이 RampUp이 지나면 모든 워커가 실행 중입니다. WorkerStartStagger와 함께 쓸 수 없으며,
+	// WarmupExclude를 지정하지 않으면 램프업 구간에 시작한 요청을 워밍업으로 보고 주 백분위수에서 제외합니다.
+	RampUp time.Duration `json:"ramp_up"`
+
+	// Start 시 메트릭 초기화 여부 (생략 시 true). false면 여러 번의 짧은 실행을 하나의 메트릭으로 누적
+	ResetOnStart *bool `json:"reset_on_start,omitempty"`
+
+	// 실행 종료(자동/수동) 시 최종 메트릭을 POST할 URL (빈 값 = 사용 안 함)
+	CompletionWebhook string `json:"completion_webhook,omitempty"`
+
+	// 격리 수준 비교 모드: 한 실행 안에서 세 격리 수준을 IsolationSlice마다 번갈아 실행 (IsolationLevel 무시)
+	CompareIsolation bool          `json:"compare_isolation"`
+	IsolationSlice   time.Duration `json:"isolation_slice"` // 격리 수준 하나를 유지하는 시간 (0 = 10초)
+	// 격리 수준을 바꾸는 단위: "slice"(IsolationSlice마다, 기본) 또는 "transaction"(트랜잭션마다 순환)
+	IsolationRotation string `json:"isolation_rotation,omitempty"`
+
+	// 과거 시각 분산: 0보다 크면 각 행의 timestamp를 현재부터 이 기간 이전 사이에서 무작위로 지정 (0 = NOW())
+	// 시간 범위 조회가 최근 데이터만이 아니라 여러 나이의 데이터를 대상으로 하도록 백필할 때 사용
+	TimestampSpread time.Duration `json:"timestamp_spread"`
+
+	// 정상 상태 모드: 배치마다 같은 수의 가장 오래된 행을 삭제해 테이블 크기를 일정하게 유지 (보존 기간이 있는 로그 테이블 모델)
+	SteadyState bool `json:"steady_state"`
+
+	// read-your-writes 검증 모드: 1건씩 INSERT한 직후 id로 다시 읽어 보이는지 확인 (BatchSize 무시)
+	ReadYourWrites bool `json:"read_your_writes"`
+
+	// 커밋 내구성: 트랜잭션마다 SET LOCAL synchronous_commit = on|off (빈 값 = 서버 설정을 그대로 사용)
+	// off면 커밋이 WAL flush를 기다리지 않아 처리량이 크게 늘지만, 서버 장애 시 최근 커밋을 잃을 수 있음
+	SynchronousCommit string `json:"synchronous_commit"`
+
+	// 여러 테이블 모드: logs 대신 이 테이블들에 배치 단위로 나눠 INSERT (비어 있으면 logs 테이블)
+	// Columns가 없으면 각 테이블은 logs와 같은 컬럼이 있어야 하며(예: CREATE TABLE logs_1 (LIKE logs INCLUDING ALL)), 파티션 테이블도 가능
+	Tables         []string `json:"tables,omitempty"`
+	TableSelection string   `json:"table_selection,omitempty"` // "round_robin"(기본) 또는 "random"
+
+	// ON CONFLICT 모드: id를 [2^40, 2^40+KeySpace) 범위에서 직접 골라 INSERT해 이미 있는 행과 충돌하게 함 (upsert 부하)
+	// "nothing"이면 ON CONFLICT DO NOTHING, "update"면 DO UPDATE (빈 값 = 사용 안 함). 충돌한 행 수는 메트릭의 conflicts
+	OnConflict string `json:"on_conflict,omitempty"`
+	KeySpace   int64  `json:"key_space"` // id 공간 크기, 작을수록 충돌이 잦음 (0 = 100000, batch_size 이상)
+
+	// 임의 스키마 모드: logs 컬럼 대신 Columns에 ColumnGenerators(같은 위치끼리 짝)로 만든 값을 INSERT (Tables 필요)
+	Columns          []string          `json:"columns,omitempty"`
+	ColumnGenerators []ColumnGenerator `json:"column_generators,omitempty"`
+
+	// 수렴 감지: ConvergenceInterval마다 누적 p95를 확인해, ConvergenceWindow번 연속으로
+	// 기준값의 ±ConvergenceTolerance 안에 머물면 수렴으로 표시 (/load/status의 convergence)
+	ConvergenceInterval  time.Duration `json:"convergence_interval"`  // 확인 간격 (0 = 사용 안 함)
+	ConvergenceTolerance float64       `json:"convergence_tolerance"` // 허용 변동 비율 (0 = 0.05, 즉 ±5%)
+	ConvergenceWindow    int           `json:"convergence_window"`    // 필요한 연속 구간 수 (0 = 5)
+	StopOnConvergence    bool          `json:"stop_on_convergence"`   // 수렴하면 자동 종료
+
+	// 조기 중단: 최근 ErrorRateWindow 동안의 에러율((실패 + 타임아웃 + 연결 끊김) / 전체)이 MaxErrorRate를 넘으면
+	// 실행을 중지하고 실패로 표시 (/load/status의 fail_fast). CI에서 이미 실패한 실행에 시간을 쓰지 않기 위함
+	MaxErrorRate    float64       `json:"max_error_rate"`    // 허용 에러율 0~1 (0 = 사용 안 함)
+	ErrorRateWindow time.Duration `json:"error_rate_window"` // 에러율 계산 윈도우 (0 = 10초)
+
+	// 지연시간 샘플링: 성공한 요청 중 이 비율만 지연시간을 기록 (0 < rate <= 1, 0 = 1)
+	// 요청 수/처리율은 항상 정확하고, 백분위수는 표본 기준, 히스토그램/히트맵 건수는 1/rate 배로 보정
+	LatencySampleRate float64 `json:"latency_sample_rate"`
+
+	// 보고할 지연시간 백분위수 (0 < p < 100, 최대 20개, 비어 있으면 [50, 95, 99]). /metrics의 percentiles에 "p75", "p99.9" 형식 키로 보고
+	Percentiles []float64 `json:"percentiles,omitempty"`
+
+	// 워밍업 제외: 실행 시작 후 이 시간 안에 시작한 요청의 지연시간은 주 백분위수에서 빼고
+	// /metrics의 warmup에 따로 보고 (0 = 사용 안 함). 요청 수/처리율 카운터에는 그대로 포함
+	WarmupExclude time.Duration `json:"warmup_exclude"`
+
+	// 재생 모드: 서버가 읽을 수 있는 NDJSON 파일의 로그를 파일 순서대로 BatchSize건씩 INSERT
+	// 파일 끝에 도달하면 Duration이 설정된 경우 처음부터 반복하고, 아니면 실행을 종료
+	ReplayFile   string `json:"replay_file,omitempty"`
+	ReplayTiming bool   `json:"replay_timing"` // 기록된 timestamp 간격대로 로그를 보냄 (TPS 무시)
+
+	// 연결 강제 종료(chaos): 이 간격마다 이 서버의 DB 연결 하나를 pg_terminate_backend로 종료 (0 = 사용 안 함, 최소 1초)
+	// 끊긴 연결로 실패한 요청은 connection_errors로 따로 집계하고, 워커 전용 연결은 새로 얻어 계속 실행
+	ChaosInterval time.Duration `json:"chaos_interval"`
+
+	// 트랜잭션 유지 모드: 워커와 별도로 트랜잭션 HeldTransactions개를 열어 xid를 할당받은 채 HoldTime 동안 유지(idle in transaction)한 뒤
+	// 커밋하고 다시 엶 (0 = 사용 안 함, 최대 20). 오래 열린 트랜잭션이 xmin horizon을 묶어 VACUUM이 dead tuple을 회수하지 못하는 상황을 재현
+	HeldTransactions int           `json:"held_transactions"`
+	HoldTime         time.Duration `json:"hold_time"` // 트랜잭션 하나를 열어 두는 시간 (0 = 30초)
+
+	// 듀티 사이클(버스트 부하): DutyActive 동안 목표 처리율로 부하를 생성하고 DutyIdle 동안 멈추는 것을 반복 (둘 다 0 = 사용 안 함, 각각 최소 100ms)
+	// 연결 풀이 늘었다 줄어드는 상황과 쉬는 동안 식은 캐시를 재현하며, 활성 구간별 메트릭을 /load/status의 duty_cycle로 보고
+	DutyActive time.Duration `json:"duty_active"`
+	DutyIdle   time.Duration `json:"duty_idle"`
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		TPS:            1000,
-		BatchSize:      10,
-		Workers:        5,
-		Duration:       0, // 무제한
-		IsolationLevel: "READ COMMITTED",
+		TPS:               1000,
+		BatchSize:         10,
+		Workers:           5,
+		Duration:          0, // 무제한
+		IsolationLevel:    "READ COMMITTED",
+		LatencySampleRate: 1.0,
+		Percentiles:       append([]float64(nil), metrics.DefaultPercentiles...),
 	}
 }
 
@@ -35,6 +148,161 @@ func (c *Config) Validate() error {
 	if c.Duration < 0 {
 		c.Duration = 0
 	}
+	if c.QueryTimeout < 0 {
+		c.QueryTimeout = 0
+	}
+	if c.WorkerStartStagger < 0 {
+		c.WorkerStartStagger = 0
+	}
+	if c.RampUp < 0 {
+		c.RampUp = 0
+	}
+	if c.RampUp > 0 && c.WorkerStartStagger > 0 {
+		return fmt.Errorf("ramp_up and worker_start_stagger cannot be used together")
+	}
+	if c.MaxOpenConns < 0 || c.MaxIdleConns < 0 || c.ConnMaxLifetime < 0 {
+		return fmt.Errorf("max_open_conns, max_idle_conns and conn_max_lifetime must not be negative, got %d, %d and %s", c.MaxOpenConns, c.MaxIdleConns, c.ConnMaxLifetime)
+	}
+	if c.TimestampSpread < 0 {
+		c.TimestampSpread = 0
+	}
+	if c.ResetOnStart == nil {
+		resetOnStart := true
+		c.ResetOnStart = &resetOnStart
+	}
+
+	if c.CompletionWebhook != "" {
+		u, err := url.Parse(c.CompletionWebhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("completion_webhook must be an absolute http(s) URL, got %q", c.CompletionWebhook)
+		}
+	}
+
+	if c.IsolationSlice <= 0 {
+		c.IsolationSlice = DefaultIsolationSlice
+	}
+	switch c.IsolationRotation {
+	case "":
+		c.IsolationRotation = RotationSlice
+	case RotationSlice, RotationTransaction:
+	default:
+		return fmt.Errorf("isolation_rotation must be %q or %q, got %q", RotationSlice, RotationTransaction, c.IsolationRotation)
+	}
+
+	if c.MaxErrorRate < 0 || c.MaxErrorRate > 1 {
+		return fmt.Errorf("max_error_rate must be between 0 and 1, got %g", c.MaxErrorRate)
+	}
+	if c.ErrorRateWindow <= 0 {
+		c.ErrorRateWindow = DefaultErrorRateWindow
+	}
+
+	if c.LatencySampleRate < 0 || c.LatencySampleRate > 1 {
+		return fmt.Errorf("latency_sample_rate must be between 0 and 1, got %g", c.LatencySampleRate)
+	}
+	if c.LatencySampleRate == 0 {
+		c.LatencySampleRate = 1
+	}
+	if len(c.Percentiles) == 0 {
+		c.Percentiles = append([]float64(nil), metrics.DefaultPercentiles...)
+	}
+	if len(c.Percentiles) > metrics.MaxPercentiles {
+		return fmt.Errorf("percentiles must have at most %d entries, got %d", metrics.MaxPercentiles, len(c.Percentiles))
+	}
+	requested := make(map[float64]bool, len(c.Percentiles))
+	for _, p := range c.Percentiles {
+		if !(p > 0 && p < 100) {
+			return fmt.Errorf("percentiles must be between 0 and 100 (exclusive), got %g", p)
+		}
+		if requested[p] {
+			return fmt.Errorf("percentiles contains %g more than once", p)
+		}
+		requested[p] = true
+	}
+	if c.WarmupExclude < 0 {
+		c.WarmupExclude = 0
+	}
+
+	if c.ChaosInterval < 0 {
+		c.ChaosInterval = 0
+	}
+	if c.ChaosInterval > 0 && c.ChaosInterval < MinChaosInterval {
+		return fmt.Errorf("chaos_interval must be at least %s, got %s", MinChaosInterval, c.ChaosInterval)
+	}
+
+	if c.HeldTransactions < 0 {
+		c.HeldTransactions = 0
+	}
+	if c.HeldTransactions > MaxHeldTransactions {
+		return fmt.Errorf("held_transactions must be at most %d, got %d", MaxHeldTransactions, c.HeldTransactions)
+	}
+	if c.HoldTime <= 0 {
+		c.HoldTime = DefaultHoldTime
+	}
+
+	if c.ReplayTiming && c.ReplayFile == "" {
+		return fmt.Errorf("replay_timing requires replay_file")
+	}
+	if err := c.validateDutyCycle(); err != nil {
+		return err
+	}
+	if c.ReplayFile != "" && c.ReadYourWrites {
+		return fmt.Errorf("replay_file cannot be combined with read_your_writes")
+	}
+
+	if c.CompareConns {
+		switch {
+		case c.DedicatedConns:
+			return fmt.Errorf("compare_conns cannot be combined with dedicated_conns (it alternates between both modes)")
+		case c.CompareIsolation:
+			return fmt.Errorf("compare_conns cannot be combined with compare_isolation")
+		case c.ReadYourWrites:
+			return fmt.Errorf("compare_conns cannot be combined with read_your_writes")
+		}
+	}
+
+	if c.ConvergenceInterval < 0 {
+		c.ConvergenceInterval = 0
+	}
+	if c.ConvergenceTolerance <= 0 {
+		c.ConvergenceTolerance = DefaultConvergenceTolerance
+	}
+	if c.ConvergenceWindow < 1 {
+		c.ConvergenceWindow = DefaultConvergenceWindow
+	}
+
+	seen := make(map[string]bool, len(c.Tables))
+	for _, table := range c.Tables {
+		if !tableNamePattern.MatchString(table) {
+			return fmt.Errorf("tables: %q is not a valid table name (lowercase identifier, optionally schema-qualified)", table)
+		}
+		if seen[table] {
+			return fmt.Errorf("tables: %q is listed more than once", table)
+		}
+		seen[table] = true
+	}
+	if len(c.Tables) > 0 && c.ReadYourWrites {
+		return fmt.Errorf("tables cannot be combined with read_your_writes")
+	}
+	if err := c.validateColumns(); err != nil {
+		return err
+	}
+	if err := c.validateOnConflict(); err != nil {
+		return err
+	}
+	switch c.TableSelection {
+	case "":
+		c.TableSelection = TableRoundRobin
+	case TableRoundRobin, TableRandom:
+	default:
+		return fmt.Errorf("table_selection must be %q or %q, got %q", TableRoundRobin, TableRandom, c.TableSelection)
+	}
+
+	switch c.SynchronousCommit {
+	case "", SynchronousCommitOn, SynchronousCommitOff:
+		// 유효한 값 (빈 값 = 서버 설정)
+	default:
+		return fmt.Errorf("synchronous_commit must be %q or %q, got %q", SynchronousCommitOn, SynchronousCommitOff, c.SynchronousCommit)
+	}
 
 	// 격리 수준 정규화
 	switch c.IsolationLevel {
@@ -46,3 +314,24 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+// shouldResetOnStart는 Start 시 메트릭을 초기화해야 하는지 반환합니다 (Validate 전이면 기본값 true).
+func (c *Config) shouldResetOnStart() bool {
+	return c.ResetOnStart == nil || *c.ResetOnStart
+}
+
+// workerStartDelay는 i번째 워커가 첫 요청 전에 기다리는 시간입니다 (RampUp 또는 WorkerStartStagger).
+func (c *Config) workerStartDelay(i int) time.Duration {
+	if c.RampUp > 0 {
+		return c.RampUp * time.Duration(i) / time.Duration(c.Workers)
+	}
+	return time.Duration(i) * c.WorkerStartStagger
+}
+
+// warmupWindow는 주 백분위수에서 제외할 워밍업 구간입니다. WarmupExclude를 지정하지 않았으면 램프업 구간입니다.
+func (c *Config) warmupWindow() time.Duration {
+	if c.WarmupExclude > 0 {
+		return c.WarmupExclude
+	}
+	return c.RampUp
+}
@@ -0,0 +1,174 @@
+package load
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+	"write-server/metrics"
+)
+
+// 연결 방식 비교 모드(Config.CompareConns)에서 트랜잭션을 실행하는 연결
+const (
+	ConnModePool   = "pool"   // 트랜잭션마다 공유 풀(db.BeginTx)에서 연결을 얻음 (기본 동작)
+	ConnModeReused = "reused" // 워커가 실행 내내 잡아 둔 연결(db.Conn) 하나에서 트랜잭션을 반복
+)
+
+// maxConnModeSamples는 연결 방식별로 보관하는 최대 샘플 수입니다 (넘으면 이후 트랜잭션은 건수만 셈).
+const maxConnModeSamples = 100000
+
+// ConnModeResult는 연결 방식 하나의 트랜잭션 오버헤드입니다. 성공한 트랜잭션만 집계합니다.
+// Setup은 BeginTx(풀에서 연결 획득 포함), SET TRANSACTION, synchronous_commit 설정까지,
+// Commit은 COMMIT, Total은 BeginTx부터 COMMIT까지 트랜잭션 전체 시간입니다.
+type ConnModeResult struct {
+	Mode         string        `json:"mode"`
+	Transactions int64         `json:"transactions"`
+	Setup        TxPercentiles `json:"setup"`
+	Commit       TxPercentiles `json:"commit"`
+	Total        TxPercentiles `json:"total"`
+}
+
+// TxPercentiles는 트랜잭션 구간 하나의 평균과 백분위수입니다 (ms 소수).
+type TxPercentiles struct {
+	AvgLatency float64 `json:"avg_latency_ms"`
+	P50Latency float64 `json:"p50_latency_ms"`
+	P95Latency float64 `json:"p95_latency_ms"`
+	P99Latency float64 `json:"p99_latency_ms"`
+}
+
+// ConnComparison은 연결 방식 비교 모드의 결과입니다.
+// Delta 값은 pool - reused (ms)이며, 양수면 트랜잭션마다 풀에서 연결을 얻는 쪽이 그만큼 더 느립니다.
+type ConnComparison struct {
+	Pool   ConnModeResult `json:"pool"`
+	Reused ConnModeResult `json:"reused"`
+
+	SetupDeltaMs    float64 `json:"setup_delta_ms"`     // 평균 준비 시간 차이
+	SetupDeltaP50Ms float64 `json:"setup_delta_p50_ms"` // 준비 시간 중앙값 차이
+	TotalDeltaMs    float64 `json:"total_delta_ms"`     // 평균 트랜잭션 전체 시간 차이
+
+	// 준비 시간 분포의 Mann-Whitney U 검정 (A = reused, B = pool). 양쪽 샘플이 부족하면 nil
+	SetupTest *metrics.DistributionComparison `json:"setup_test,omitempty"`
+}
+
+// connModeSamples는 연결 방식 하나의 샘플입니다.
+type connModeSamples struct {
+	transactions int64
+	setup        []time.Duration
+	commit       []time.Duration
+	total        []time.Duration
+}
+
+// connComparison은 연결 방식 비교 모드에서 방식별 트랜잭션 시간을 모읍니다.
+// 워커는 트랜잭션마다 두 방식을 번갈아 쓰므로, 두 방식이 같은 시간대와 같은 부하에서 측정됩니다.
+type connComparison struct {
+	mu     sync.Mutex
+	pool   connModeSamples
+	reused connModeSamples
+}
+
+func newConnComparison() *connComparison {
+	return &connComparison{}
+}
+
+// record는 성공한 트랜잭션 하나의 시간을 기록합니다.
+// total은 insertBatch 호출 전체 시간, latency는 insertBatch가 반환한 지연시간(준비 이후부터 COMMIT까지)입니다.
+func (c *connComparison) record(mode string, total, latency, commit time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := &c.pool
+	if mode == ConnModeReused {
+		s = &c.reused
+	}
+	s.transactions++
+	if len(s.total) < maxConnModeSamples {
+		s.setup = append(s.setup, total-latency)
+		s.commit = append(s.commit, commit)
+		s.total = append(s.total, total)
+	}
+}
+
+// results는 현재까지의 방식별 결과와 차이를 계산합니다.
+func (c *connComparison) results() *ConnComparison {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := &ConnComparison{
+		Pool:   c.pool.result(ConnModePool),
+		Reused: c.reused.result(ConnModeReused),
+	}
+	result.SetupDeltaMs = result.Pool.Setup.AvgLatency - result.Reused.Setup.AvgLatency
+	result.SetupDeltaP50Ms = result.Pool.Setup.P50Latency - result.Reused.Setup.P50Latency
+	result.TotalDeltaMs = result.Pool.Total.AvgLatency - result.Reused.Total.AvgLatency
+	if test, err := metrics.CompareDistributions(c.reused.setup, c.pool.setup); err == nil {
+		result.SetupTest = &test
+	}
+	return result
+}
+
+func (s *connModeSamples) result(mode string) ConnModeResult {
+	return ConnModeResult{
+		Mode:         mode,
+		Transactions: s.transactions,
+		Setup:        durationPercentiles(s.setup),
+		Commit:       durationPercentiles(s.commit),
+		Total:        durationPercentiles(s.total),
+	}
+}
+
+// durationPercentiles는 latencies의 평균과 백분위수를 소수 ms로 계산합니다 (비어 있으면 0).
+// 준비 시간은 대부분 1ms 미만이므로 정수 ms로 자르지 않습니다.
+func durationPercentiles(latencies []time.Duration) TxPercentiles {
+	if len(latencies) == 0 {
+		return TxPercentiles{}
+	}
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	var sum time.Duration
+	for _, lat := range sorted {
+		sum += lat
+	}
+	ms := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+	return TxPercentiles{
+		AvgLatency: ms(sum) / float64(len(sorted)),
+		P50Latency: ms(sorted[len(sorted)*50/100]),
+		P95Latency: ms(sorted[len(sorted)*95/100]),
+		P99Latency: ms(sorted[len(sorted)*99/100]),
+	}
+}
+
+// connMode는 CompareConns일 때 워커의 n번째 트랜잭션에 쓸 연결 방식과 연결을 반환합니다 (짝수 = 공유 풀, 홀수 = 전용 연결).
+func (g *Generator) connMode(n int, conn txBeginner) (string, txBeginner) {
+	if n%2 == 0 {
+		return ConnModePool, g.db
+	}
+	return ConnModeReused, conn
+}
+
+// ConnComparison은 연결 방식 비교 모드의 결과를 반환합니다.
+// 비교 모드로 실행한 적이 없으면 nil입니다. 실행 중에는 현재까지의 결과를 반환합니다.
+func (g *Generator) ConnComparison() *ConnComparison {
+	comparison := g.connComparison.Load()
+	if comparison == nil {
+		return nil
+	}
+	return comparison.results()
+}
+
+// logConnComparison은 연결 방식별 트랜잭션 오버헤드를 로그에 표로 출력합니다.
+func logConnComparison(result *ConnComparison) {
+	log.Printf("Connection mode comparison (per transaction, ms):")
+	log.Printf("  %-8s %12s %9s %9s %9s %9s %9s", "mode", "transactions", "setup_avg", "setup_p50", "setup_p99", "commit", "total_avg")
+	for _, r := range []ConnModeResult{result.Pool, result.Reused} {
+		log.Printf("  %-8s %12d %9.3f %9.3f %9.3f %9.3f %9.3f",
+			r.Mode, r.Transactions, r.Setup.AvgLatency, r.Setup.P50Latency, r.Setup.P99Latency, r.Commit.AvgLatency, r.Total.AvgLatency)
+	}
+	log.Printf("  delta (pool - reused): setup_avg=%.3f setup_p50=%.3f total_avg=%.3f", result.SetupDeltaMs, result.SetupDeltaP50Ms, result.TotalDeltaMs)
+	if test := result.SetupTest; test != nil {
+		log.Printf("  setup mann-whitney: p=%.4f significant=%t", test.PValue, test.Significant)
+	}
+}
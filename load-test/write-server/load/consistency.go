@@ -0,0 +1,97 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// readCheckMaxWait는 INSERT한 행이 보이기를 기다리는 최대 시간입니다 (초과 시 unresolved).
+	readCheckMaxWait = 5 * time.Second
+	// readCheckInterval은 첫 읽기에서 놓친 행을 다시 읽는 간격입니다.
+	readCheckInterval = 5 * time.Millisecond
+)
+
+// SetReadDB는 read-your-writes 검증에서 다시 읽을 DB를 지정합니다 (예: 레플리카).
+// 지정하지 않으면 쓰기와 같은 DB에서 읽습니다. 부하 생성 시작 전에 호출해야 합니다.
+func (g *Generator) SetReadDB(db *sql.DB) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.readDB = db
+}
+
+// insertOne은 로그 1건을 INSERT하고 생성된 id, 지연시간, 커밋 시간, 추정 바이트 수를 반환합니다.
+func (g *Generator) insertOne(ctx context.Context, conn txBeginner, isolation string) (int64, time.Duration, time.Duration, int64, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET TRANSACTION ISOLATION LEVEL %s", isolation)); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	if err := g.setSynchronousCommit(ctx, tx); err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	start := time.Now()
+	level, service, message, metadata := randomLevel(), randomService(), randomMessage(), randomMetadata()
+
+	var id int64
+	err = tx.QueryRowContext(
+		ctx,
+		"INSERT INTO logs (level, service, message, metadata) VALUES ($1, $2, $3, $4) RETURNING id",
+		level,
+		service,
+		message,
+		metadata,
+	).Scan(&id)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	commitStart := time.Now()
+	if err := tx.Commit(); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	commit := time.Since(commitStart)
+
+	return id, time.Since(start), commit, rowBytes(level, service, message, metadata), nil
+}
+
+// waitVisible은 커밋된 id를 readDB에서 읽어 보일 때까지 기다립니다.
+// 첫 읽기에서 보이면 lag = 0이고, readCheckMaxWait 안에 보이지 않으면 visible = false입니다.
+func (g *Generator) waitVisible(id int64, stopCh <-chan struct{}) (time.Duration, bool, error) {
+	committed := time.Now()
+	for attempt := 0; ; attempt++ {
+		var found int64
+		err := g.readDB.QueryRow("SELECT id FROM logs WHERE id = $1", id).Scan(&found)
+		switch {
+		case err == nil:
+			if attempt == 0 {
+				return 0, true, nil
+			}
+			return time.Since(committed), true, nil
+		case !errors.Is(err, sql.ErrNoRows):
+			return 0, false, err
+		}
+
+		if time.Since(committed) >= readCheckMaxWait {
+			return 0, false, nil
+		}
+
+		select {
+		case <-time.After(readCheckInterval):
+		case <-stopCh:
+			return 0, false, errStopped
+		}
+	}
+}
+
+// errStopped는 검증 대기 중 부하 생성이 중지되었음을 나타냅니다 (결과를 기록하지 않음).
+var errStopped = errors.New("load generator stopped")
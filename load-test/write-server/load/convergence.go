@@ -0,0 +1,111 @@
+package load
+
+import (
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultConvergenceTolerance는 p95가 기준값에서 벗어나도 되는 기본 비율입니다 (±5%).
+	DefaultConvergenceTolerance = 0.05
+	// DefaultConvergenceWindow는 수렴으로 판단하기 위해 필요한 기본 연속 구간 수입니다.
+	DefaultConvergenceWindow = 5
+)
+
+// ConvergenceStatus는 p95 수렴 감지 상태입니다.
+type ConvergenceStatus struct {
+	Converged       bool    `json:"converged"`
+	ConvergedAfter  float64 `json:"converged_after_seconds,omitempty"` // 실행 시작부터 수렴까지 걸린 시간
+	P95Latency      float64 `json:"p95_latency_ms"`                    // 마지막으로 확인한 p95
+	Reference       float64 `json:"reference_p95_ms"`                  // 현재 허용 범위의 기준값
+	StableIntervals int     `json:"stable_intervals"`                  // 기준값의 허용 범위 안에 연속으로 머문 구간 수
+	Window          int     `json:"window"`                            // 수렴에 필요한 연속 구간 수
+	Checks          int     `json:"checks"`                            // 지금까지 확인한 횟수
+}
+
+// convergenceMonitor는 주기적으로 관찰한 p95가 기준값의 ±tolerance 안에
+// window번 연속으로 머무는지 추적합니다. 범위를 벗어나면 그 값을 새 기준으로 다시 셉니다.
+type convergenceMonitor struct {
+	mu        sync.Mutex
+	tolerance float64
+	start     time.Time
+	status    ConvergenceStatus
+}
+
+func newConvergenceMonitor(tolerance float64, window int) *convergenceMonitor {
+	return &convergenceMonitor{
+		tolerance: tolerance,
+		start:     time.Now(),
+		status:    ConvergenceStatus{Window: window},
+	}
+}
+
+// observe는 p95 하나를 반영하고, 이번 관찰로 처음 수렴했으면 true를 반환합니다.
+func (m *convergenceMonitor) observe(p95 float64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := &m.status
+	s.Checks++
+	s.P95Latency = p95
+
+	if s.Checks == 1 || math.Abs(p95-s.Reference) > s.Reference*m.tolerance {
+		s.Reference = p95
+		s.StableIntervals = 0
+		return false
+	}
+
+	s.StableIntervals++
+	if s.Converged || s.StableIntervals < s.Window {
+		return false
+	}
+	s.Converged = true
+	s.ConvergedAfter = time.Since(m.start).Seconds()
+	return true
+}
+
+func (m *convergenceMonitor) snapshot() ConvergenceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.status
+}
+
+// watchConvergence는 interval마다 누적 p95를 monitor에 반영합니다.
+// 수렴하면 로그를 남기고, stopOnConvergence면 해당 실행을 중지합니다.
+// 성공한 요청이 없는 구간은 p95가 의미 없으므로 건너뜁니다.
+func (g *Generator) watchConvergence(monitor *convergenceMonitor, interval time.Duration, stopOnConvergence bool, stopCh chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m := g.collector.GetMetrics()
+			if m.SuccessRequests == 0 || !monitor.observe(m.P95Latency) {
+				continue
+			}
+			status := monitor.snapshot()
+			log.Printf("p95 converged at %.2fms after %.1fs (%d stable intervals)", status.P95Latency, status.ConvergedAfter, status.StableIntervals)
+			if stopOnConvergence {
+				g.stopRun(stopCh, StopReasonConverged)
+				return
+			}
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// Convergence는 p95 수렴 감지 상태를 반환합니다.
+// 수렴 감지를 켜고 실행한 적이 없으면 nil입니다. 실행이 끝난 뒤에도 다음 Start까지 유지됩니다.
+func (g *Generator) Convergence() *ConvergenceStatus {
+	monitor := g.convergence.Load()
+	if monitor == nil {
+		return nil
+	}
+	status := monitor.snapshot()
+	return &status
+}
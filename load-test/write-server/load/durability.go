@@ -0,0 +1,26 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+)
+
+// 커밋 내구성 (Config.SynchronousCommit)
+const (
+	// SynchronousCommitOn은 커밋이 WAL을 디스크에 flush할 때까지 기다립니다 (PostgreSQL 기본값).
+	SynchronousCommitOn = "on"
+	// SynchronousCommitOff는 WAL flush를 기다리지 않고 커밋을 반환합니다. 서버가 비정상 종료되면
+	// 최근(최대 wal_writer_delay의 약 3배) 커밋이 사라질 수 있지만 데이터 일관성은 깨지지 않습니다.
+	SynchronousCommitOff = "off"
+)
+
+// setSynchronousCommit은 SynchronousCommit이 지정되어 있으면 이 트랜잭션에만 synchronous_commit을 설정합니다.
+// SET LOCAL은 트랜잭션이 끝나면 원래 값으로 돌아가므로 풀에 돌려준 연결에 설정이 남지 않습니다.
+// 값은 Validate에서 on/off로 제한하므로 그대로 인라인합니다.
+func (g *Generator) setSynchronousCommit(ctx context.Context, tx *sql.Tx) error {
+	if g.config.SynchronousCommit == "" {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, "SET LOCAL synchronous_commit = "+g.config.SynchronousCommit)
+	return err
+}
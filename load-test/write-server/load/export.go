@@ -0,0 +1,233 @@
+package load
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+	"write-server/metrics"
+)
+
+// RunExportVersion은 GET /load/run/export가 만드는 문서 형식의 버전입니다.
+// 필드를 빼거나 의미를 바꾸면 올리고, DecodeRunExport에 이전 버전을 현재 형식으로 옮기는 경로를 남깁니다.
+const RunExportVersion = 1
+
+// RunExportServer는 내보낸 문서의 server 값입니다. 다른 서버의 문서와는 비교할 수 없습니다.
+const RunExportServer = "write-server"
+
+// comparisonTolerance는 비교에서 변화로 보지 않는 상대 차이입니다 (±5%).
+const comparisonTolerance = 0.05
+
+// RunExport는 실행 하나를 공유하거나 버그 리포트에 첨부하기 위한 문서입니다 (GET /load/run/export).
+// POST /metrics/compare/import로 다시 올리면 현재 실행과 비교할 수 있습니다.
+type RunExport struct {
+	Version    int       `json:"version"`
+	Server     string    `json:"server"`
+	ExportedAt time.Time `json:"exported_at"`
+	Running    bool      `json:"running"` // 실행 중에 내보냈으면 true (중간 결과)
+
+	Config   Config          `json:"config"`
+	Metrics  metrics.Metrics `json:"metrics"`
+	Errors   ErrorBreakdown  `json:"errors"`
+	Timeline []StatusSample  `json:"timeline"` // 상태 이력 (/load/status/history의 samples, 오래된 순)
+}
+
+// ErrorBreakdown은 실행의 에러 종류별 건수입니다.
+type ErrorBreakdown struct {
+	Failed           int64                  `json:"failed"`
+	Timeouts         int64                  `json:"timeouts"`
+	ConnectionErrors int64                  `json:"connection_errors"`
+	Aborted          int64                  `json:"aborted"`    // 강제 중지로 취소된 요청 (에러율에 넣지 않음)
+	ErrorRate        float64                `json:"error_rate"` // (실패 + 타임아웃 + 연결 끊김) / 전체
+	ByLabel          map[string]LabelErrors `json:"by_label,omitempty"`
+	ByCode           map[string]int64       `json:"by_code,omitempty"` // 일반 실패의 원인별 건수 (/metrics의 failures_by_code)
+}
+
+// LabelErrors는 작업 라벨 하나의 에러 건수입니다 (에러가 있는 라벨만).
+type LabelErrors struct {
+	Failed           int64   `json:"failed"`
+	Timeouts         int64   `json:"timeouts"`
+	ConnectionErrors int64   `json:"connection_errors"`
+	ErrorRate        float64 `json:"error_rate"`
+}
+
+// MetricDelta는 비교한 지표 하나입니다. Verdict는 현재 실행(live) 기준으로
+// better/worse/same(차이가 ±5% 이내)이며, 가져온 값이 0이면 ChangePct를 생략합니다.
+type MetricDelta struct {
+	Name      string   `json:"name"`
+	Imported  float64  `json:"imported"`
+	Live      float64  `json:"live"`
+	Delta     float64  `json:"delta"` // live - imported
+	ChangePct *float64 `json:"change_pct,omitempty"`
+	Verdict   string   `json:"verdict"`
+}
+
+// ConfigChange는 두 실행의 설정에서 값이 다른 필드입니다.
+type ConfigChange struct {
+	Field    string          `json:"field"`
+	Imported json.RawMessage `json:"imported"`
+	Live     json.RawMessage `json:"live"`
+}
+
+// RunComparison은 POST /metrics/compare/import 응답입니다.
+type RunComparison struct {
+	ImportedVersion    int            `json:"imported_version"`
+	ImportedExportedAt time.Time      `json:"imported_exported_at"`
+	LiveRunning        bool           `json:"live_running"`
+	Metrics            []MetricDelta  `json:"metrics"`
+	ConfigChanges      []ConfigChange `json:"config_changes"` // 빈 배열이면 같은 설정
+}
+
+// ExportRun은 현재(또는 마지막) 실행의 설정, 메트릭, 에러 분류, 상태 이력을 하나의 문서로 만듭니다.
+func (g *Generator) ExportRun() RunExport {
+	m := g.collector.GetMetrics()
+	return RunExport{
+		Version:    RunExportVersion,
+		Server:     RunExportServer,
+		ExportedAt: time.Now(),
+		Running:    g.IsRunning(),
+		Config:     *g.GetConfig(),
+		Metrics:    m,
+		Errors:     errorBreakdown(m),
+		Timeline:   g.StatusHistory(time.Time{}).Samples,
+	}
+}
+
+func errorBreakdown(m metrics.Metrics) ErrorBreakdown {
+	b := ErrorBreakdown{
+		Failed:           m.FailedRequests,
+		Timeouts:         m.TimeoutRequests,
+		ConnectionErrors: m.ConnectionErrors,
+		Aborted:          m.AbortedRequests,
+		ErrorRate:        errorRate(m.FailedRequests+m.TimeoutRequests+m.ConnectionErrors, m.TotalRequests),
+		ByCode:           m.FailuresByCode,
+	}
+	for label, lm := range m.ByLabel {
+		errors := lm.FailedRequests + lm.TimeoutRequests + lm.ConnectionErrors
+		if errors == 0 {
+			continue
+		}
+		if b.ByLabel == nil {
+			b.ByLabel = make(map[string]LabelErrors)
+		}
+		b.ByLabel[label] = LabelErrors{
+			Failed:           lm.FailedRequests,
+			Timeouts:         lm.TimeoutRequests,
+			ConnectionErrors: lm.ConnectionErrors,
+			ErrorRate:        errorRate(errors, lm.TotalRequests),
+		}
+	}
+	return b
+}
+
+func errorRate(errors, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(errors) / float64(total)
+}
+
+// DecodeRunExport는 내보낸 문서를 읽습니다. 이전 버전의 문서는 현재 형식으로 옮겨 반환하고,
+// 이 서버보다 새 버전이거나 다른 서버의 문서는 에러입니다.
+// 설정은 DecodeConfig와 달리 모르는 필드를 허용합니다 (같은 버전 안에서 나중에 추가된 필드).
+func DecodeRunExport(r io.Reader) (*RunExport, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var header struct {
+		Version int    `json:"version"`
+		Server  string `json:"server"`
+	}
+	if err := json.Unmarshal(body, &header); err != nil {
+		return nil, fmt.Errorf("malformed export: %v", err)
+	}
+	switch {
+	case header.Version == 0:
+		return nil, fmt.Errorf("missing export version")
+	case header.Version > RunExportVersion:
+		return nil, fmt.Errorf("export version %d is newer than supported version %d", header.Version, RunExportVersion)
+	case header.Server != RunExportServer:
+		return nil, fmt.Errorf("export is from %q, cannot compare with %s", header.Server, RunExportServer)
+	}
+
+	// 버전 1이 첫 형식이므로 아직 옮길 이전 버전이 없음
+	var export RunExport
+	if err := json.Unmarshal(body, &export); err != nil {
+		return nil, fmt.Errorf("malformed export (version %d): %v", header.Version, err)
+	}
+	return &export, nil
+}
+
+// CompareRuns는 가져온 실행(imported)과 현재 실행(live)의 주요 지표와 설정을 비교합니다.
+func CompareRuns(imported, live RunExport) RunComparison {
+	a, b := imported.Metrics, live.Metrics
+	deltas := []MetricDelta{
+		metricDelta("tps", a.TPS, b.TPS, true),
+		metricDelta("achieved_ratio", a.AchievedRatio, b.AchievedRatio, true),
+		metricDelta("avg_latency_ms", a.AvgLatency, b.AvgLatency, false),
+		metricDelta("p50_latency_ms", a.P50Latency, b.P50Latency, false),
+		metricDelta("p95_latency_ms", a.P95Latency, b.P95Latency, false),
+		metricDelta("p99_latency_ms", a.P99Latency, b.P99Latency, false),
+		metricDelta("error_rate", imported.Errors.ErrorRate, live.Errors.ErrorRate, false),
+		metricDelta("timeout_rate", a.TimeoutRate, b.TimeoutRate, false),
+		metricDelta("conflict_rate", a.ConflictRate, b.ConflictRate, false),
+	}
+	return RunComparison{
+		ImportedVersion:    imported.Version,
+		ImportedExportedAt: imported.ExportedAt,
+		LiveRunning:        live.Running,
+		Metrics:            deltas,
+		ConfigChanges:      configChanges(imported.Config, live.Config),
+	}
+}
+
+func metricDelta(name string, imported, live float64, higherIsBetter bool) MetricDelta {
+	d := MetricDelta{Name: name, Imported: imported, Live: live, Delta: live - imported, Verdict: "same"}
+	// 기준이 0이면 0이 아닌 값은 모두 변화 (에러율 0 → 0.001)
+	changed := live != 0
+	if imported != 0 {
+		change := d.Delta / math.Abs(imported)
+		changed = math.Abs(change) > comparisonTolerance
+		change *= 100
+		d.ChangePct = &change
+	}
+	if changed {
+		if (d.Delta > 0) == higherIsBetter {
+			d.Verdict = "better"
+		} else {
+			d.Verdict = "worse"
+		}
+	}
+	return d
+}
+
+// configChanges는 두 설정을 JSON 필드 단위로 비교해 값이 다른 필드를 이름순으로 반환합니다.
+func configChanges(imported, live Config) []ConfigChange {
+	a, b := configFields(imported), configFields(live)
+	changes := []ConfigChange{}
+	for field, av := range a {
+		if bv, ok := b[field]; !ok || !bytes.Equal(av, bv) {
+			changes = append(changes, ConfigChange{Field: field, Imported: av, Live: bv})
+		}
+	}
+	for field, bv := range b {
+		if _, ok := a[field]; !ok {
+			changes = append(changes, ConfigChange{Field: field, Live: bv})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+func configFields(config Config) map[string]json.RawMessage {
+	data, _ := json.Marshal(config)
+	fields := make(map[string]json.RawMessage)
+	json.Unmarshal(data, &fields)
+	return fields
+}
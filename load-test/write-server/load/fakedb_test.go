@@ -0,0 +1,184 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"write-server/metrics"
+)
+
+// fakeDB는 PostgreSQL 없이 Generator를 실행하기 위한 database/sql 드라이버입니다.
+// 모든 쿼리는 delay만큼 걸린 뒤(컨텍스트가 취소되면 즉시 ctx.Err()) rows가 정한 결과를 반환합니다.
+type fakeDB struct {
+	// delay는 쿼리마다 걸리는 시간입니다 (nil = 0).
+	delay func(query string) time.Duration
+	// rows는 쿼리의 결과입니다 (nil = 열 없는 빈 결과).
+	rows func(query string, args []driver.NamedValue) ([]string, [][]driver.Value)
+
+	queries   atomic.Int64 // 실행한 쿼리 수 (SET TRANSACTION 제외)
+	inFlight  atomic.Int64 // 진행 중인 쿼리 수
+	commits   atomic.Int64 // 커밋한 트랜잭션 수
+	rollbacks atomic.Int64 // 롤백한 트랜잭션 수
+
+	mu  sync.Mutex
+	log []fakeQuery
+}
+
+// fakeQuery는 실행한 쿼리 하나의 기록입니다 (SET TRANSACTION 제외).
+type fakeQuery struct {
+	query string
+	start time.Time
+	end   time.Time
+}
+
+// open은 f를 쓰는 *sql.DB를 반환하며 테스트가 끝나면 닫습니다.
+func (f *fakeDB) open(t testing.TB) *sql.DB {
+	db := sql.OpenDB(fakeConnector{f})
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// history는 지금까지 실행한 쿼리 기록의 복사본입니다.
+func (f *fakeDB) history() []fakeQuery {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeQuery(nil), f.log...)
+}
+
+func (f *fakeDB) run(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if isSetTransaction(query) {
+		return &fakeRows{}, nil
+	}
+
+	f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	start := time.Now()
+	if f.delay != nil {
+		if d := f.delay(query); d > 0 {
+			timer := time.NewTimer(d)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+	f.queries.Add(1)
+	f.mu.Lock()
+	f.log = append(f.log, fakeQuery{query: query, start: start, end: time.Now()})
+	f.mu.Unlock()
+
+	if f.rows == nil {
+		return &fakeRows{}, nil
+	}
+	columns, values := f.rows(query, args)
+	return &fakeRows{columns: columns, values: values}, nil
+}
+
+func isSetTransaction(query string) bool {
+	return len(query) >= 15 && query[:15] == "SET TRANSACTION"
+}
+
+type fakeConnector struct{ db *fakeDB }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: c.db}, nil }
+func (c fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fakeDB is opened through its connector")
+}
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+func (c *fakeConn) PrepareContext(_ context.Context, query string) (driver.Stmt, error) {
+	return c.Prepare(query)
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{c.db}, nil }
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return fakeTx{c.db}, nil
+}
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.db.run(ctx, query, args)
+}
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if _, err := c.db.run(ctx, query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+type fakeTx struct{ db *fakeDB }
+
+func (tx fakeTx) Commit() error   { tx.db.commits.Add(1); return nil }
+func (tx fakeTx) Rollback() error { tx.db.rollbacks.Add(1); return nil }
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, driver.ErrSkip
+}
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, driver.ErrSkip
+}
+func (s *fakeStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+func (s *fakeStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.db.run(ctx, s.query, args)
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
+
+// newTestGenerator는 fake로 쿼리를 실행하는 Generator를 만듭니다. config는 검증한 뒤 사용합니다.
+func newTestGenerator(t testing.TB, fake *fakeDB, config *Config) *Generator {
+	t.Helper()
+	if err := config.Validate(); err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	return NewGenerator(fake.open(t), config, metrics.NewCollector())
+}
+
+// waitFor는 cond가 참이 될 때까지 timeout 동안 기다립니다.
+func waitFor(t testing.TB, timeout time.Duration, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out after %s waiting for %s", timeout, what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
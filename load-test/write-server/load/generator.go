@@ -1,9 +1,13 @@
 package load
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log"
 	"math/rand"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -12,157 +16,833 @@ import (
 
 type Generator struct {
 	db        *sql.DB
+	readDB    *sql.DB // read-your-writes 검증에서 다시 읽을 DB (기본값 db)
 	config    *Config
 	collector *metrics.Collector
 	running   atomic.Bool
 	wg        sync.WaitGroup
 	stopCh    chan struct{}
+	// cancelRun은 현재 실행의 컨텍스트를 취소합니다 (g.mu로 보호). 워커의 쿼리 컨텍스트는 모두 이 컨텍스트에서 파생되므로,
+	// 중지하면(Stop, ForceStop, Duration 종료 등) 진행 중인 쿼리가 끝나기를 기다리지 않고 취소됩니다.
+	cancelRun context.CancelFunc
+
+	// mu는 Start/Stop/UpdateConfig를 직렬화합니다.
+	// Stop이 wg.Wait()를 끝내기 전에 다음 Start가 wg.Add()를 호출하지 않도록 보장합니다.
+	mu sync.Mutex
+	// configMu는 실행 중이 아닐 때만 바뀌는 config 포인터를 GetConfig와 안전하게 공유합니다.
+	configMu sync.RWMutex
+	// rate는 워커가 따르는 목표 TPS입니다. config를 바꾸거나 실행을 시작할 때 config.TPS로 맞추고,
+	// 실행 중에는 SetTPS로만 바뀝니다 (config는 실행 중에 바뀌지 않으므로 따로 둠, rate.go).
+	rate atomic.Int64
+	// rotation은 격리 수준 비교 모드의 상태입니다 (비교 모드가 아니면 nil).
+	// 실행이 끝난 뒤에도 결과 조회를 위해 다음 Start까지 유지됩니다.
+	rotation atomic.Pointer[isolationRotation]
+	// convergence는 p95 수렴 감지 상태입니다 (ConvergenceInterval이 0이면 nil).
+	convergence atomic.Pointer[convergenceMonitor]
+	// failFast는 에러율 기반 조기 중단 상태입니다 (MaxErrorRate가 0이면 nil).
+	failFast atomic.Pointer[failFastMonitor]
+	// chaos는 연결 강제 종료 모드의 상태입니다 (ChaosInterval이 0이면 nil).
+	chaos atomic.Pointer[chaosMonitor]
+	// dutyCycle은 듀티 사이클(버스트 부하) 모드의 상태입니다 (DutyActive가 0이면 nil).
+	dutyCycle atomic.Pointer[dutyCycle]
+	// connComparison은 연결 방식 비교 모드의 상태입니다 (CompareConns가 아니면 nil). rotation처럼 다음 Start까지 유지됩니다.
+	connComparison atomic.Pointer[connComparison]
+	// heldTx는 트랜잭션 유지 모드의 상태입니다 (HeldTransactions가 0이면 nil).
+	heldTx atomic.Pointer[heldTxMonitor]
+	// targets는 현재 실행의 INSERT 대상 테이블입니다 (Tables가 비어 있으면 nil). g.mu로 보호되며 실행이 끝나면 준비한 문을 닫습니다.
+	targets *writeTargets
+	// history는 최근 상태 샘플의 원형 버퍼입니다 (Start마다 비우며, 실행이 끝난 뒤에도 다음 Start까지 유지).
+	history *statusHistory
+	// metricsCSVPath는 실행 중 초당 메트릭을 덧붙일 CSV 파일 경로입니다 (SetMetricsCSV, 빈 값 = 기록 안 함).
+	// metricsCSV는 현재 실행이 연 파일로, g.mu로 보호되며 실행이 끝나면 닫습니다.
+	metricsCSVPath string
+	metricsCSV     *metricsCSV
+	// poolDefaults는 config의 풀 설정이 0일 때 쓰는 서버 기본값입니다 (SetPoolDefaults, pool.go).
+	poolDefaults PoolSettings
+	// poolApplied는 마지막으로 g.db에 적용한 풀 설정입니다 (PoolSettings, /debug/config에서 잠금 없이 읽음).
+	poolApplied atomic.Pointer[PoolSettings]
 }
 
 func NewGenerator(db *sql.DB, config *Config, collector *metrics.Collector) *Generator {
-	return &Generator{
+	g := &Generator{
 		db:        db,
+		readDB:    db,
 		config:    config,
 		collector: collector,
 		stopCh:    make(chan struct{}),
+		history:   newStatusHistory(MaxStatusHistory),
 	}
+	g.rate.Store(int64(config.TPS))
+	return g
 }
 
 func (g *Generator) Start() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	if g.running.Load() {
 		return fmt.Errorf("generator already running")
 	}
 
+	trace, err := loadReplayTrace(g.config)
+	if err != nil {
+		return err
+	}
+	targets, err := g.prepareTargets(g.config)
+	if err != nil {
+		return err
+	}
+	csvLog, err := g.openMetricsCSV()
+	if err != nil {
+		targets.close()
+		return err
+	}
+
+	// ResetOnStart가 false면 이전 실행의 메트릭에 이어서 누적
+	if g.config.shouldResetOnStart() {
+		g.collector.Reset()
+	}
+	// 상태 이력은 메트릭 누적 여부와 관계없이 실행마다 새로 기록
+	g.history.reset()
+	g.startLocked(trace, targets, csvLog)
+	return nil
+}
+
+// startLocked는 현재 config로 워커와 타이머를 시작합니다. g.mu를 잡은 상태에서 호출해야 합니다.
+// 메트릭은 초기화하지 않으므로 Reload에서 누적 메트릭을 유지한 채 재시작할 수 있습니다.
+// trace는 재생 모드일 때 미리 읽어 둔 재생 파일, targets는 미리 준비한 대상 테이블,
+// csvLog는 미리 연 CSV 메트릭 로그입니다 (사용하지 않으면 nil).
+func (g *Generator) startLocked(trace *replayTrace, targets *writeTargets, csvLog *metricsCSV) {
+	// 실행마다 새 stopCh를 만들어 워커와 타이머에 직접 전달 (필드 재할당과 경합 방지)
+	stopCh := make(chan struct{})
+	g.stopCh = stopCh
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	g.cancelRun = cancelRun
+	g.rate.Store(int64(g.config.TPS))
+	g.applyPoolSettings()
 	g.running.Store(true)
-	g.stopCh = make(chan struct{})
-	g.collector.Reset()
 
 	// Duration이 설정된 경우 타이머 시작
-	if g.config.Duration > 0 {
+	if duration := g.config.Duration; duration > 0 {
 		go func() {
-			time.Sleep(g.config.Duration)
-			g.Stop()
+			timer := time.NewTimer(duration)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				g.stopRun(stopCh, StopReasonDuration)
+			case <-stopCh:
+			}
 		}()
 	}
 
+	// 격리 수준 비교 모드: IsolationSlice마다 (또는 트랜잭션마다) 다음 격리 수준으로 전환
+	g.rotation.Store(nil)
+	if g.config.CompareIsolation {
+		rotation := newIsolationRotation(g.config.IsolationRotation == RotationTransaction)
+		g.rotation.Store(rotation)
+		if !rotation.perTransaction {
+			go func(slice time.Duration) {
+				ticker := time.NewTicker(slice)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						rotation.advance()
+					case <-stopCh:
+						return
+					}
+				}
+			}(g.config.IsolationSlice)
+		}
+	}
+
+	// 연결 방식 비교 모드: 워커가 트랜잭션마다 공유 풀과 전용 연결을 번갈아 사용
+	g.connComparison.Store(nil)
+	if g.config.CompareConns {
+		g.connComparison.Store(newConnComparison())
+	}
+
+	// 지연시간 샘플링 비율 (Reload로 바뀌면 이후 요청부터 적용)
+	g.collector.SetLatencySampleRate(g.config.LatencySampleRate)
+	g.collector.SetPercentiles(g.config.Percentiles)
+
+	// 워밍업 제외 구간(warmup_exclude, 없으면 ramp_up)은 실행(재시작 포함)마다 지금부터 다시 셈
+	g.collector.SetWarmupExclude(g.config.warmupWindow())
+
+	// 수렴 감지: ConvergenceInterval마다 p95가 안정되었는지 확인
+	g.convergence.Store(nil)
+	if interval := g.config.ConvergenceInterval; interval > 0 {
+		monitor := newConvergenceMonitor(g.config.ConvergenceTolerance, g.config.ConvergenceWindow)
+		g.convergence.Store(monitor)
+		go g.watchConvergence(monitor, interval, g.config.StopOnConvergence, stopCh)
+	}
+
+	// 조기 중단: 윈도우 에러율이 MaxErrorRate를 넘으면 실행 중지
+	g.failFast.Store(nil)
+	if g.config.MaxErrorRate > 0 {
+		window := g.config.ErrorRateWindow
+		interval := errorRateCheckInterval
+		if window < interval {
+			interval = window
+		}
+		monitor := newFailFastMonitor(g.config.MaxErrorRate, window, interval)
+		g.failFast.Store(monitor)
+		go g.watchErrorRate(monitor, interval, stopCh)
+	}
+
+	// 연결 강제 종료: ChaosInterval마다 이 서버의 연결 하나를 종료해 네트워크 끊김을 흉내 냄
+	g.chaos.Store(nil)
+	if interval := g.config.ChaosInterval; interval > 0 {
+		monitor := newChaosMonitor(interval)
+		g.chaos.Store(monitor)
+		go g.injectChaos(monitor, interval, stopCh)
+	}
+
+	// 듀티 사이클: DutyActive 동안 부하를 생성하고 DutyIdle 동안 멈추는 것을 반복 (워커는 pausedUntil로 확인)
+	g.dutyCycle.Store(nil)
+	if g.config.DutyActive > 0 {
+		cycle := newDutyCycle(g.config.DutyActive, g.config.DutyIdle, g.collector)
+		g.dutyCycle.Store(cycle)
+		go g.runDutyCycle(cycle, stopCh)
+	}
+
+	// 트랜잭션 유지: 워커와 별도로 트랜잭션 HeldTransactions개를 HoldTime씩 열어 둠 (Stop은 워커처럼 wg로 기다림)
+	g.heldTx.Store(nil)
+	if holders := g.config.HeldTransactions; holders > 0 {
+		monitor := newHeldTxMonitor(holders, g.config.HoldTime)
+		g.heldTx.Store(monitor)
+		for i := 0; i < holders; i++ {
+			g.wg.Add(1)
+			go g.holdTransactions(runCtx, monitor, i, g.config.HoldTime, stopCh)
+		}
+	}
+
+	// 재생 모드: 워커들이 파일 순서대로 로그를 나눠 INSERT (Duration이 있으면 파일을 반복)
+	var replay *replayCursor
+	if trace != nil {
+		replay = newReplayCursor(trace, g.config.Duration > 0)
+	}
+
+	// 상태 이력: StatusHistoryInterval마다 메트릭 요약을 기록 (GET /load/status/history)
+	go g.recordHistory(stopCh)
+
+	// CSV 메트릭 로그: MetricsCSVInterval마다 한 행씩 덧붙임 (-metrics-csv)
+	g.metricsCSV = csvLog
+	if csvLog != nil {
+		go g.writeMetricsCSV(csvLog, stopCh)
+	}
+
+	// 여러 테이블 모드: 배치마다 대상 테이블을 돌아가며(또는 무작위로) 고름
+	g.targets = targets
+
 	// 워커 시작
 	for i := 0; i < g.config.Workers; i++ {
 		g.wg.Add(1)
-		go g.worker()
+		go g.worker(runCtx, stopCh, g.config.workerStartDelay(i), replay, targets)
 	}
+}
 
-	return nil
+// Stop은 부하 생성을 중지하고 모든 워커가 끝난 시점의 최종 메트릭을 반환합니다.
+// 실행 컨텍스트를 먼저 취소하므로 진행 중인 트랜잭션이 느려도 끝나기를 기다리지 않고 곧바로 멈춥니다.
+// 취소된 요청은 성공도 실패도 아닌 aborted_requests로 기록됩니다.
+// g.mu를 잡은 채 조회하므로 다음 Start의 메트릭 초기화보다 항상 먼저입니다.
+func (g *Generator) Stop() metrics.Metrics {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.stopLocked(StopReasonManual)
+	return g.collector.GetMetrics()
 }
 
-func (g *Generator) Stop() {
+// ForceStop은 Stop과 같지만 완료 웹훅의 중지 사유를 forced로 기록합니다 (긴급 중지, ?force=true).
+// 진행 중인 요청을 취소하는 것은 Stop도 같습니다.
+func (g *Generator) ForceStop() metrics.Metrics {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.stopLocked(StopReasonForced)
+	return g.collector.GetMetrics()
+}
+
+// stopRun은 stopCh에 해당하는 실행이 아직 진행 중일 때만 중지합니다.
+// Duration 타이머나 수렴 감지가 이후에 재시작된 다른 실행을 중지하지 않도록 합니다.
+func (g *Generator) stopRun(stopCh chan struct{}, reason string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.stopCh != stopCh {
+		return
+	}
+	g.stopLocked(reason)
+}
+
+func (g *Generator) stopLocked(reason string) {
 	if !g.running.Load() {
 		return
 	}
 
 	g.running.Store(false)
+	// 실행 컨텍스트를 먼저 취소해 진행 중인 쿼리를 중단시킴 (느린 쿼리가 끝나기를 기다리지 않음, 중단된 요청은 aborted_requests)
+	g.cancelRun()
 	close(g.stopCh)
 	g.wg.Wait()
+	// Reload는 같은 풀 설정으로 곧바로 다시 시작하므로(checkPoolUnchanged) 되돌리지 않음
+	if reason != StopReasonReload {
+		g.restorePoolSettings()
+	}
+
+	// 모든 워커가 끝난 시점을 상태 이력과 CSV 로그의 마지막 행으로 기록
+	now, final := time.Now(), g.collector.GetMetrics()
+	g.history.record(now, false, final)
+	if g.metricsCSV != nil {
+		if err := g.metricsCSV.record(now, final); err != nil {
+			log.Printf("Failed to write metrics CSV: %v", err)
+		}
+		g.metricsCSV.close()
+		g.metricsCSV = nil
+	}
+
+	g.targets.close()
+	g.targets = nil
+
+	var comparison []IsolationResult
+	if rotation := g.rotation.Load(); rotation != nil {
+		rotation.finish()
+		comparison = rotation.results()
+		logIsolationComparison(comparison)
+	}
+	if cycle := g.dutyCycle.Load(); cycle != nil {
+		cycle.finish()
+		logDutyCycle(g.DutyCycle())
+	}
+	connResult := g.ConnComparison()
+	if connResult != nil {
+		logConnComparison(connResult)
+	}
+
+	// 모든 워커가 끝난 뒤의 메트릭이 최종 결과
+	if url := g.config.CompletionWebhook; url != "" {
+		go notifyCompletion(url, CompletionEvent{
+			Reason:     reason,
+			FinishedAt: time.Now(),
+			Config:     *g.config,
+			Metrics:    g.collector.GetMetrics(),
+
+			IsolationComparison: comparison,
+			ConnComparison:      connResult,
+			Convergence:         g.Convergence(),
+			FailFast:            g.FailFast(),
+			Chaos:               g.Chaos(),
+			DutyCycle:           g.DutyCycle(),
+			HeldTransactions:    g.HeldTransactions(),
+		})
+	}
 }
 
-func (g *Generator) worker() {
+// worker는 startDelay만큼 기다린 뒤 부하를 생성합니다. 기다리는 중에 중지되면 연결을 얻지 않고 종료합니다.
+// runCtx는 실행 컨텍스트로, 중지하면 취소되어 진행 중인 쿼리를 중단하고 종료합니다.
+func (g *Generator) worker(runCtx context.Context, stopCh chan struct{}, startDelay time.Duration, replay *replayCursor, targets *writeTargets) {
 	defer g.wg.Done()
 
-	// TPS 제한을 위한 rate limiter
-	var ticker *time.Ticker
-	var tickerCh <-chan time.Time
+	if startDelay > 0 {
+		timer := time.NewTimer(startDelay)
+		select {
+		case <-timer.C:
+		case <-stopCh:
+			timer.Stop()
+			return
+		}
+	}
 
-	if g.config.TPS > 0 {
-		// TPS를 워커 수로 나눔
-		tpsPerWorker := g.config.TPS / g.config.Workers
-		if tpsPerWorker < 1 {
-			tpsPerWorker = 1
+	conn, release, err := g.workerConn(stopCh)
+	if err != nil {
+		// 연결을 기다리는 중에 중지된 경우는 정상 종료
+		if !errors.Is(err, context.Canceled) {
+			log.Printf("Worker failed to acquire dedicated connection: %v", err)
 		}
-		interval := time.Second / time.Duration(tpsPerWorker)
-		ticker = time.NewTicker(interval)
-		tickerCh = ticker.C
-		defer ticker.Stop()
+		return
 	}
+	defer func() { release() }()
+
+	// 지연시간은 워커별 기록기에 모았다가 주기적으로 합침 (종료할 때 남은 샘플을 합침)
+	rec := g.newWorkerRecorders()
+	defer rec.flush()
+
+	// TPS 제한을 위한 rate limiter (SetTPS로 목표가 바뀌면 다음 트랜잭션부터 새 간격)
+	var limiter workerLimiter
+	defer limiter.stop()
+
+	// 배치 크기별로 메트릭을 구분하기 위한 라벨 (설정은 실행 중 바뀌지 않음)
+	baseLabel := batchLabel(g.config)
+
+	// 연결 방식 비교 모드면 트랜잭션마다 공유 풀과 전용 연결을 번갈아 사용
+	connComparison := g.connComparison.Load()
+	var transactions int
+
+	// 연결 에러가 나면 성공할 때까지 점점 길게 기다리며 재시도
+	var backoff reconnectBackoff
+	// 직전 틱을 받은 시각 (틱 사이에서 일한 시간과 기다린 시간을 나눠 기록)
+	lastTick := time.Now()
+
+	// 듀티 사이클이면 쉬는 구간마다 다음 활성 구간까지 멈춤
+	pattern := g.pattern()
+	cycle := g.dutyCycle.Load()
 
 	for {
 		select {
-		case <-g.stopCh:
+		case <-stopCh:
 			return
 		default:
+			if pattern != nil {
+				if due := pattern.pausedUntil(time.Now()); !due.IsZero() {
+					rec.flushIfDue()
+					if !waitUntil(due, stopCh) {
+						return
+					}
+					// 쉬는 구간은 처리율 제한 대기도 작업도 아님
+					lastTick = time.Now()
+				}
+			}
+
 			// TPS 제한이 있으면 ticker 대기
-			if tickerCh != nil {
+			if tickerCh := limiter.update(g.limitRate(), g.config.Workers); tickerCh != nil {
+				rec.flushIfDue()
+				waitStart := time.Now()
 				select {
 				case <-tickerCh:
-				case <-g.stopCh:
+				case <-stopCh:
 					return
 				}
+				lastTick = g.recordLimiterWait(lastTick, waitStart)
 			}
 
-			// 배치 INSERT 실행
-			if err := g.insertBatch(); err != nil {
-				g.collector.RecordFailure(g.config.BatchSize)
+			isolation, levelCollector := g.isolation()
+
+			// read-your-writes 검증: 1건 INSERT 후 바로 다시 읽기
+			if g.config.ReadYourWrites {
+				err := g.readYourWrite(runCtx, conn, isolation, levelCollector, rec, stopCh)
+				if aborted(runCtx, err) {
+					return
+				}
+				if isConnectionError(err) {
+					if !g.connLost(&backoff, err, stopCh) {
+						return
+					}
+					// 워커 전용 연결은 끊기면 이후 쿼리가 모두 실패하므로 새 연결을 얻음
+					if g.config.DedicatedConns {
+						if conn, release, err = g.reconnect(release, &backoff, stopCh); err != nil {
+							return
+						}
+					}
+				} else if err == nil {
+					g.connRestored(&backoff)
+				}
+				continue
+			}
+
+			// 재생 모드면 파일의 다음 로그로 배치를 채움 (파일 끝에서는 BatchSize보다 짧을 수 있음)
+			rows := g.config.BatchSize
+			var logs []replayLog
+			if replay != nil {
+				var due time.Time
+				var ok bool
+				if logs, due, ok = replay.next(rows); !ok {
+					// 파일 끝: stopRun은 워커 종료를 기다리므로 별도 고루틴에서 호출
+					go g.stopRun(stopCh, StopReasonReplayEnd)
+					return
+				}
+				if !waitUntil(due, stopCh) {
+					return
+				}
+				rows = len(logs)
+			}
+
+			// 여러 테이블 모드면 이번 배치의 테이블을 고르고 테이블별 라벨로 기록
+			label := baseLabel
+			var target *writeTarget
+			if targets != nil {
+				target = targets.pick()
+				label = target.label
+			}
+
+			txConn, mode := conn, ""
+			if connComparison != nil {
+				mode, txConn = g.connMode(transactions, conn)
+				transactions++
+			}
+
+			// 배치 INSERT 실행 (연결 방식 비교 모드는 BeginTx부터의 전체 시간도 잼)
+			ctx, cancel := g.queryContext(runCtx)
+			txStart := time.Now()
+			latency, commit, bytes, deleted, conflicts, err := g.insertBatch(ctx, txConn, isolation, target, rows, logs)
+			txTotal := time.Since(txStart)
+			err = deadlineError(ctx, err)
+			cancel()
+			if aborted(runCtx, err) {
+				g.collector.RecordAborted(txTotal, rows)
+				if levelCollector != nil {
+					levelCollector.RecordAborted(txTotal, rows)
+				}
+				return
+			}
+			if isConnectionError(err) {
+				g.collector.RecordConnError(label, txTotal, rows)
+				if levelCollector != nil {
+					levelCollector.RecordConnError(label, txTotal, rows)
+				}
+				if !g.connLost(&backoff, err, stopCh) {
+					return
+				}
+				// 워커 전용 연결은 끊기면 이후 쿼리가 모두 실패하므로 새 연결을 얻음 (공유 풀에서 실패했으면 필요 없음)
+				if g.holdsConn() && mode != ConnModePool {
+					if conn, release, err = g.reconnect(release, &backoff, stopCh); err != nil {
+						return
+					}
+				}
+				continue
+			}
+			if isTimeout(err) {
+				g.collector.RecordTimeout(label, txTotal, rows)
+				if levelCollector != nil {
+					levelCollector.RecordTimeout(label, txTotal, rows)
+				}
+				continue
+			}
+			if err != nil {
+				g.collector.RecordFailureWithError(label, txTotal, rows, err)
+				if levelCollector != nil {
+					levelCollector.RecordFailureWithError(label, txTotal, rows, err)
+					g.recordConflict(isolation, rows, err)
+				}
+				continue
+			}
+
+			g.connRestored(&backoff)
+			if cycle != nil {
+				cycle.record(latency)
+			}
+			rec.main.RecordSuccess(label, latency, rows, bytes)
+			rec.main.RecordCommit(latency, commit, rows)
+			if levelCollector != nil {
+				rec.level(levelCollector).RecordSuccess(label, latency, rows, bytes)
+			}
+			if deleted > 0 {
+				g.collector.RecordDeleted(latency, deleted)
+			}
+			if g.config.OnConflict != "" {
+				g.collector.RecordConflicts(latency, conflicts)
+			}
+			if connComparison != nil {
+				connComparison.record(mode, txTotal, latency, commit)
 			}
 		}
 	}
 }
 
-func (g *Generator) insertBatch() error {
-	tx, err := g.db.Begin()
+// labelInsertOne은 read-your-writes 모드의 단건 INSERT 메트릭 라벨입니다.
+const labelInsertOne = "insert_one"
+
+// batchLabel은 배치 INSERT의 메트릭 라벨을 반환합니다 (예: "insert_batch_100", 정상 상태 모드는 "insert_delete_batch_100",
+// ON CONFLICT 모드는 "upsert_nothing_batch_100" 또는 "upsert_update_batch_100").
+// 대상 테이블을 여러 개 지정하면 테이블마다 ":테이블" 접미사를 붙인 라벨을 씁니다 (writeTarget.label).
+func batchLabel(config *Config) string {
+	if config.OnConflict != "" {
+		return fmt.Sprintf("upsert_%s_batch_%d", config.OnConflict, config.BatchSize)
+	}
+	if config.SteadyState {
+		return fmt.Sprintf("insert_delete_batch_%d", config.BatchSize)
+	}
+	return fmt.Sprintf("insert_batch_%d", config.BatchSize)
+}
+
+// readYourWrite는 로그 1건을 INSERT한 직후 id로 다시 읽어 보이는지 확인하고 결과를 기록합니다.
+// INSERT 에러를 반환하므로 워커는 연결 에러인지 보고 연결을 다시 얻을 수 있습니다.
+func (g *Generator) readYourWrite(runCtx context.Context, conn txBeginner, isolation string, levelCollector *metrics.Collector, rec *workerRecorders, stopCh <-chan struct{}) error {
+	ctx, cancel := g.queryContext(runCtx)
+	start := time.Now()
+	id, latency, commit, bytes, err := g.insertOne(ctx, conn, isolation)
+	elapsed := time.Since(start)
+	err = deadlineError(ctx, err)
+	cancel()
+	if aborted(runCtx, err) {
+		g.collector.RecordAborted(elapsed, 1)
+		if levelCollector != nil {
+			levelCollector.RecordAborted(elapsed, 1)
+		}
+		return err
+	}
+	if isConnectionError(err) {
+		g.collector.RecordConnError(labelInsertOne, elapsed, 1)
+		if levelCollector != nil {
+			levelCollector.RecordConnError(labelInsertOne, elapsed, 1)
+		}
+		return err
+	}
+	if isTimeout(err) {
+		g.collector.RecordTimeout(labelInsertOne, elapsed, 1)
+		if levelCollector != nil {
+			levelCollector.RecordTimeout(labelInsertOne, elapsed, 1)
+		}
+		return err
+	}
 	if err != nil {
+		g.collector.RecordFailureWithError(labelInsertOne, elapsed, 1, err)
+		if levelCollector != nil {
+			levelCollector.RecordFailureWithError(labelInsertOne, elapsed, 1, err)
+			g.recordConflict(isolation, 1, err)
+		}
 		return err
 	}
+
+	if cycle := g.dutyCycle.Load(); cycle != nil {
+		cycle.record(latency)
+	}
+	rec.main.RecordSuccess(labelInsertOne, latency, 1, bytes)
+	rec.main.RecordCommit(latency, commit, 1)
+	if levelCollector != nil {
+		rec.level(levelCollector).RecordSuccess(labelInsertOne, latency, 1, bytes)
+	}
+
+	lag, visible, err := g.waitVisible(id, stopCh)
+	if err != nil {
+		return nil
+	}
+	g.collector.RecordReadCheck(time.Since(start), lag, visible)
+	return nil
+}
+
+// queryContext는 실행 컨텍스트 runCtx에서 파생된 쿼리 컨텍스트를 반환합니다.
+// QueryTimeout이 설정되어 있으면 그 시간이 지나도 취소됩니다.
+func (g *Generator) queryContext(runCtx context.Context) (context.Context, context.CancelFunc) {
+	if g.config.QueryTimeout > 0 {
+		return context.WithTimeout(runCtx, g.config.QueryTimeout)
+	}
+	return context.WithCancel(runCtx)
+}
+
+// aborted는 err가 중지로 실행 컨텍스트가 취소되어 중단된 쿼리의 에러인지 확인합니다.
+// 취소된 쿼리는 드라이버에 따라 57014(query_canceled)나 연결 에러로 끝나므로 에러 종류보다 먼저 확인합니다.
+func aborted(runCtx context.Context, err error) bool {
+	return err != nil && runCtx.Err() != nil
+}
+
+// deadlineError는 컨텍스트 데드라인이 지난 뒤 발생한 에러(sql.ErrTxDone 등)도 타임아웃으로 분류되도록 감쌉니다.
+func deadlineError(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && !isTimeout(err) {
+		return fmt.Errorf("%w: %v", context.DeadlineExceeded, err)
+	}
+	return err
+}
+
+// isolation은 이번 배치에 사용할 격리 수준과, 비교 모드일 때 해당 격리 수준의 컬렉터를 반환합니다.
+func (g *Generator) isolation() (string, *metrics.Collector) {
+	if rotation := g.rotation.Load(); rotation != nil {
+		return rotation.level()
+	}
+	return g.config.IsolationLevel, nil
+}
+
+// workerRecorders는 워커 하나의 기록기(metrics.Recorder)입니다. 성공한 배치는 배치마다 Collector를 잠그지 않고 여기에 기록합니다.
+// 실패는 드물고 바로 보여야 하므로 Collector에 직접 기록합니다.
+type workerRecorders struct {
+	main   *metrics.Recorder
+	levels map[*metrics.Collector]*metrics.Recorder // 격리 수준 비교 모드의 수준별 기록기
+}
+
+func (g *Generator) newWorkerRecorders() *workerRecorders {
+	return &workerRecorders{main: g.collector.NewRecorder()}
+}
+
+// level은 격리 수준별 Collector c의 기록기를 반환하며, 처음이면 만듭니다.
+func (r *workerRecorders) level(c *metrics.Collector) *metrics.Recorder {
+	rec, ok := r.levels[c]
+	if !ok {
+		if r.levels == nil {
+			r.levels = make(map[*metrics.Collector]*metrics.Recorder)
+		}
+		rec = c.NewRecorder()
+		r.levels[c] = rec
+	}
+	return rec
+}
+
+// flushIfDue는 기다리기 전에 호출해, 배치가 뜸해도 모은 샘플이 주기를 넘겨 묵지 않게 합니다.
+func (r *workerRecorders) flushIfDue() {
+	r.main.FlushIfDue()
+	for _, rec := range r.levels {
+		rec.FlushIfDue()
+	}
+}
+
+func (r *workerRecorders) flush() {
+	r.main.Flush()
+	for _, rec := range r.levels {
+		rec.Flush()
+	}
+}
+
+// recordConflict는 비교 모드에서 err가 직렬화 충돌(40001)이면 격리 수준별로 count건을 셉니다.
+func (g *Generator) recordConflict(isolation string, count int, err error) {
+	if rotation := g.rotation.Load(); rotation != nil && isSerializationFailure(err) {
+		rotation.recordConflict(isolation, count)
+	}
+}
+
+// IsolationComparison은 격리 수준 비교 모드의 격리 수준별 결과를 반환합니다.
+// 비교 모드로 실행한 적이 없으면 nil입니다. 실행 중에는 현재까지의 결과를 반환합니다.
+func (g *Generator) IsolationComparison() []IsolationResult {
+	rotation := g.rotation.Load()
+	if rotation == nil {
+		return nil
+	}
+	return rotation.results()
+}
+
+// insertBatch는 n행 배치 INSERT를 실행하고 지연시간, 그중 커밋 시간, 추정 바이트 수, (SteadyState일 때) 삭제한 행 수,
+// (OnConflict일 때) 이미 있던 id와 충돌한 행 수를 반환합니다.
+// logs가 있으면 랜덤 값 대신 재생 파일의 로그를 넣습니다 (len(logs) == n).
+// target이 nil이면 logs 테이블에, 아니면 대상 테이블에 넣으며 n이 BatchSize면 미리 준비한 문을 씁니다.
+func (g *Generator) insertBatch(ctx context.Context, conn txBeginner, isolation string, target *writeTarget, n int, logs []replayLog) (time.Duration, time.Duration, int64, int64, int64, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, 0, 0, 0, err
+	}
 	defer tx.Rollback()
 
 	// 격리 수준 설정
-	if _, err := tx.Exec(fmt.Sprintf("SET TRANSACTION ISOLATION LEVEL %s", g.config.IsolationLevel)); err != nil {
-		return err
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET TRANSACTION ISOLATION LEVEL %s", isolation)); err != nil {
+		return 0, 0, 0, 0, 0, err
+	}
+	// 커밋 내구성 설정 (SynchronousCommit 지정 시)
+	if err := g.setSynchronousCommit(ctx, tx); err != nil {
+		return 0, 0, 0, 0, 0, err
 	}
 
 	start := time.Now()
+	var bytes int64
 
-	if g.config.BatchSize == 1 {
-		// 단일 INSERT
-		_, err = tx.Exec(
-			"INSERT INTO logs (level, service, message, metadata) VALUES ($1, $2, $3, $4)",
-			randomLevel(),
-			randomService(),
-			randomMessage(),
-			randomMetadata(),
-		)
-	} else {
-		// 배치 INSERT (VALUES를 여러 개 나열)
-		query := "INSERT INTO logs (level, service, message, metadata) VALUES "
-		args := make([]interface{}, 0, g.config.BatchSize*4)
+	columns, perRow := insertColumns(g.config)
+	args := make([]interface{}, 0, n*perRow)
 
-		for i := 0; i < g.config.BatchSize; i++ {
-			if i > 0 {
-				query += ", "
-			}
-			offset := i * 4
-			query += fmt.Sprintf("($%d, $%d, $%d, $%d)", offset+1, offset+2, offset+3, offset+4)
+	// ON CONFLICT 모드: 한정된 id 공간에서 고른 id를 직접 지정해 이미 있는 행과 충돌하게 함
+	var keys []int64
+	if g.config.OnConflict != "" {
+		keys = randomKeys(n, g.config.KeySpace)
+	}
 
-			args = append(args,
-				randomLevel(),
-				randomService(),
-				randomMessage(),
-				randomMetadata(),
-			)
+	for i := 0; i < n; i++ {
+		// 임의 스키마 모드: 컬럼별 생성기로 값 생성
+		if len(g.config.Columns) > 0 {
+			var size int64
+			args, size = g.config.appendColumnValues(args, start)
+			bytes += size
+			continue
 		}
 
-		_, err = tx.Exec(query, args...)
+		level, service, message, metadata := randomLevel(), randomService(), randomMessage(), randomMetadata()
+		if logs != nil {
+			level, service, message, metadata = logs[i].row()
+		}
+		bytes += rowBytes(level, service, message, metadata)
+		if keys != nil {
+			args = append(args, keys[i])
+		}
+		args = append(args, level, service, message, metadata)
+		if g.config.TimestampSpread > 0 {
+			args = append(args, randomTimestamp(start, g.config.TimestampSpread))
+		}
 	}
 
+	table := "logs"
+	var prepared *sql.Stmt
+	switch {
+	case target == nil:
+	case n == g.config.BatchSize:
+		table = target.quoted
+		prepared = target.stmt
+	default:
+		table = target.quoted
+	}
+	var query string
+	if prepared == nil {
+		query = insertQuery(table, columns, perRow, n) + conflictClause(g.config)
+	}
+	conflicts, err := insertRows(ctx, tx, prepared, query, g.config.OnConflict, n, args)
 	if err != nil {
-		return err
+		return 0, 0, 0, 0, 0, err
+	}
+
+	// 정상 상태 모드: 넣은 만큼 가장 오래된 행을 같은 트랜잭션에서 삭제
+	var deleted int64
+	if g.config.SteadyState {
+		deleted, err = deleteOldest(ctx, tx, table, n)
+		if err != nil {
+			return 0, 0, 0, 0, 0, err
+		}
 	}
 
+	// 커밋(WAL flush) 시간만 따로 측정 (INSERT 실행 제외)
+	commitStart := time.Now()
 	if err := tx.Commit(); err != nil {
-		return err
+		return 0, 0, 0, 0, 0, err
 	}
+	commit := time.Since(commitStart)
 
-	latency := time.Since(start)
-	g.collector.RecordSuccess(latency, g.config.BatchSize)
+	return time.Since(start), commit, bytes, deleted, conflicts, nil
+}
 
-	return nil
+// insertColumns는 INSERT할 컬럼 목록과 행당 인자 수를 반환합니다.
+// Columns를 지정하면 그 컬럼을, 아니면 logs 컬럼을 쓰며 과거 시각 분산 모드면 timestamp를, ON CONFLICT 모드면 id를 직접 지정합니다
+// (아니면 DEFAULT NOW()와 시퀀스).
+func insertColumns(config *Config) (string, int) {
+	if len(config.Columns) > 0 {
+		return customColumns(config.Columns), len(config.Columns)
+	}
+	columns, perRow := "level, service, message, metadata", 4
+	if config.OnConflict != "" {
+		columns, perRow = "id, "+columns, perRow+1
+	}
+	if config.TimestampSpread > 0 {
+		columns, perRow = columns+", timestamp", perRow+1
+	}
+	return columns, perRow
+}
+
+// insertQuery는 table에 n행을 넣는 INSERT 문을 만듭니다 (VALUES를 n개 나열, n이 1이면 단일 INSERT).
+// table은 인용된 이름이어야 합니다.
+func insertQuery(table, columns string, perRow, n int) string {
+	var b strings.Builder
+	b.WriteString("INSERT INTO " + table + " (" + columns + ") VALUES ")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(placeholders(i*perRow+1, perRow))
+	}
+	return b.String()
+}
+
+// deleteOldest는 table에서 id가 가장 작은 행을 최대 n개 삭제합니다.
+// SKIP LOCKED로 다른 워커가 삭제 중인 행을 건너뛰므로 워커끼리 같은 행을 두고 대기하지 않습니다.
+func deleteOldest(ctx context.Context, tx *sql.Tx, table string, n int) (int64, error) {
+	result, err := tx.ExecContext(
+		ctx,
+		"DELETE FROM "+table+" WHERE id IN (SELECT id FROM "+table+" ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED)",
+		n,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// rowBytes는 한 행의 쓰기 크기를 문자열 길이 합으로 추정합니다 (직렬화 크기가 아닌 저렴한 근사치).
+func rowBytes(fields ...string) int64 {
+	var n int64
+	for _, f := range fields {
+		n += int64(len(f))
+	}
+	return n
 }
 
 // 랜덤 데이터 생성 함수들
@@ -190,6 +870,25 @@ func randomMessage() string {
 	return messages[rand.Intn(len(messages))]
 }
 
+// randomTimestamp는 now 이전 spread 범위에서 균등하게 고른 시각을 반환합니다.
+func randomTimestamp(now time.Time, spread time.Duration) time.Time {
+	return now.Add(-time.Duration(rand.Int63n(int64(spread))))
+}
+
+// placeholders는 first부터 n개의 위치 인자를 "($1, $2, ...)" 형식으로 만듭니다.
+func placeholders(first, n int) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "$%d", first+i)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
 func randomMetadata() string {
 	requestID := rand.Intn(1000000)
 	userID := rand.Intn(10000)
@@ -199,6 +898,9 @@ func randomMetadata() string {
 }
 
 func (g *Generator) UpdateConfig(config *Config) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	if g.running.Load() {
 		return fmt.Errorf("cannot update config while generator is running")
 	}
@@ -207,14 +909,107 @@ func (g *Generator) UpdateConfig(config *Config) error {
 		return err
 	}
 
+	g.configMu.Lock()
 	g.config = config
+	g.rate.Store(int64(config.TPS))
+	g.configMu.Unlock()
 	return nil
 }
 
+// Reload는 config를 검증한 뒤 적용합니다. 실행 중이면 현재 실행을 중지하고 새 config로 다시 시작하며,
+// 이때 메트릭은 초기화하지 않습니다 (SIGHUP 설정 리로드용). 재시작 여부를 반환합니다.
+func (g *Generator) Reload(config *Config) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := config.Validate(); err != nil {
+		return false, err
+	}
+
+	// 재생 파일, 대상 테이블 INSERT 문, CSV 로그는 현재 실행을 멈추기 전에 준비해, 실패하면 기존 실행을 유지
+	restart := g.running.Load()
+	if restart {
+		if err := g.checkPoolUnchanged(config); err != nil {
+			return false, err
+		}
+	}
+	var trace *replayTrace
+	var targets *writeTargets
+	var csvLog *metricsCSV
+	if restart {
+		var err error
+		if trace, err = loadReplayTrace(config); err != nil {
+			return false, err
+		}
+		if targets, err = g.prepareTargets(config); err != nil {
+			return false, err
+		}
+		if csvLog, err = g.openMetricsCSV(); err != nil {
+			targets.close()
+			return false, err
+		}
+		g.stopLocked(StopReasonReload)
+	}
+
+	g.configMu.Lock()
+	g.config = config
+	g.rate.Store(int64(config.TPS))
+	g.configMu.Unlock()
+
+	if restart {
+		g.startLocked(trace, targets, csvLog)
+	}
+	return restart, nil
+}
+
 func (g *Generator) GetConfig() *Config {
+	g.configMu.RLock()
+	defer g.configMu.RUnlock()
+
 	return g.config
 }
 
+// TargetRate는 워커들이 실제로 목표로 하는 초당 INSERT 행 수입니다 (0 = 무제한 또는 기록된 간격대로 재생, Metrics.TargetRate).
+// TPS는 초당 트랜잭션 수이고 tps 메트릭은 행 단위로 세므로, 트랜잭션당 행 수(BatchSize, read-your-writes는 1)를 곱합니다.
+// TPS를 워커 수로 나눈 몫(최소 1)에 워커 수를 곱하므로 설정한 TPS와 조금 다를 수 있습니다.
+func (g *Generator) TargetRate() float64 {
+	config := g.GetConfig()
+	tps := g.TargetTPS()
+	if tps <= 0 || config.ReplayTiming {
+		return 0
+	}
+	rowsPerTx := config.BatchSize
+	if config.ReadYourWrites {
+		rowsPerTx = 1
+	}
+	return float64(perWorkerRate(tps, config.Workers) * config.Workers * rowsPerTx)
+}
+
+// limitRate는 워커가 지금 따라야 하는 목표 TPS입니다. 기록된 간격대로 재생할 때는 TPS 제한을 쓰지 않습니다 (0).
+func (g *Generator) limitRate() int {
+	if g.config.ReplayTiming {
+		return 0
+	}
+	return g.TargetTPS()
+}
+
+// recordLimiterWait는 waitStart부터 지금까지 틱을 기다린 시간과, 직전 틱(lastTick)부터 waitStart까지 일한 시간을 기록하고
+// 틱을 받은 지금 시각을 반환합니다. 워커가 틱을 놓칠 만큼 느리면 ticker가 밀린 틱을 버리므로 대기는 거의 0이 됩니다.
+func (g *Generator) recordLimiterWait(lastTick, waitStart time.Time) time.Time {
+	now := time.Now()
+	g.collector.RecordLimiterWait(now.Sub(waitStart), waitStart.Sub(lastTick))
+	return now
+}
+
+// perWorkerRate는 워커 하나가 맡는 초당 실행 횟수입니다 (최소 1).
+func perWorkerRate(rate, workers int) int {
+	perWorker := rate / workers
+	if perWorker < 1 {
+		perWorker = 1
+	}
+	return perWorker
+}
+
 func (g *Generator) IsRunning() bool {
 	return g.running.Load()
 }
@@ -0,0 +1,147 @@
+package load
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// Start/Stop을 반복해도 WaitGroup 재사용이나 stopCh 교체가 경합하지 않는지 확인합니다 (go test -race로 실행).
+func TestStartStopRepeated(t *testing.T) {
+	iterations := 1000
+	if testing.Short() {
+		iterations = 100
+	}
+
+	config := DefaultConfig()
+	config.TPS = 0
+	config.Workers = 4
+	g := newTestGenerator(t, &fakeDB{}, config)
+
+	// 실행 상태와 메트릭을 동시에 읽는 쪽 (HTTP 핸들러 역할)
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	readers.Add(1)
+	go func() {
+		defer readers.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				g.IsRunning()
+				g.TargetRate()
+				g.collector.GetMetrics()
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}()
+
+	for i := 0; i < iterations; i++ {
+		if err := g.Start(); err != nil {
+			t.Fatalf("Start #%d: %v", i, err)
+		}
+		if err := g.Start(); err == nil {
+			t.Fatalf("Start #%d succeeded while already running", i)
+		}
+		g.Stop()
+		if g.IsRunning() {
+			t.Fatalf("generator still running after Stop #%d", i)
+		}
+	}
+	close(done)
+	readers.Wait()
+}
+
+// 여러 고루틴이 동시에 Start/Stop을 호출해도 실행 상태가 일관되고 모든 워커가 끝나는지 확인합니다.
+func TestStartStopConcurrent(t *testing.T) {
+	config := DefaultConfig()
+	config.TPS = 0
+	config.Workers = 2
+	g := newTestGenerator(t, &fakeDB{}, config)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				g.Start()
+				g.Stop()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if g.IsRunning() {
+		t.Fatal("generator still running after every Stop")
+	}
+}
+
+// 램프업 중에는 워커가 모두 실행 중이지 않고, RampUp이 지나면 모두 실행 중인지 확인합니다.
+// 쿼리가 끝나지 않도록 오래 걸리게 하고, 진행 중인 쿼리 수를 실행 중인 워커 수로 봅니다.
+func TestRampUpStartsWorkersGradually(t *testing.T) {
+	const workers = 4
+	const rampUp = 400 * time.Millisecond
+	fake := &fakeDB{delay: func(string) time.Duration { return time.Minute }}
+
+	config := DefaultConfig()
+	config.TPS = 0
+	config.Workers = workers
+	config.RampUp = rampUp
+	g := newTestGenerator(t, fake, config)
+
+	start := time.Now()
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer g.ForceStop()
+
+	// i번째 워커는 RampUp × i / Workers 뒤에 시작하므로 첫 간격(100ms) 전에는 첫 워커만 실행 중
+	time.Sleep(rampUp / workers / 2)
+	if n := fake.inFlight.Load(); n >= workers {
+		t.Fatalf("%d workers running %v after Start, want fewer than %d during ramp-up", n, time.Since(start), workers)
+	}
+
+	waitFor(t, rampUp+2*time.Second, "all workers running", func() bool { return fake.inFlight.Load() == workers })
+	// 마지막 워커는 RampUp × (Workers-1) / Workers 뒤에 시작
+	if elapsed, last := time.Since(start), rampUp*(workers-1)/workers; elapsed < last {
+		t.Fatalf("all workers running after %v, want not before %v", elapsed, last)
+	}
+	time.Sleep(time.Until(start.Add(rampUp)))
+	if n := fake.inFlight.Load(); n != workers {
+		t.Fatalf("%d workers running after RampUp, want %d", n, workers)
+	}
+}
+
+// 진행 중인 배치가 느려도 Stop이 기다리지 않고 곧바로 끝나며, 취소된 배치는 성공도 실패도 아닌 aborted_requests(행 수 기준)로만 기록되는지 확인합니다.
+func TestStopCancelsSlowQuery(t *testing.T) {
+	const workers, batchSize = 2, 10
+	fake := &fakeDB{delay: func(string) time.Duration { return time.Minute }}
+
+	config := DefaultConfig()
+	config.TPS = 0
+	config.Workers = workers
+	config.BatchSize = batchSize
+	g := newTestGenerator(t, fake, config)
+
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	waitFor(t, 2*time.Second, "slow queries in flight", func() bool { return fake.inFlight.Load() == workers })
+
+	start := time.Now()
+	m := g.Stop()
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Stop took %v with a slow query in flight, want well under the query's 1m", elapsed)
+	}
+	if n := fake.inFlight.Load(); n != 0 {
+		t.Fatalf("%d queries still in flight after Stop", n)
+	}
+	if m.AbortedRequests != workers*batchSize {
+		t.Fatalf("aborted_requests = %d, want %d", m.AbortedRequests, workers*batchSize)
+	}
+	if m.TotalRequests != 0 || m.SuccessRequests != 0 || m.FailedRequests != 0 || m.TimeoutRequests != 0 || m.ConnectionErrors != 0 {
+		t.Fatalf("canceled queries were counted as completed: %+v", m)
+	}
+}
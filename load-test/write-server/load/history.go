@@ -0,0 +1,155 @@
+package load
+
+import (
+	"sync"
+	"time"
+	"write-server/metrics"
+)
+
+// 상태 이력 설정. 히트맵처럼 필요하면 서버 시작 시 변경할 수 있습니다.
+var (
+	StatusHistoryInterval = time.Second // 상태를 기록하는 간격
+	MaxStatusHistory      = 300         // 유지할 최대 샘플 수 (넘으면 오래된 샘플부터 덮어씀, 0이면 기록 안 함)
+)
+
+// StatusSample은 상태 이력의 한 점으로, 그 시점 /load/status 메트릭의 요약입니다.
+// 지연시간은 그 시점까지의 누적 백분위수입니다 (/metrics와 같음).
+type StatusSample struct {
+	Time             time.Time `json:"time"`
+	Running          bool      `json:"running"`
+	ElapsedSeconds   float64   `json:"elapsed_seconds"`
+	TotalRequests    int64     `json:"total_requests"`
+	SuccessRequests  int64     `json:"success_requests"`
+	FailedRequests   int64     `json:"failed_requests"`
+	TimeoutRequests  int64     `json:"timeout_requests"`
+	ConnectionErrors int64     `json:"connection_errors"`
+	TPS              float64   `json:"tps"`          // 실행 평균 (total / elapsed)
+	IntervalTPS      float64   `json:"interval_tps"` // 직전 샘플 이후 구간의 초당 트랜잭션 수
+	AvgLatency       float64   `json:"avg_latency_ms"`
+	P50Latency       float64   `json:"p50_latency_ms"`
+	P95Latency       float64   `json:"p95_latency_ms"`
+	P99Latency       float64   `json:"p99_latency_ms"`
+}
+
+// StatusHistory는 GET /load/status/history 응답입니다.
+type StatusHistory struct {
+	IntervalSeconds float64        `json:"interval_seconds"`
+	Capacity        int            `json:"capacity"` // 유지하는 최대 샘플 수
+	Dropped         int64          `json:"dropped"`  // 버퍼가 가득 차 덮어쓴 샘플 수
+	Samples         []StatusSample `json:"samples"`  // 오래된 순
+}
+
+// statusHistory는 최근 상태 샘플의 원형 버퍼입니다. 실행을 시작할 때(Start) 비웁니다.
+type statusHistory struct {
+	mu      sync.Mutex
+	samples []StatusSample // 길이 capacity 고정, next-count부터 count개가 오래된 순
+	next    int
+	count   int
+	dropped int64
+}
+
+func newStatusHistory(capacity int) *statusHistory {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &statusHistory{samples: make([]StatusSample, capacity)}
+}
+
+// reset은 기록한 샘플을 모두 버립니다.
+func (h *statusHistory) reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.next, h.count, h.dropped = 0, 0, 0
+}
+
+// record는 m을 요약해 샘플 하나를 추가합니다. 구간 처리율은 직전 샘플과의 차이로 계산하며,
+// 직전 샘플이 없거나 그 사이 메트릭이 초기화되었으면 실행 평균을 씁니다.
+func (h *statusHistory) record(now time.Time, running bool, m metrics.Metrics) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) == 0 {
+		return
+	}
+
+	sample := StatusSample{
+		Time:             now,
+		Running:          running,
+		ElapsedSeconds:   m.Elapsed,
+		TotalRequests:    m.TotalRequests,
+		SuccessRequests:  m.SuccessRequests,
+		FailedRequests:   m.FailedRequests,
+		TimeoutRequests:  m.TimeoutRequests,
+		ConnectionErrors: m.ConnectionErrors,
+		TPS:              m.TPS,
+		IntervalTPS:      m.TPS,
+		AvgLatency:       m.AvgLatency,
+		P50Latency:       m.P50Latency,
+		P95Latency:       m.P95Latency,
+		P99Latency:       m.P99Latency,
+	}
+	if h.count > 0 {
+		prev := h.samples[(h.next+len(h.samples)-1)%len(h.samples)]
+		if m.TotalRequests >= prev.TotalRequests && m.Elapsed > prev.ElapsedSeconds {
+			sample.IntervalTPS = float64(m.TotalRequests-prev.TotalRequests) / (m.Elapsed - prev.ElapsedSeconds)
+		}
+	}
+
+	h.samples[h.next] = sample
+	h.next = (h.next + 1) % len(h.samples)
+	if h.count < len(h.samples) {
+		h.count++
+	} else {
+		h.dropped++
+	}
+}
+
+// snapshot은 기록한 샘플 중 since 이후(since가 0이면 전부)를 오래된 순으로 복사해 반환합니다.
+func (h *statusHistory) snapshot(since time.Time) StatusHistory {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	out := StatusHistory{
+		IntervalSeconds: StatusHistoryInterval.Seconds(),
+		Capacity:        len(h.samples),
+		Dropped:         h.dropped,
+		Samples:         make([]StatusSample, 0, h.count),
+	}
+	first := h.next - h.count
+	if first < 0 {
+		first += len(h.samples)
+	}
+	for i := 0; i < h.count; i++ {
+		sample := h.samples[(first+i)%len(h.samples)]
+		if !since.IsZero() && !sample.Time.After(since) {
+			continue
+		}
+		out.Samples = append(out.Samples, sample)
+	}
+	return out
+}
+
+// recordHistory는 실행 중 StatusHistoryInterval마다 현재 메트릭을 상태 이력에 기록합니다.
+func (g *Generator) recordHistory(stopCh chan struct{}) {
+	if StatusHistoryInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(StatusHistoryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			g.history.record(now, true, g.collector.GetMetrics())
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// StatusHistory는 최근 상태 샘플을 반환합니다 (since 이후만, 0이면 전부).
+// 실행 도중 접속한 대시보드가 놓친 앞부분 차트를 채우는 데 사용합니다.
+func (g *Generator) StatusHistory(since time.Time) StatusHistory {
+	return g.history.snapshot(since)
+}
@@ -0,0 +1,160 @@
+package load
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxHeldTransactions는 동시에 열어 둘 수 있는 최대 트랜잭션 수입니다.
+	// 열린 트랜잭션마다 풀 연결 하나를 잡으므로, 풀(최대 50)의 대부분을 워커에게 남겨 둡니다.
+	MaxHeldTransactions = 20
+	// DefaultHoldTime은 트랜잭션 하나를 열어 두는 기본 시간입니다.
+	DefaultHoldTime = 30 * time.Second
+	// holdRetryDelay는 트랜잭션을 열지 못했을 때 다시 시도하기 전 대기 시간입니다.
+	holdRetryDelay = time.Second
+)
+
+// holdQuery는 열어 둔 트랜잭션에 트랜잭션 ID를 할당합니다.
+// xid가 할당된 트랜잭션은 끝날 때까지 모든 스냅샷에서 "진행 중"으로 보이므로, xmin horizon을 이 트랜잭션 이전에 묶어 둡니다.
+// 그동안 지워진 행은 VACUUM이 회수할 수 없는 dead tuple로 남습니다.
+const holdQuery = "SELECT txid_current()"
+
+// HeldTxStatus는 트랜잭션 유지(idle in transaction) 모드의 상태입니다.
+type HeldTxStatus struct {
+	Holders     int     `json:"holders"`      // 유지하는 트랜잭션 수 (HeldTransactions)
+	HoldSeconds float64 `json:"hold_seconds"` // 트랜잭션 하나를 열어 두는 시간
+	Open        int     `json:"open"`         // 지금 열려 있는 트랜잭션 수
+	Opened      int64   `json:"opened"`       // 연 트랜잭션 수 (누적)
+	Committed   int64   `json:"committed"`    // 유지 시간이 지나 커밋한 트랜잭션 수 (중지되어 롤백한 트랜잭션은 제외)
+	Errors      int64   `json:"errors"`       // 트랜잭션을 열거나 커밋하지 못한 횟수
+	OldestXID   int64   `json:"oldest_xid,omitempty"`
+	LastError   string  `json:"last_error,omitempty"`
+}
+
+// heldTxMonitor는 열어 둔 트랜잭션의 상태를 기록합니다.
+type heldTxMonitor struct {
+	mu     sync.Mutex
+	status HeldTxStatus
+	xids   map[int]int64 // 유지 고루틴 번호 → 열려 있는 트랜잭션의 xid
+}
+
+func newHeldTxMonitor(holders int, holdTime time.Duration) *heldTxMonitor {
+	return &heldTxMonitor{
+		status: HeldTxStatus{Holders: holders, HoldSeconds: holdTime.Seconds()},
+		xids:   make(map[int]int64, holders),
+	}
+}
+
+func (m *heldTxMonitor) opened(holder int, xid int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.status.Opened++
+	m.xids[holder] = xid
+}
+
+// closed는 holder의 트랜잭션이 끝났음을 기록합니다. err는 커밋 에러이며, 중지로 롤백된 경우는 aborted입니다.
+func (m *heldTxMonitor) closed(holder int, aborted bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.xids, holder)
+	switch {
+	case aborted:
+	case err != nil:
+		m.status.Errors++
+		m.status.LastError = err.Error()
+	default:
+		m.status.Committed++
+	}
+}
+
+func (m *heldTxMonitor) failed(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.status.Errors++
+	m.status.LastError = err.Error()
+}
+
+func (m *heldTxMonitor) snapshot() HeldTxStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := m.status
+	status.Open = len(m.xids)
+	for _, xid := range m.xids {
+		if status.OldestXID == 0 || xid < status.OldestXID {
+			status.OldestXID = xid
+		}
+	}
+	return status
+}
+
+// holdTransactions는 트랜잭션 하나를 열어 xid를 할당받고 holdTime 동안 아무것도 하지 않다가(idle in transaction)
+// 커밋하고 다시 여는 것을 반복합니다. 다른 워커는 그동안 평소대로 부하를 생성합니다.
+// 중지되면 runCtx가 먼저 취소되므로 database/sql이 열려 있는 트랜잭션을 롤백하고 끝납니다 (쓴 것이 없으므로 커밋과 결과가 같음).
+func (g *Generator) holdTransactions(runCtx context.Context, monitor *heldTxMonitor, holder int, holdTime time.Duration, stopCh chan struct{}) {
+	defer g.wg.Done()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		tx, err := g.db.BeginTx(runCtx, nil)
+		var xid int64
+		if err == nil {
+			if err = tx.QueryRowContext(runCtx, holdQuery).Scan(&xid); err != nil {
+				tx.Rollback()
+			}
+		}
+		if err != nil {
+			if runCtx.Err() != nil {
+				return
+			}
+			log.Printf("Held transaction %d: failed to open: %v", holder, err)
+			monitor.failed(err)
+			if !waitUntil(time.Now().Add(holdRetryDelay), stopCh) {
+				return
+			}
+			continue
+		}
+		monitor.opened(holder, xid)
+
+		timer := time.NewTimer(holdTime)
+		stopped := false
+		select {
+		case <-timer.C:
+		case <-stopCh:
+			timer.Stop()
+			stopped = true
+		}
+
+		err = tx.Commit()
+		aborted := runCtx.Err() != nil
+		if err != nil && !aborted {
+			log.Printf("Held transaction %d: failed to commit: %v", holder, err)
+		}
+		monitor.closed(holder, aborted, err)
+		if stopped {
+			return
+		}
+	}
+}
+
+// HeldTransactions는 트랜잭션 유지 모드의 상태를 반환합니다.
+// HeldTransactions를 지정하고 실행한 적이 없으면 nil입니다. 실행이 끝난 뒤에도 다음 Start까지 유지됩니다.
+func (g *Generator) HeldTransactions() *HeldTxStatus {
+	monitor := g.heldTx.Load()
+	if monitor == nil {
+		return nil
+	}
+	status := monitor.snapshot()
+	return &status
+}
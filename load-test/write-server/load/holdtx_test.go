@@ -0,0 +1,53 @@
+package load
+
+import (
+	"database/sql/driver"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 유지 시간 중에 Stop하면 열어 둔 트랜잭션을 커밋하지 않고 롤백하는지 확인합니다.
+// Stop은 실행 컨텍스트를 먼저 취소하므로 유지 고루틴의 Commit은 취소된 트랜잭션에 대해 실패하고,
+// database/sql이 트랜잭션을 롤백합니다. 워커의 INSERT는 끝나지 않게 해 커밋이 섞이지 않도록 합니다.
+func TestStopRollsBackHeldTransactions(t *testing.T) {
+	const holders = 2
+	var xid atomic.Int64
+	fake := &fakeDB{
+		delay: func(query string) time.Duration {
+			if query == holdQuery {
+				return 0
+			}
+			return time.Minute
+		},
+		rows: func(query string, _ []driver.NamedValue) ([]string, [][]driver.Value) {
+			if query != holdQuery {
+				return nil, nil
+			}
+			return []string{"txid_current"}, [][]driver.Value{{xid.Add(1)}}
+		},
+	}
+
+	config := DefaultConfig()
+	config.TPS = 0
+	config.Workers = 1
+	config.HeldTransactions = holders
+	config.HoldTime = time.Minute
+	g := newTestGenerator(t, fake, config)
+
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	waitFor(t, 2*time.Second, "held transactions open", func() bool { return g.HeldTransactions().Open == holders })
+
+	g.Stop()
+	status := g.HeldTransactions()
+	if status.Open != 0 || status.Committed != 0 || status.Errors != 0 {
+		t.Fatalf("held transactions after Stop = %+v, want none open, committed or failed", *status)
+	}
+	if n := fake.commits.Load(); n != 0 {
+		t.Fatalf("%d transactions committed, want 0", n)
+	}
+	// 롤백은 database/sql이 컨텍스트 취소를 받아 별도 고루틴에서 호출하므로 Stop이 끝난 직후에는 아직일 수 있음
+	waitFor(t, 2*time.Second, "held transactions rolled back", func() bool { return fake.rollbacks.Load() >= holders })
+}
@@ -0,0 +1,67 @@
+package load
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// SetTPS는 실행 중인 부하의 목표 TPS를 바꾸고 이전 값을 반환합니다 (0 = 무제한).
+// 워커는 멈추지 않고 다음 트랜잭션부터 새 간격으로 기다리므로 연결 풀과 누적 메트릭이 그대로 유지됩니다.
+// 배치 크기, 워커 수 같은 다른 설정은 여전히 중지한 뒤 바꿔야 합니다.
+// 설정(GetConfig)은 바꾸지 않으므로 다음 Start는 설정의 TPS로 시작합니다.
+func (g *Generator) SetTPS(tps int) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch {
+	case tps < 0:
+		return 0, fmt.Errorf("tps must not be negative, got %d", tps)
+	case !g.running.Load():
+		return 0, fmt.Errorf("load generator is not running")
+	case g.config.ReplayTiming:
+		return 0, fmt.Errorf("tps cannot be changed while replaying with replay_timing")
+	}
+
+	previous := int(g.rate.Swap(int64(tps)))
+	log.Printf("Target TPS changed from %d to %d", previous, tps)
+	return previous, nil
+}
+
+// TargetTPS는 워커가 따르는 목표 TPS입니다 (SetTPS로 바꿨으면 그 값, 아니면 설정의 TPS).
+func (g *Generator) TargetTPS() int {
+	return int(g.rate.Load())
+}
+
+// workerLimiter는 워커 하나의 처리율 제한 ticker입니다.
+// 목표 처리율이 실행 중에 바뀌면(SetTPS) 다음 트랜잭션 전에 ticker를 만들거나, 간격을 바꾸거나, 멈춥니다.
+type workerLimiter struct {
+	ticker *time.Ticker
+	rate   int // ticker를 만든 목표 처리율 (0 = 제한 없음)
+}
+
+// update는 목표 처리율 rate(워커 workers개의 합)에 맞춘 ticker 채널을 반환합니다 (nil = 제한 없음).
+func (l *workerLimiter) update(rate, workers int) <-chan time.Time {
+	if rate != l.rate {
+		l.rate = rate
+		switch {
+		case rate <= 0:
+			l.stop()
+			l.ticker = nil
+		case l.ticker == nil:
+			l.ticker = time.NewTicker(time.Second / time.Duration(perWorkerRate(rate, workers)))
+		default:
+			l.ticker.Reset(time.Second / time.Duration(perWorkerRate(rate, workers)))
+		}
+	}
+	if l.ticker == nil {
+		return nil
+	}
+	return l.ticker.C
+}
+
+func (l *workerLimiter) stop() {
+	if l.ticker != nil {
+		l.ticker.Stop()
+	}
+}
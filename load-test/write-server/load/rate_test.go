@@ -0,0 +1,63 @@
+package load
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// 실행 중에 SetTPS로 목표를 바꾸면 워커의 틱 간격이 새 목표에 맞게 바뀌는지 확인합니다.
+// 여러 고루틴이 동시에 바꾸고 읽으므로 go test -race로 실행합니다.
+func TestSetTPSMidRun(t *testing.T) {
+	const workers = 2
+	config := DefaultConfig()
+	config.TPS = 50 // 워커당 초당 25번 = 40ms 간격
+	config.Workers = workers
+	config.BatchSize = 1
+	g := newTestGenerator(t, &fakeDB{}, config)
+
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer g.Stop()
+
+	// interval은 window 동안 완료한 트랜잭션 수로 계산한 워커당 틱 간격입니다 (배치 크기 1이면 요청 수 = 트랜잭션 수).
+	interval := func(window time.Duration) time.Duration {
+		before, _ := g.collector.RequestCounts()
+		time.Sleep(window)
+		after, _ := g.collector.RequestCounts()
+		if after == before {
+			return window
+		}
+		return window * workers / time.Duration(after-before)
+	}
+
+	time.Sleep(100 * time.Millisecond) // 첫 틱이 자리 잡을 때까지
+	before := interval(500 * time.Millisecond)
+
+	// 워커가 도는 중에 여러 고루틴이 동시에 목표를 바꾸고 읽음
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := g.SetTPS(500); err != nil {
+				t.Errorf("SetTPS: %v", err)
+			}
+			g.TargetTPS()
+			g.collector.GetMetrics()
+		}()
+	}
+	wg.Wait()
+	if got := g.TargetTPS(); got != 500 {
+		t.Fatalf("TargetTPS = %d, want 500", got)
+	}
+
+	time.Sleep(100 * time.Millisecond) // 워커가 이전 간격의 틱을 받고 새 ticker로 바꿀 때까지
+	// 목표가 10배가 되었으므로 간격은 약 1/10이 되어야 함. 벽시계 기준 절대값은 부하가 걸린 CI에서 흔들리므로
+	// 이전 간격과의 비율로 확인 (5배 이상 빨라짐)
+	after := interval(500 * time.Millisecond)
+	if after*5 > before {
+		t.Fatalf("tick interval went from %v at tps 50 to %v at tps 500, want at least 5x shorter", before, after)
+	}
+}
@@ -0,0 +1,175 @@
+package load
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxReplayLine은 재생 파일 한 줄의 최대 크기입니다.
+const maxReplayLine = 1 << 20
+
+// replayLog는 재생 파일의 한 줄(로그 1건)입니다.
+// 예: {"timestamp": "2024-01-01T00:00:00.120Z", "level": "ERROR", "service": "api", "message": "...", "metadata": {"user_id": 1}}
+type replayLog struct {
+	Level     string          `json:"level"`     // 비어 있으면 랜덤
+	Service   string          `json:"service"`   // 비어 있으면 랜덤
+	Message   string          `json:"message"`   // 비어 있으면 랜덤
+	Metadata  json.RawMessage `json:"metadata"`  // JSON 객체 (없으면 랜덤)
+	Timestamp time.Time       `json:"timestamp"` // 기록된 도착 시각 (ReplayTiming일 때만 사용, 행의 timestamp는 평소처럼 지정)
+}
+
+// row는 INSERT할 값을 반환합니다. 비어 있는 필드는 랜덤 값으로 채웁니다.
+func (l replayLog) row() (level, service, message, metadata string) {
+	level, service, message, metadata = l.Level, l.Service, l.Message, string(l.Metadata)
+	if level == "" {
+		level = randomLevel()
+	}
+	if service == "" {
+		service = randomService()
+	}
+	if message == "" {
+		message = randomMessage()
+	}
+	if len(l.Metadata) == 0 || string(l.Metadata) == "null" {
+		metadata = randomMetadata()
+	}
+	return level, service, message, metadata
+}
+
+// replayTrace는 재생 파일에서 읽은 로그와, ReplayTiming일 때 첫 로그 기준 각 로그의 도착 시각입니다.
+type replayTrace struct {
+	logs    []replayLog
+	offsets []time.Duration // ReplayTiming이 아니면 nil
+	period  time.Duration   // 반복 재생 시 한 바퀴 길이 (마지막 도착 시각 + 평균 간격)
+}
+
+// loadReplayTrace는 config.ReplayFile을 읽습니다. ReplayFile이 비어 있으면 nil을 반환합니다.
+// 파일 전체를 메모리에 올리므로 Start/Reload 시점에 한 번만 읽습니다.
+func loadReplayTrace(config *Config) (*replayTrace, error) {
+	if config.ReplayFile == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(config.ReplayFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay file: %w", err)
+	}
+	defer f.Close()
+
+	trace := &replayTrace{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxReplayLine)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var l replayLog
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, fmt.Errorf("replay file %s line %d: %w", config.ReplayFile, line, err)
+		}
+		if config.ReplayTiming && l.Timestamp.IsZero() {
+			return nil, fmt.Errorf("replay file %s line %d: timestamp is required with replay_timing", config.ReplayFile, line)
+		}
+		trace.logs = append(trace.logs, l)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read replay file: %w", err)
+	}
+	if len(trace.logs) == 0 {
+		return nil, fmt.Errorf("replay file %s has no logs", config.ReplayFile)
+	}
+
+	if config.ReplayTiming {
+		timestamps := make([]time.Time, len(trace.logs))
+		for i, l := range trace.logs {
+			timestamps[i] = l.Timestamp
+		}
+		trace.offsets, trace.period = replayOffsets(timestamps)
+	}
+	return trace, nil
+}
+
+// replayOffsets는 첫 시각 기준의 도착 시각과 반복 재생 시 한 바퀴 길이를 계산합니다.
+// 기록이 시간 순이 아니어도 도착 시각이 줄어들지 않도록 앞 값으로 맞춥니다.
+func replayOffsets(timestamps []time.Time) ([]time.Duration, time.Duration) {
+	offsets := make([]time.Duration, len(timestamps))
+	for i, ts := range timestamps {
+		offsets[i] = ts.Sub(timestamps[0])
+		if i > 0 && offsets[i] < offsets[i-1] {
+			offsets[i] = offsets[i-1]
+		}
+	}
+
+	// 다음 바퀴의 첫 로그는 마지막 로그 뒤 평균 간격만큼 지나서 보냄
+	last := offsets[len(offsets)-1]
+	if len(offsets) == 1 {
+		return offsets, last
+	}
+	return offsets, last + last/time.Duration(len(offsets)-1)
+}
+
+// replayCursor는 한 실행의 워커들이 공유하는 재생 위치입니다.
+// 워커들은 파일 순서대로 로그를 배치 단위로 나눠 가지며, 커밋 순서는 워커 수가 1일 때만 파일 순서와 같습니다.
+type replayCursor struct {
+	trace *replayTrace
+	loop  bool      // 파일 끝에 도달하면 처음부터 다시 재생 (Duration이 설정된 경우)
+	start time.Time // 재생 시작 시각 (도착 시각 기준점)
+
+	mu    sync.Mutex
+	pos   int
+	round int
+}
+
+func newReplayCursor(trace *replayTrace, loop bool) *replayCursor {
+	return &replayCursor{trace: trace, loop: loop, start: time.Now()}
+}
+
+// next는 다음 로그를 최대 n건 꺼내고, 그 배치를 보낼 시각(마지막 로그의 도착 시각,
+// ReplayTiming이 아니면 zero time)을 반환합니다. 반복하지 않으면 파일 끝에서 배치가 n건보다 짧을 수 있고,
+// 남은 로그가 없으면 false를 반환합니다.
+func (c *replayCursor) next(n int) ([]replayLog, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	logs := make([]replayLog, 0, n)
+	var due time.Time
+	for len(logs) < n {
+		if c.pos == len(c.trace.logs) {
+			if !c.loop {
+				break
+			}
+			c.pos = 0
+			c.round++
+		}
+		logs = append(logs, c.trace.logs[c.pos])
+		if c.trace.offsets != nil {
+			due = c.start.Add(time.Duration(c.round)*c.trace.period + c.trace.offsets[c.pos])
+		}
+		c.pos++
+	}
+	return logs, due, len(logs) > 0
+}
+
+// waitUntil은 due까지 기다립니다. due가 zero time이거나 이미 지났으면 바로 반환하고,
+// 기다리는 중에 중지되면 false를 반환합니다.
+func waitUntil(due time.Time, stopCh <-chan struct{}) bool {
+	if due.IsZero() {
+		return true
+	}
+	d := time.Until(due)
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stopCh:
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// 대상 테이블 선택 방식 (Config.TableSelection)
+const (
+	// TableRoundRobin은 배치마다 Tables를 순서대로 돌아가며 고릅니다 (모든 워커가 하나의 순번을 공유).
+	TableRoundRobin = "round_robin"
+	// TableRandom은 배치마다 Tables 중 하나를 무작위로 고릅니다.
+	TableRandom = "random"
+)
+
+// tableNamePattern은 대상 테이블 이름 형식입니다 (소문자 식별자, 선택적으로 schema.table).
+// 따옴표로 감싸 인라인하므로 대문자를 허용하면 따옴표 없이 만든 테이블과 이름이 달라집니다.
+var tableNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*(\.[a-z_][a-z0-9_]*)?$`)
+
+// prepareTimeout은 대상 테이블 INSERT 문 준비 하나의 타임아웃입니다.
+const prepareTimeout = 5 * time.Second
+
+// quoteTable은 schema.table 각 부분을 식별자로 인용합니다.
+func quoteTable(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = pq.QuoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// writeTarget은 INSERT 대상 테이블 하나입니다.
+type writeTarget struct {
+	name   string
+	quoted string    // SQL에 인라인할 인용된 이름
+	label  string    // 메트릭 라벨 (예: "insert_batch_100:logs_1")
+	stmt   *sql.Stmt // BatchSize행 INSERT 준비 문 (재생 파일 끝의 짧은 배치는 쿼리를 따로 만듦)
+}
+
+// writeTargets는 한 실행의 INSERT 대상 테이블들입니다. Tables를 지정하지 않으면 nil이고 logs 테이블에 씁니다.
+type writeTargets struct {
+	targets []*writeTarget
+	random  bool
+	next    atomic.Uint64
+}
+
+// prepareTargets는 config.Tables마다 BatchSize행 INSERT 문을 준비합니다. Tables가 비어 있으면 nil을 반환합니다.
+// 테이블이 없거나 컬럼(logs 컬럼 또는 Columns)이 맞지 않으면 준비 단계에서 실패하므로 시작 전에 확인됩니다.
+func (g *Generator) prepareTargets(config *Config) (*writeTargets, error) {
+	if len(config.Tables) == 0 {
+		return nil, nil
+	}
+
+	columns, perRow := insertColumns(config)
+	t := &writeTargets{random: config.TableSelection == TableRandom}
+	for _, name := range config.Tables {
+		target := &writeTarget{
+			name:   name,
+			quoted: quoteTable(name),
+			label:  batchLabel(config) + ":" + name,
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), prepareTimeout)
+		stmt, err := g.db.PrepareContext(ctx, insertQuery(target.quoted, columns, perRow, config.BatchSize)+conflictClause(config))
+		cancel()
+		if err != nil {
+			t.close()
+			return nil, fmt.Errorf("failed to prepare insert into %s: %w", name, err)
+		}
+		target.stmt = stmt
+		t.targets = append(t.targets, target)
+	}
+	return t, nil
+}
+
+// pick은 이번 배치를 넣을 테이블을 고릅니다.
+func (t *writeTargets) pick() *writeTarget {
+	if t.random {
+		return t.targets[rand.Intn(len(t.targets))]
+	}
+	i := t.next.Add(1) - 1
+	return t.targets[i%uint64(len(t.targets))]
+}
+
+// close는 준비한 문을 모두 닫습니다. 모든 워커가 끝난 뒤에 호출해야 합니다.
+func (t *writeTargets) close() {
+	if t == nil {
+		return
+	}
+	for _, target := range t.targets {
+		if target.stmt != nil {
+			target.stmt.Close()
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package load
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// 충돌 처리 방식 (Config.OnConflict)
+const (
+	OnConflictNothing = "nothing" // ON CONFLICT (id) DO NOTHING: 이미 있는 id는 건너뜀
+	OnConflictUpdate  = "update"  // ON CONFLICT (id) DO UPDATE: 이미 있는 id는 새 값으로 덮어씀 (upsert)
+)
+
+// DefaultKeySpace는 ON CONFLICT 모드의 기본 id 공간 크기입니다.
+const DefaultKeySpace = 100000
+
+// upsertKeyBase는 ON CONFLICT 모드가 직접 지정하는 id의 시작값입니다.
+// 일반 INSERT가 시퀀스(BIGSERIAL)로 받는 id와 겹치지 않도록 충분히 큰 값부터 씁니다.
+const upsertKeyBase = int64(1) << 40
+
+// conflictClause는 OnConflict에 해당하는 ON CONFLICT 절을 반환합니다 (사용하지 않으면 빈 문자열).
+// DO UPDATE는 갱신된 행도 RowsAffected에 포함되므로, 새로 들어간 행(xmax = 0)인지 RETURNING으로 돌려받아 셉니다.
+// 갱신 대상 컬럼이 INSERT 목록에 없으면 EXCLUDED에는 기본값(timestamp는 NOW())이 들어 있습니다.
+func conflictClause(config *Config) string {
+	switch config.OnConflict {
+	case OnConflictNothing:
+		return " ON CONFLICT (id) DO NOTHING"
+	case OnConflictUpdate:
+		return " ON CONFLICT (id) DO UPDATE SET level = EXCLUDED.level, service = EXCLUDED.service," +
+			" message = EXCLUDED.message, metadata = EXCLUDED.metadata, timestamp = EXCLUDED.timestamp" +
+			" RETURNING (xmax = 0)"
+	default:
+		return ""
+	}
+}
+
+// randomKeys는 id 공간 [upsertKeyBase, upsertKeyBase+keySpace)에서 서로 다른 id n개를 오름차순으로 고릅니다 (n <= keySpace).
+// 한 문장 안에서 같은 id가 두 번 나오면 DO UPDATE가 실패하므로 중복 없이 고르고,
+// 워커들이 겹치는 행을 항상 같은 순서로 잠그도록 정렬해 배치끼리의 교착 상태를 피합니다.
+func randomKeys(n int, keySpace int64) []int64 {
+	keys := make([]int64, 0, n)
+	if int64(n)*2 > keySpace {
+		// 공간의 절반 이상을 고르면 순열에서 앞부분을 씀 (keySpace는 BatchSize의 2배 미만)
+		for _, k := range rand.Perm(int(keySpace))[:n] {
+			keys = append(keys, upsertKeyBase+int64(k))
+		}
+	} else {
+		seen := make(map[int64]bool, n)
+		for len(keys) < n {
+			k := rand.Int63n(keySpace)
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, upsertKeyBase+k)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// insertRows는 n행 INSERT를 실행하고, ON CONFLICT 모드면 이미 있던 id와 충돌한 행 수를 반환합니다.
+// prepared가 있으면 준비한 문을, 없으면 query를 실행합니다.
+func insertRows(ctx context.Context, tx *sql.Tx, prepared *sql.Stmt, query string, onConflict string, n int, args []interface{}) (int64, error) {
+	if onConflict == OnConflictUpdate {
+		var rows *sql.Rows
+		var err error
+		if prepared != nil {
+			rows, err = tx.StmtContext(ctx, prepared).QueryContext(ctx, args...)
+		} else {
+			rows, err = tx.QueryContext(ctx, query, args...)
+		}
+		if err != nil {
+			return 0, err
+		}
+		defer rows.Close()
+
+		var inserted int64
+		for rows.Next() {
+			var isInsert bool
+			if err := rows.Scan(&isInsert); err != nil {
+				return 0, err
+			}
+			if isInsert {
+				inserted++
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		return int64(n) - inserted, nil
+	}
+
+	var result sql.Result
+	var err error
+	if prepared != nil {
+		result, err = tx.StmtContext(ctx, prepared).ExecContext(ctx, args...)
+	} else {
+		result, err = tx.ExecContext(ctx, query, args...)
+	}
+	if err != nil || onConflict == "" {
+		return 0, err
+	}
+	// DO NOTHING은 들어간 행만 RowsAffected에 셈
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int64(n) - affected, nil
+}
+
+// validateOnConflict는 OnConflict와 KeySpace를 검증하고 기본값을 채웁니다.
+func (c *Config) validateOnConflict() error {
+	switch c.OnConflict {
+	case "":
+		return nil
+	case OnConflictNothing, OnConflictUpdate:
+	default:
+		return fmt.Errorf("on_conflict must be %q or %q, got %q", OnConflictNothing, OnConflictUpdate, c.OnConflict)
+	}
+
+	if c.KeySpace <= 0 {
+		c.KeySpace = DefaultKeySpace
+	}
+	if c.KeySpace < int64(c.BatchSize) {
+		return fmt.Errorf("key_space (%d) must be at least batch_size (%d)", c.KeySpace, c.BatchSize)
+	}
+
+	// 아래 모드는 id를 지정하지 않는 INSERT를 전제로 함
+	switch {
+	case len(c.Columns) > 0:
+		return fmt.Errorf("on_conflict cannot be combined with columns")
+	case c.ReadYourWrites:
+		return fmt.Errorf("on_conflict cannot be combined with read_your_writes")
+	case c.SteadyState:
+		return fmt.Errorf("on_conflict cannot be combined with steady_state (it deletes batch_size rows even when rows conflicted)")
+	}
+	return nil
+}
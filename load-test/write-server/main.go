@@ -2,22 +2,39 @@ package main
 
 import (
 	"database/sql"
+	"expvar"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
+	"write-server/audit"
+	"write-server/grpcapi"
 	"write-server/handler"
 	"write-server/load"
 	"write-server/metrics"
+	"write-server/pb"
 
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
 )
 
+// 연결 풀 최대 연결 수 (/debug/config에 표시)
+const maxOpenConns = 50
+
 func main() {
+	// 부하 설정 파일 (지정 시 시작할 때 적용하고, SIGHUP을 받으면 다시 읽음)
+	configPath := flag.String("config", "", "path to load config JSON file (reloaded on SIGHUP)")
+	// 실행 중 초당 메트릭을 덧붙일 CSV 파일 (스프레드시트 분석용)
+	metricsCSVPath := flag.String("metrics-csv", "", "append one CSV row of metrics per second during each run to this file")
+	flag.Parse()
+
 	// 환경 변수 읽기
 	dbHost := getEnv("DB_HOST", "localhost")
 	dbPort := getEnv("DB_PORT", "5432")
@@ -25,12 +42,90 @@ func main() {
 	dbUser := getEnv("DB_USER", "postgres")
 	dbPassword := getEnv("DB_PASSWORD", "postgres")
 	serverPort := getEnv("SERVER_PORT", "8080")
+	auditLogFile := getEnv("AUDIT_LOG_FILE", "")
+	grpcPort := getEnv("GRPC_PORT", "9080")
+	// read-your-writes 검증에서 다시 읽을 DB (예: 레플리카, 미지정 시 쓰기 DB)
+	readDBHost := getEnv("READ_DB_HOST", "")
+	readDBPort := getEnv("READ_DB_PORT", dbPort)
+
+	// 연결 풀 설정 (연결 재생성(churn)은 /metrics의 pool로 확인)
+	maxIdleConns, err := strconv.Atoi(getEnv("DB_MAX_IDLE_CONNS", "10"))
+	if err != nil {
+		log.Fatalf("Invalid DB_MAX_IDLE_CONNS: %v", err)
+	}
+	connMaxLifetime, err := time.ParseDuration(getEnv("DB_CONN_MAX_LIFETIME", "1h"))
+	if err != nil {
+		log.Fatalf("Invalid DB_CONN_MAX_LIFETIME: %v", err)
+	}
+
+	// HTTP 서버 타임아웃 (0 = 제한 없음)
+	// 읽기/쓰기 타임아웃은 가장 오래 걸리는 요청(큰 배치 INSERT, /db/maintenance 등)보다 길어야 함
+	readTimeout, err := durationEnv("HTTP_READ_TIMEOUT", "15s")
+	if err != nil {
+		log.Fatalf("Invalid HTTP_READ_TIMEOUT: %v", err)
+	}
+	writeTimeout, err := durationEnv("HTTP_WRITE_TIMEOUT", "15s")
+	if err != nil {
+		log.Fatalf("Invalid HTTP_WRITE_TIMEOUT: %v", err)
+	}
+	idleTimeout, err := durationEnv("HTTP_IDLE_TIMEOUT", "60s")
+	if err != nil {
+		log.Fatalf("Invalid HTTP_IDLE_TIMEOUT: %v", err)
+	}
+
+	// /readyz 판정 기준 (최근 윈도우 에러율, DB ping 지연)
+	var healthThresholds handler.HealthThresholds
+	healthThresholds.Window, err = durationEnv("READYZ_WINDOW", handler.DefaultHealthWindow.String())
+	if err != nil {
+		log.Fatalf("Invalid READYZ_WINDOW: %v", err)
+	}
+	healthThresholds.DegradedErrorRate, err = strconv.ParseFloat(getEnv("READYZ_DEGRADED_ERROR_RATE", strconv.FormatFloat(handler.DefaultDegradedErrorRate, 'g', -1, 64)), 64)
+	if err != nil {
+		log.Fatalf("Invalid READYZ_DEGRADED_ERROR_RATE: %v", err)
+	}
+	healthThresholds.UnhealthyErrorRate, err = strconv.ParseFloat(getEnv("READYZ_UNHEALTHY_ERROR_RATE", strconv.FormatFloat(handler.DefaultUnhealthyErrorRate, 'g', -1, 64)), 64)
+	if err != nil {
+		log.Fatalf("Invalid READYZ_UNHEALTHY_ERROR_RATE: %v", err)
+	}
+	healthThresholds.DegradedPingLatency, err = durationEnv("READYZ_DEGRADED_PING", handler.DefaultDegradedPingLatency.String())
+	if err != nil {
+		log.Fatalf("Invalid READYZ_DEGRADED_PING: %v", err)
+	}
+	if err := healthThresholds.Validate(); err != nil {
+		log.Fatalf("Invalid READYZ_* thresholds: %v", err)
+	}
+
+	// 쓰기 API 요청 크기 제한 (본문 크기, 배치 최대 행 수, 트랜잭션을 나누는 청크 크기와 동시 실행 수)
+	var batchLimits handler.BatchLimits
+	batchLimits.MaxBodyBytes, err = strconv.ParseInt(getEnv("MAX_BODY_BYTES", strconv.Itoa(handler.DefaultMaxBodyBytes)), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid MAX_BODY_BYTES: %v", err)
+	}
+	batchLimits.MaxRows, err = strconv.Atoi(getEnv("MAX_BATCH_ROWS", strconv.Itoa(handler.DefaultMaxBatchRows)))
+	if err != nil {
+		log.Fatalf("Invalid MAX_BATCH_ROWS: %v", err)
+	}
+	batchLimits.ChunkSize, err = strconv.Atoi(getEnv("BATCH_CHUNK_SIZE", strconv.Itoa(handler.DefaultBatchChunkSize)))
+	if err != nil {
+		log.Fatalf("Invalid BATCH_CHUNK_SIZE: %v", err)
+	}
+	batchLimits.Parallelism, err = strconv.Atoi(getEnv("BATCH_PARALLELISM", strconv.Itoa(handler.DefaultBatchParallelism)))
+	if err != nil {
+		log.Fatalf("Invalid BATCH_PARALLELISM: %v", err)
+	}
+	if batchLimits.Parallelism > handler.MaxBatchParallelism {
+		log.Fatalf("Invalid BATCH_PARALLELISM: %d exceeds max %d", batchLimits.Parallelism, handler.MaxBatchParallelism)
+	}
+
+	// 추가 연결 파라미터 (예: "connect_timeout=5 target_session_attrs=read-write")
+	// application_name 기본값으로 pg_stat_activity에서 부하 연결을 구분
+	dbParams, err := connParams("loadtest-write-server", getEnv("DB_EXTRA_PARAMS", ""))
+	if err != nil {
+		log.Fatalf("Invalid DB_EXTRA_PARAMS: %v", err)
+	}
 
 	// PostgreSQL 연결
-	connStr := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		dbHost, dbPort, dbUser, dbPassword, dbName,
-	)
+	connStr := connString(dbHost, dbPort, dbUser, dbPassword, dbName, dbParams)
 
 	log.Printf("Connecting to PostgreSQL at %s:%s/%s", dbHost, dbPort, dbName)
 
@@ -41,9 +136,9 @@ func main() {
 	defer db.Close()
 
 	// 연결 풀 설정
-	db.SetMaxOpenConns(50)
-	db.SetMaxIdleConns(10)
-	db.SetConnMaxLifetime(time.Hour)
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns)
+	db.SetConnMaxLifetime(connMaxLifetime)
 
 	// DB 연결 확인
 	if err := db.Ping(); err != nil {
@@ -54,17 +149,105 @@ func main() {
 
 	// 메트릭 컬렉터 초기화
 	collector := metrics.NewCollector()
+	collector.SetDB(db)
 
 	// 부하 생성기 초기화
 	defaultConfig := load.DefaultConfig()
+	if *configPath != "" {
+		defaultConfig, err = load.LoadConfigFile(*configPath)
+		if err != nil {
+			log.Fatalf("Failed to load config: %v", err)
+		}
+		log.Printf("Loaded load config from %s", *configPath)
+	}
 	generator := load.NewGenerator(db, defaultConfig, collector)
+	generator.SetPoolDefaults(load.PoolSettings{
+		MaxOpenConns:    maxOpenConns,
+		MaxIdleConns:    maxIdleConns,
+		ConnMaxLifetime: connMaxLifetime,
+	})
+	collector.SetTargetRate(generator.TargetRate)
+	if *metricsCSVPath != "" {
+		generator.SetMetricsCSV(*metricsCSVPath)
+		log.Printf("Writing per-second metrics CSV to %s", *metricsCSVPath)
+	}
+
+	if readDBHost != "" {
+		readConnStr := connString(readDBHost, readDBPort, dbUser, dbPassword, dbName, dbParams)
+
+		log.Printf("Connecting to read PostgreSQL at %s:%s/%s", readDBHost, readDBPort, dbName)
+
+		readDB, err := sql.Open("postgres", readConnStr)
+		if err != nil {
+			log.Fatalf("Failed to connect to read database: %v", err)
+		}
+		defer readDB.Close()
+
+		readDB.SetMaxOpenConns(maxOpenConns)
+		readDB.SetMaxIdleConns(maxIdleConns)
+		readDB.SetConnMaxLifetime(connMaxLifetime)
+
+		if err := readDB.Ping(); err != nil {
+			log.Fatalf("Failed to ping read database: %v", err)
+		}
+		generator.SetReadDB(readDB)
+	}
+
+	// 감사 로그 초기화 (AUDIT_LOG_FILE 미지정 시 표준 출력)
+	auditLog, err := audit.Open(auditLogFile)
+	if err != nil {
+		log.Fatalf("Failed to open audit log: %v", err)
+	}
+	defer auditLog.Close()
 
 	// 핸들러 초기화
-	writeHandler := handler.NewWriteHandler(db, collector)
-	loadHandler := handler.NewLoadHandler(generator, collector)
+	writeHandler := handler.NewWriteHandler(db, collector, batchLimits)
+	loadHandler := handler.NewLoadHandler(generator, collector, auditLog)
+	dbHandler := handler.NewDBHandler(db)
+	healthChecker := handler.NewHealthChecker(db, collector, healthThresholds)
+	healthChecker.Start()
+
+	// /debug/config에 보여 줄 실제 설정 (DB 비밀번호 제외)
+	runtimeConfig := handler.RuntimeConfig{
+		Database: handler.DatabaseSettings{
+			Host:            dbHost,
+			Port:            dbPort,
+			Name:            dbName,
+			User:            dbUser,
+			MaxOpenConns:    maxOpenConns,
+			MaxIdleConns:    maxIdleConns,
+			ConnMaxLifetime: connMaxLifetime.String(),
+			Params:          paramMap(dbParams),
+		},
+		Server: handler.ServerSettings{
+			HTTPPort:     serverPort,
+			GRPCPort:     grpcPort,
+			ReadTimeout:  readTimeout.String(),
+			WriteTimeout: writeTimeout.String(),
+			IdleTimeout:  idleTimeout.String(),
+			AuditLogFile: auditLogFile,
+			ConfigFile:   *configPath,
+		},
+		Limits: writeHandler.Limits(),
+		Env:    handler.EnvSnapshot(envKeys),
+	}
+	if readDBHost != "" {
+		readDatabase := runtimeConfig.Database
+		readDatabase.Host = readDBHost
+		readDatabase.Port = readDBPort
+		runtimeConfig.ReadDatabase = &readDatabase
+	}
+
+	// HTTP 라우트별 요청 수/처리 시간 (/metrics/http, /debug/vars)
+	httpMetrics := metrics.NewHTTPMetrics()
+	expvar.Publish("http_routes", expvar.Func(func() any { return httpMetrics.Snapshot() }))
 
 	// 라우터 설정
 	router := mux.NewRouter()
+	router.Use(handler.HTTPMetricsMiddleware(httpMetrics))
+	// 없는 경로/메서드도 다른 에러와 같은 JSON 형식으로 응답
+	router.NotFoundHandler = http.HandlerFunc(handler.NotFound)
+	router.MethodNotAllowedHandler = http.HandlerFunc(handler.MethodNotAllowed)
 
 	// 로그 INSERT API
 	router.HandleFunc("/logs", writeHandler.InsertLog).Methods("POST")
@@ -75,25 +258,44 @@ func main() {
 	router.HandleFunc("/load/stop", loadHandler.Stop).Methods("POST")
 	router.HandleFunc("/load/config", loadHandler.GetConfig).Methods("GET")
 	router.HandleFunc("/load/config", loadHandler.UpdateConfig).Methods("POST")
+	router.HandleFunc("/load/config/profile", loadHandler.UpdateConfigFromProfile).Methods("POST")
+	router.HandleFunc("/load/tps", loadHandler.SetTPS).Methods("POST")
 	router.HandleFunc("/load/status", loadHandler.GetStatus).Methods("GET")
+	router.HandleFunc("/load/status/history", loadHandler.GetStatusHistory).Methods("GET")
+	router.HandleFunc("/load/run/export", loadHandler.ExportRun).Methods("GET")
 
 	// 메트릭 API
 	router.HandleFunc("/metrics", loadHandler.GetMetrics).Methods("GET")
 	router.HandleFunc("/metrics/reset", loadHandler.ResetMetrics).Methods("POST")
+	router.HandleFunc("/metrics/baseline", loadHandler.SaveBaseline).Methods("POST")
+	router.HandleFunc("/metrics/compare/stats", loadHandler.CompareStats).Methods("GET")
+	router.HandleFunc("/metrics/compare/import", loadHandler.CompareImport).Methods("POST")
+	router.HandleFunc("/metrics/heatmap", loadHandler.GetHeatmap).Methods("GET")
+	router.HandleFunc("/metrics/prometheus", loadHandler.GetPrometheusMetrics).Methods("GET")
+	router.HandleFunc("/metrics/http", handler.HTTPMetricsHandler(httpMetrics)).Methods("GET")
+	router.Handle("/debug/vars", expvar.Handler()).Methods("GET")
+	router.HandleFunc("/debug/config", handler.DebugConfigHandler(runtimeConfig, generator)).Methods("GET")
+
+	// DB 통계 API (PostgreSQL 쪽 집계)
+	router.HandleFunc("/db/query-stats", dbHandler.QueryStats).Methods("GET")
+	router.HandleFunc("/db/table-stats", dbHandler.TableStats).Methods("GET")
+	router.HandleFunc("/db/maintenance", dbHandler.Maintenance).Methods("POST")
+	router.HandleFunc("/db/locks", dbHandler.Locks).Methods("GET")
 
 	// 헬스체크
 	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintf(w, "OK")
 	}).Methods("GET")
+	router.HandleFunc("/readyz", healthChecker.Readyz).Methods("GET")
 
 	// HTTP 서버 시작
 	srv := &http.Server{
 		Addr:         ":" + serverPort,
 		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+		IdleTimeout:  idleTimeout,
 	}
 
 	// Graceful shutdown 설정
@@ -104,13 +306,36 @@ func main() {
 		}
 	}()
 
+	// gRPC 부하 제어 서버 시작 (HTTP와 별도 포트)
+	grpcServer := grpc.NewServer()
+	pb.RegisterLoadControlServer(grpcServer, grpcapi.NewServer(generator, collector))
+
+	go func() {
+		lis, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatalf("Failed to listen on gRPC port: %v", err)
+		}
+		log.Printf("gRPC load control listening on port %s", grpcPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}()
+
 	// 종료 시그널 대기
+	// 종료 시그널을 받을 때까지 SIGHUP마다 설정 파일을 다시 적용
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-	<-sigCh
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigCh {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		reloadConfig(generator, auditLog, *configPath)
+	}
 
 	log.Println("Shutting down server...")
 
+	grpcServer.GracefulStop()
+
 	// 부하 생성기 중지
 	if generator.IsRunning() {
 		log.Println("Stopping load generator...")
@@ -120,6 +345,69 @@ func main() {
 	log.Println("Server stopped")
 }
 
+// reloadConfig는 설정 파일을 다시 읽어 부하 생성기에 적용하고 결과를 로그로 남깁니다.
+// 실행 중이면 새 설정으로 재시작하며, 누적 메트릭은 유지됩니다.
+func reloadConfig(generator *load.Generator, auditLog *audit.Logger, path string) {
+	if path == "" {
+		log.Println("Received SIGHUP but no -config file was given, ignoring")
+		return
+	}
+
+	config, err := load.LoadConfigFile(path)
+	if err != nil {
+		log.Printf("Config reload failed, keeping current config: %v", err)
+		return
+	}
+
+	before := *generator.GetConfig()
+	restarted, err := generator.Reload(config)
+	if err != nil {
+		log.Printf("Config reload failed, keeping current config: %v", err)
+		return
+	}
+
+	auditLog.Record(audit.Event{
+		Action: "config_reload",
+		Remote: "SIGHUP",
+		Detail: path,
+		Before: before,
+		After:  *config,
+	})
+
+	if restarted {
+		log.Printf("Config reloaded from %s, load generator restarted with new config", path)
+	} else {
+		log.Printf("Config reloaded from %s", path)
+	}
+}
+
+// envKeys는 서버가 읽는 환경 변수 목록입니다 (/debug/config의 env).
+var envKeys = []string{
+	"DB_HOST",
+	"DB_PORT",
+	"DB_NAME",
+	"DB_USER",
+	"DB_PASSWORD",
+	"DB_MAX_IDLE_CONNS",
+	"DB_CONN_MAX_LIFETIME",
+	"READ_DB_HOST",
+	"READ_DB_PORT",
+	"SERVER_PORT",
+	"GRPC_PORT",
+	"AUDIT_LOG_FILE",
+	"HTTP_READ_TIMEOUT",
+	"HTTP_WRITE_TIMEOUT",
+	"HTTP_IDLE_TIMEOUT",
+	"READYZ_WINDOW",
+	"READYZ_DEGRADED_ERROR_RATE",
+	"READYZ_UNHEALTHY_ERROR_RATE",
+	"READYZ_DEGRADED_PING",
+	"MAX_BODY_BYTES",
+	"MAX_BATCH_ROWS",
+	"BATCH_CHUNK_SIZE",
+	"BATCH_PARALLELISM",
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -127,3 +415,15 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// durationEnv는 Go duration 형식(예: 30s, 5m)의 환경 변수를 읽습니다. 음수는 에러입니다.
+func durationEnv(key, defaultValue string) (time.Duration, error) {
+	d, err := time.ParseDuration(getEnv(key, defaultValue))
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative, got %s", d)
+	}
+	return d, nil
+}
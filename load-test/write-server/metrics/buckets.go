@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+)
+
+// LatencyBucketEdges는 지연시간 히스토그램 버킷의 경계(ms, 오름차순)입니다.
+// 버킷은 [0, e1), [e1, e2), ..., [eN, +∞) 로 구성되며, 필요하면 서버 시작 시 변경할 수 있습니다.
+var LatencyBucketEdges = []float64{1, 5, 10, 50, 100, 500}
+
+// LatencyBucket은 지연시간 히스토그램의 한 구간입니다.
+type LatencyBucket struct {
+	Range string  `json:"range"`  // 예: "1-5", "500+"
+	MinMs float64 `json:"min_ms"` // 포함
+	MaxMs float64 `json:"max_ms"` // 미포함 (마지막 버킷은 0 = 상한 없음)
+	Count int64   `json:"count"`
+}
+
+// newLatencyBuckets는 LatencyBucketEdges 기준의 빈 히스토그램 구간을 만듭니다 (latencyHistogram.latencyBuckets).
+func newLatencyBuckets() []LatencyBucket {
+	edges := LatencyBucketEdges
+	buckets := make([]LatencyBucket, len(edges)+1)
+
+	lower := 0.0
+	for i, edge := range edges {
+		buckets[i] = LatencyBucket{
+			Range: fmt.Sprintf("%g-%g", lower, edge),
+			MinMs: lower,
+			MaxMs: edge,
+		}
+		lower = edge
+	}
+	buckets[len(edges)] = LatencyBucket{
+		Range: fmt.Sprintf("%g+", lower),
+		MinMs: lower,
+	}
+
+	return buckets
+}
+
+// bucketIndex는 latency가 속하는 LatencyBucketEdges 버킷의 인덱스를 반환합니다.
+func bucketIndex(latency time.Duration) int {
+	ms := float64(latency) / float64(time.Millisecond)
+	i := 0
+	for i < len(LatencyBucketEdges) && ms >= LatencyBucketEdges[i] {
+		i++
+	}
+	return i
+}
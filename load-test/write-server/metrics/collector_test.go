@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Reset 이전에 시작한 배치는 성공이든 실패든 기록되지 않고, 이후에 시작한 배치만 기록되는지 확인합니다.
+func TestResetDropsRequestsStartedBefore(t *testing.T) {
+	c := NewCollector()
+	c.Reset()
+	before := time.Since(c.current.Load().start) + time.Hour // 확실히 Reset 이전에 시작한 배치
+
+	c.RecordSuccess("insert", before, 10, 100)
+	c.RecordConflicts(before, 2)
+	c.RecordDeleted(before, 3)
+	c.RecordFailure("insert", before, 10)
+	c.RecordTimeout("insert", before, 10)
+	c.RecordConnError("insert", before, 10)
+	c.RecordFailureWithError("insert", before, 10, errors.New("boom"))
+	c.RecordAborted(before, 10)
+
+	m := c.GetMetrics()
+	if m.TotalRequests != 0 || m.FailedRequests != 0 || m.TimeoutRequests != 0 || m.ConnectionErrors != 0 || m.AbortedRequests != 0 {
+		t.Fatalf("batches started before Reset were recorded: %+v", m)
+	}
+	if m.Conflicts != 0 || m.RowsDeleted != 0 || len(m.FailuresByCode) != 0 {
+		t.Fatalf("conflicts = %d, rows_deleted = %d, failures_by_code = %v, want none", m.Conflicts, m.RowsDeleted, m.FailuresByCode)
+	}
+
+	c.RecordSuccess("insert", 0, 10, 100)
+	c.RecordConflicts(0, 2)
+	c.RecordDeleted(0, 3)
+	c.RecordTimeout("insert", 0, 10)
+	c.RecordConnError("insert", 0, 10)
+	c.RecordFailureWithError("insert", 0, 10, errors.New("boom"))
+	c.RecordAborted(0, 10)
+
+	m = c.GetMetrics()
+	if m.TotalRequests != 40 || m.SuccessRequests != 10 || m.FailedRequests != 10 || m.TimeoutRequests != 10 || m.ConnectionErrors != 10 || m.AbortedRequests != 10 {
+		t.Fatalf("batches started after Reset: %+v", m)
+	}
+	if m.Conflicts != 2 || m.RowsDeleted != 3 {
+		t.Fatalf("conflicts = %d, rows_deleted = %d, want 2 and 3", m.Conflicts, m.RowsDeleted)
+	}
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+)
+
+// CommitLatency는 성공한 트랜잭션에서 tx.Commit() 호출만 따로 잰 지연시간 분포입니다 (INSERT 실행 제외).
+// 작은 배치에서는 커밋(WAL fsync) 비용이 트랜잭션 지연시간의 대부분이며, 배치를 키우면 한 번의 커밋 비용을 여러 행이 나눠 냅니다.
+// 1ms 미만의 커밋도 흔하므로 다른 지연시간 필드와 달리 ms 단위 소수로 보고합니다.
+type CommitLatency struct {
+	Samples    int     `json:"samples"` // 기록된 커밋 샘플 수 (LatencySampleRate, 워밍업 제외 적용)
+	AvgLatency float64 `json:"avg_latency_ms"`
+	P50Latency float64 `json:"p50_latency_ms"`
+	P95Latency float64 `json:"p95_latency_ms"`
+	P99Latency float64 `json:"p99_latency_ms"`
+	PerRow     float64 `json:"per_row_ms"` // 커밋 시간 합 / 커밋한 행 수 (배치가 커밋 비용을 나누는 정도)
+	Share      float64 `json:"share"`      // 평균 커밋 시간 / 평균 트랜잭션 지연시간 (avg_latency_ms)
+}
+
+// RecordCommit은 성공한 트랜잭션의 커밋 시간과 그 커밋에 포함된 행 수를 기록합니다.
+// latency는 RecordSuccess에 넘긴 트랜잭션 전체 지연시간으로, 마지막 Reset 이전이나 워밍업 구간에 시작한 트랜잭션을 가려내는 데 씁니다.
+// 샤드 하나에 바로 합치므로, 부하 생성기 워커는 대신 Recorder를 씁니다.
+func (c *Collector) RecordCommit(latency, commit time.Duration, rows int) {
+	c.addSamples(nil, []commitSample{{end: time.Now(), latency: latency, commit: commit, rows: rows}})
+}
+
+// commitLatency는 커밋 시간 분포를 계산합니다. 기록된 커밋이 없으면 nil입니다.
+// avgLatency는 같은 구간의 평균 트랜잭션 지연시간(ms)입니다.
+func commitLatency(latencies []time.Duration, rows int64, avgLatency float64) *CommitLatency {
+	if len(latencies) == 0 {
+		return nil
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	var sum time.Duration
+	for _, lat := range sorted {
+		sum += lat
+	}
+
+	result := &CommitLatency{
+		Samples:    len(sorted),
+		AvgLatency: durationMs(sum) / float64(len(sorted)),
+		P50Latency: durationMs(sorted[percentileIndex(len(sorted), 50)]),
+		P95Latency: durationMs(sorted[percentileIndex(len(sorted), 95)]),
+		P99Latency: durationMs(sorted[percentileIndex(len(sorted), 99)]),
+	}
+	if rows > 0 {
+		result.PerRow = durationMs(sum) / float64(rows)
+	}
+	if avgLatency > 0 {
+		result.Share = result.AvgLatency / avgLatency
+	}
+	return result
+}
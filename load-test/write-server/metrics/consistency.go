@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+)
+
+// ReadYourWrites는 read-your-writes 검증 모드의 결과입니다.
+// 단일 primary에서는 항상 Misses = 0이어야 하며, 레플리카에서 읽으면 복제 지연이 드러납니다.
+type ReadYourWrites struct {
+	Checks     int64   `json:"checks"`     // INSERT 직후 다시 읽어본 횟수
+	Misses     int64   `json:"misses"`     // 첫 읽기에서 행이 보이지 않은 횟수
+	Unresolved int64   `json:"unresolved"` // 대기 시간 안에 끝내 보이지 않은 횟수 (Misses에 포함)
+	MissRate   float64 `json:"miss_rate"`  // Misses / Checks
+
+	// 첫 읽기에서 놓친 행이 보이기까지 걸린 시간 분포 (Unresolved 제외)
+	LagP50 float64 `json:"lag_p50_ms"`
+	LagP95 float64 `json:"lag_p95_ms"`
+	LagP99 float64 `json:"lag_p99_ms"`
+	LagMax float64 `json:"lag_max_ms"`
+}
+
+// RecordReadCheck는 INSERT한 행을 다시 읽어본 결과를 기록합니다.
+// lag는 커밋 후 행이 처음 보일 때까지 걸린 시간이며, 첫 읽기에서 바로 보였다면 0입니다.
+// visible이 false면 대기 시간 안에 끝내 보이지 않은 경우입니다. elapsed는 INSERT를 시작한 뒤 지금까지 걸린 시간입니다.
+func (c *Collector) RecordReadCheck(elapsed, lag time.Duration, visible bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.startedBeforeReset(time.Now(), elapsed) {
+		return
+	}
+
+	c.readChecks++
+	switch {
+	case !visible:
+		c.readMisses++
+		c.readUnresolved++
+	case lag > 0:
+		c.readMisses++
+		if len(c.readLags) < maxLatencySamples {
+			c.readLags = append(c.readLags, lag)
+		}
+	}
+}
+
+// readYourWrites는 검증 결과를 집계합니다. 검증 모드로 실행하지 않았으면 nil입니다.
+// c.mu를 잡은 상태에서 호출해야 합니다.
+func (c *Collector) readYourWrites() *ReadYourWrites {
+	if c.readChecks == 0 {
+		return nil
+	}
+
+	result := &ReadYourWrites{
+		Checks:     c.readChecks,
+		Misses:     c.readMisses,
+		Unresolved: c.readUnresolved,
+		MissRate:   float64(c.readMisses) / float64(c.readChecks),
+	}
+
+	if len(c.readLags) > 0 {
+		sorted := make([]time.Duration, len(c.readLags))
+		copy(sorted, c.readLags)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i] < sorted[j]
+		})
+
+		result.LagP50 = durationMs(sorted[percentileIndex(len(sorted), 50)])
+		result.LagP95 = durationMs(sorted[percentileIndex(len(sorted), 95)])
+		result.LagP99 = durationMs(sorted[percentileIndex(len(sorted), 99)])
+		result.LagMax = durationMs(sorted[len(sorted)-1])
+	}
+
+	return result
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// RouteMetrics는 HTTP 라우트(메서드 + 경로 템플릿) 하나의 요청 수와 지연시간 분포입니다.
+// 부하 생성기가 실행하는 DB 쿼리와 별개로, HTTP 처리(JSON 인코딩 등)까지 포함한 시간을 측정합니다.
+type RouteMetrics struct {
+	Method         string          `json:"method"`
+	Path           string          `json:"path"`
+	Requests       int64           `json:"requests"`
+	Errors         int64           `json:"errors"` // 5xx 응답 수
+	AvgLatency     float64         `json:"avg_latency_ms"`
+	MaxLatency     float64         `json:"max_latency_ms"`
+	LatencyBuckets []LatencyBucket `json:"latency_buckets"` // LatencyBucketEdges 기준
+}
+
+type routeKey struct {
+	method string
+	path   string
+}
+
+type routeStats struct {
+	requests int64
+	errors   int64
+	sum      time.Duration
+	max      time.Duration
+	buckets  []int64 // len(LatencyBucketEdges)+1
+}
+
+// HTTPMetrics는 라우트별 HTTP 요청 수와 지연시간 히스토그램을 누적합니다.
+// 버킷 수만 저장하므로 요청 수와 관계없이 메모리 사용량이 일정합니다.
+// 부하 생성 메트릭과 달리 /metrics/reset으로 초기화되지 않습니다 (프로세스 수명 동안 누적).
+type HTTPMetrics struct {
+	mu     sync.Mutex
+	routes map[routeKey]*routeStats
+}
+
+func NewHTTPMetrics() *HTTPMetrics {
+	return &HTTPMetrics{
+		routes: make(map[routeKey]*routeStats),
+	}
+}
+
+// Observe는 요청 하나의 처리 결과를 기록합니다.
+func (m *HTTPMetrics) Observe(method, path string, status int, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := routeKey{method: method, path: path}
+	stats, ok := m.routes[key]
+	if !ok {
+		stats = &routeStats{buckets: make([]int64, len(LatencyBucketEdges)+1)}
+		m.routes[key] = stats
+	}
+
+	stats.requests++
+	if status >= 500 {
+		stats.errors++
+	}
+	stats.sum += latency
+	if latency > stats.max {
+		stats.max = latency
+	}
+	stats.buckets[bucketIndex(latency)]++
+}
+
+// Snapshot은 라우트별 메트릭을 경로, 메서드 순으로 정렬해 반환합니다.
+func (m *HTTPMetrics) Snapshot() []RouteMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]RouteMetrics, 0, len(m.routes))
+	for key, stats := range m.routes {
+		buckets := newLatencyBuckets()
+		for i := range buckets {
+			buckets[i].Count = stats.buckets[i]
+		}
+
+		result = append(result, RouteMetrics{
+			Method:         key.method,
+			Path:           key.path,
+			Requests:       stats.requests,
+			Errors:         stats.errors,
+			AvgLatency:     float64(stats.sum) / float64(time.Millisecond) / float64(stats.requests),
+			MaxLatency:     float64(stats.max) / float64(time.Millisecond),
+			LatencyBuckets: buckets,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Path != result[j].Path {
+			return result[i].Path < result[j].Path
+		}
+		return result[i].Method < result[j].Method
+	})
+	return result
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import "sync/atomic"
+
+// LabelMetrics는 작업 라벨(배치 크기, API 경로 등) 하나에 대한 메트릭입니다.
+type LabelMetrics struct {
+	TotalRequests    int64   `json:"total_requests"`
+	SuccessRequests  int64   `json:"success_requests"`
+	FailedRequests   int64   `json:"failed_requests"`
+	TimeoutRequests  int64   `json:"timeout_requests"`
+	ConnectionErrors int64   `json:"connection_errors"`
+	BytesWritten     int64   `json:"bytes_written"`
+	AvgLatency       float64 `json:"avg_latency_ms"`
+	P50Latency       float64 `json:"p50_latency_ms"`
+	P95Latency       float64 `json:"p95_latency_ms"`
+	P99Latency       float64 `json:"p99_latency_ms"`
+}
+
+// labelCounters는 카운터 묶음 하나의 라벨별 누적 카운터입니다 (counters.labels). 기록 경로에서 잠금 없이 더합니다.
+// 라벨별 지연시간은 샤드에 따로 둡니다 (shard.labels).
+type labelCounters struct {
+	totalRequests   atomic.Int64
+	successRequests atomic.Int64
+	failedRequests  atomic.Int64
+	timeoutRequests atomic.Int64
+	connErrors      atomic.Int64
+	bytesWritten    atomic.Int64
+}
+
+// labelTotals는 모든 카운터 묶음의 같은 라벨을 합친 값입니다 (window.totals).
+type labelTotals struct {
+	totalRequests   int64
+	successRequests int64
+	failedRequests  int64
+	timeoutRequests int64
+	connErrors      int64
+	bytesWritten    int64
+}
+
+func (t *labelTotals) add(lc *labelCounters) {
+	t.totalRequests += lc.totalRequests.Load()
+	t.successRequests += lc.successRequests.Load()
+	t.failedRequests += lc.failedRequests.Load()
+	t.timeoutRequests += lc.timeoutRequests.Load()
+	t.connErrors += lc.connErrors.Load()
+	t.bytesWritten += lc.bytesWritten.Load()
+}
+
+// labelLatencies는 label의 지연시간 히스토그램을 반환하며, 처음 보는 라벨이면 새로 만듭니다.
+// 빈 라벨은 합계에만 반영하므로 nil을 반환합니다. sh.mu를 잡은 상태에서 호출해야 합니다.
+func (sh *shard) labelLatencies(label string) *latencyHistogram {
+	if label == "" {
+		return nil
+	}
+	if sh.labels == nil {
+		sh.labels = make(map[string]*latencyHistogram)
+	}
+	h, ok := sh.labels[label]
+	if !ok {
+		h = &latencyHistogram{}
+		sh.labels[label] = h
+	}
+	return h
+}
+
+// labelMetrics는 합친 카운터(window.totals)의 라벨별 카운터와 샤드를 합친 사본(Collector.merged)의 라벨별 지연시간으로 메트릭을 계산합니다.
+func labelMetrics(labels map[string]*labelTotals, merged *shard) map[string]LabelMetrics {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	byLabel := make(map[string]LabelMetrics, len(labels))
+	for label, lt := range labels {
+		var avg, p50, p95, p99 float64
+		if h := merged.labels[label]; h != nil {
+			avg, p50, p95, p99 = h.summarize()
+		}
+		byLabel[label] = LabelMetrics{
+			TotalRequests:    lt.totalRequests,
+			SuccessRequests:  lt.successRequests,
+			FailedRequests:   lt.failedRequests,
+			TimeoutRequests:  lt.timeoutRequests,
+			ConnectionErrors: lt.connErrors,
+			BytesWritten:     lt.bytesWritten,
+			AvgLatency:       avg,
+			P50Latency:       p50,
+			P95Latency:       p95,
+			P99Latency:       p99,
+		}
+	}
+	return byLabel
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import "time"
+
+// outage는 데이터베이스 연결이 끊긴 구간의 기록입니다 (Collector.mu로 보호).
+// 부하 생성기가 연결 에러를 보면 RecordDisconnect로 구간을 열고, 백오프 뒤 처음 성공하면 RecordRecovered로 닫습니다.
+type outage struct {
+	down       bool
+	downSince  time.Time
+	reconnects int64
+	downtime   time.Duration // 닫힌 구간의 합 (진행 중인 구간 제외)
+}
+
+// RecordDisconnect는 연결 에러로 연결이 끊긴 구간이 시작되었음을 기록합니다.
+// 이미 끊긴 구간 안이면 아무것도 하지 않고 false를, 새 구간을 열었으면 true를 반환합니다.
+func (c *Collector) RecordDisconnect(now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.outage.down {
+		return false
+	}
+	c.outage.down = true
+	c.outage.downSince = now
+	return true
+}
+
+// RecordRecovered는 끊긴 구간 뒤 요청이 다시 성공했음을 기록하고 그 구간의 길이를 반환합니다.
+// 끊긴 구간이 없었으면(다른 워커가 이미 닫았으면) false를 반환합니다.
+func (c *Collector) RecordRecovered(now time.Time) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.outage.down {
+		return 0, false
+	}
+	d := now.Sub(c.outage.downSince)
+	c.outage.down = false
+	c.outage.reconnects++
+	c.outage.downtime += d
+	return d, true
+}
+
+// outageMetrics는 재연결 횟수와 누적 끊김 시간(초, 진행 중인 구간 포함)을 반환합니다. c.mu를 잡은 상태에서 호출해야 합니다.
+func (c *Collector) outageMetrics(now time.Time) (int64, float64) {
+	downtime := c.outage.downtime
+	if c.outage.down {
+		downtime += now.Sub(c.outage.downSince)
+	}
+	return c.outage.reconnects, downtime.Seconds()
+}
+
+// resetOutage는 재연결 횟수와 끊김 시간을 초기화합니다. 끊긴 구간 중이면 지금부터 다시 셉니다. c.mu를 잡은 상태에서 호출해야 합니다.
+func (c *Collector) resetOutage(now time.Time) {
+	c.outage.reconnects = 0
+	c.outage.downtime = 0
+	if c.outage.down {
+		c.outage.downSince = now
+	}
+}
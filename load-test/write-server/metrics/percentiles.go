@@ -0,0 +1,35 @@
+package metrics
+
+import "strconv"
+
+// DefaultPercentiles는 Metrics.Percentiles에 보고하는 기본 백분위수입니다 (p50/p95/p99_latency_ms 필드와 같은 값).
+var DefaultPercentiles = []float64{50, 95, 99}
+
+// MaxPercentiles는 한 번에 보고할 수 있는 최대 백분위수 개수입니다.
+const MaxPercentiles = 20
+
+// PercentileKey는 백분위수 p의 Metrics.Percentiles 키입니다 (예: 75 → "p75", 99.9 → "p99.9").
+func PercentileKey(p float64) string {
+	return "p" + strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// SetPercentiles는 Metrics.Percentiles에 보고할 백분위수(0 < p < 100)를 지정합니다. 비어 있으면 DefaultPercentiles입니다.
+// 지연시간은 히스토그램에 모두 누적되므로 실행 도중에 바꿔도 다음 조회부터 지금까지의 요청으로 다시 계산합니다.
+func (c *Collector) SetPercentiles(percentiles []float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(percentiles) == 0 {
+		percentiles = DefaultPercentiles
+	}
+	c.percentiles = append([]float64(nil), percentiles...)
+}
+
+// percentiles는 ps의 백분위수(ms)를 PercentileKey로 계산합니다. 다른 지연시간 필드처럼 ms 미만은 버립니다.
+func (h *latencyHistogram) percentiles(ps []float64) map[string]float64 {
+	result := make(map[string]float64, len(ps))
+	for _, p := range ps {
+		result[PercentileKey(p)] = float64(h.percentile(p).Milliseconds())
+	}
+	return result
+}
@@ -0,0 +1,22 @@
+package metrics
+
+// SetTargetRate는 스냅샷 시점의 목표 처리율(초당 INSERT 행 수, 0 = 무제한)을 돌려주는 함수를 지정합니다.
+// 부하 설정은 실행마다 바뀌므로 값이 아닌 함수로 받아 GetMetrics 때마다 다시 계산합니다.
+func (c *Collector) SetTargetRate(target func() float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.targetRate = target
+}
+
+// rateMetrics는 목표 처리율과 실제 처리율, 그 비율을 계산합니다. c.mu를 잡은 상태에서 호출해야 합니다.
+func (c *Collector) rateMetrics(achieved float64) (target, ratio float64) {
+	if c.targetRate == nil {
+		return 0, 0
+	}
+	target = c.targetRate()
+	if target <= 0 {
+		return 0, 0
+	}
+	return target, achieved / target
+}
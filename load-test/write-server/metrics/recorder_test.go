@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// 카운터는 바로 반영되고, 지연시간과 커밋 시간은 Flush(또는 주기)에서 합쳐지는지 확인합니다.
+func TestRecorderFlush(t *testing.T) {
+	c := NewCollector()
+	r := c.NewRecorder()
+	time.Sleep(5 * time.Millisecond) // 기록할 요청이 Collector를 만든 뒤에 시작하도록
+
+	r.RecordSuccess("insert_batch_10", time.Millisecond, 10, 100)
+	r.RecordCommit(time.Millisecond, 500*time.Microsecond, 10)
+	r.RecordSuccess("insert_batch_5", 2*time.Millisecond, 5, 50)
+
+	m := c.GetMetrics()
+	if m.SuccessRequests != 15 || m.BytesWritten != 150 {
+		t.Fatalf("success/bytes = %d/%d before Flush, want 15/150", m.SuccessRequests, m.BytesWritten)
+	}
+	if m.LatencySamples != 0 || m.CommitLatency != nil {
+		t.Fatalf("latency_samples = %d, commit_latency = %+v before Flush, want none (buffered)", m.LatencySamples, m.CommitLatency)
+	}
+
+	r.Flush()
+	m = c.GetMetrics()
+	if m.LatencySamples != 2 || m.CommitLatency == nil || m.CommitLatency.Samples != 1 {
+		t.Fatalf("latency_samples = %d, commit_latency = %+v after Flush, want 2 and 1 commit", m.LatencySamples, m.CommitLatency)
+	}
+	if m.ByLabel["insert_batch_10"].SuccessRequests != 10 || m.ByLabel["insert_batch_5"].BytesWritten != 50 {
+		t.Fatalf("by_label = %+v", m.ByLabel)
+	}
+}
+
+// Reset 이전에 끝나 버퍼에 남아 있던 샘플은 Reset 뒤에 합쳐도 버려지는지 확인합니다.
+func TestRecorderDropsSamplesFromBeforeReset(t *testing.T) {
+	c := NewCollector()
+	r := c.NewRecorder()
+	time.Sleep(5 * time.Millisecond) // 기록할 요청이 Collector를 만든 뒤에 시작하도록
+
+	r.RecordSuccess("insert_batch_1", time.Millisecond, 1, 10)
+	r.RecordCommit(time.Millisecond, time.Millisecond, 1)
+	time.Sleep(time.Millisecond)
+	c.Reset()
+	r.Flush()
+
+	m := c.GetMetrics()
+	if m.TotalRequests != 0 || m.LatencySamples != 0 || m.CommitLatency != nil {
+		t.Fatalf("total/latency_samples = %d/%d, commit_latency = %+v, want none", m.TotalRequests, m.LatencySamples, m.CommitLatency)
+	}
+}
+
+// 워커 64개가 동시에 기록하고 조회와 겹쳐도 경합이 없는지 확인합니다 (go test -race로 실행).
+func TestRecorderConcurrent(t *testing.T) {
+	const workers, perWorker = 64, 1000
+	c := NewCollector()
+
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	readers.Add(1)
+	go func() {
+		defer readers.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				c.GetMetrics()
+				c.RequestCounts()
+				c.GetHeatmap()
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := c.NewRecorder()
+			defer r.Flush()
+			for j := 0; j < perWorker; j++ {
+				r.RecordSuccess("insert_batch_1", 0, 1, 10)
+				r.RecordCommit(0, 0, 1)
+				c.RecordTimeout("insert_batch_1", 0, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	readers.Wait()
+
+	m := c.GetMetrics()
+	if m.SuccessRequests != workers*perWorker || m.TimeoutRequests != workers*perWorker {
+		t.Fatalf("success/timeout = %d/%d, want %d each", m.SuccessRequests, m.TimeoutRequests, workers*perWorker)
+	}
+	if m.LatencySamples != workers*perWorker {
+		t.Fatalf("latency_samples = %d, want %d", m.LatencySamples, workers*perWorker)
+	}
+}
+
+// 워커 64개가 각자 Recorder로 기록하는 부하 생성기의 기록 경로입니다.
+func BenchmarkRecorder64Workers(b *testing.B) {
+	benchmarkWorkers(b, 64, func(c *Collector) func(time.Duration) {
+		r := c.NewRecorder()
+		return func(latency time.Duration) {
+			r.RecordSuccess("insert_batch_100", latency, 100, 10000)
+			r.RecordCommit(latency, latency/2, 100)
+		}
+	})
+}
+
+// 비교용: 같은 부하를 Collector.RecordSuccess, RecordCommit(배치마다 샤드 잠금)으로 기록합니다.
+func BenchmarkCollector64Workers(b *testing.B) {
+	benchmarkWorkers(b, 64, func(c *Collector) func(time.Duration) {
+		return func(latency time.Duration) {
+			c.RecordSuccess("insert_batch_100", latency, 100, 10000)
+			c.RecordCommit(latency, latency/2, 100)
+		}
+	})
+}
+
+// benchmarkWorkers는 b.N번의 기록을 workers개 고루틴에 나눠 동시에 실행합니다.
+func benchmarkWorkers(b *testing.B, workers int, newWorker func(c *Collector) func(time.Duration)) {
+	c := NewCollector()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		n := b.N / workers
+		if i < b.N%workers {
+			n++
+		}
+		record := newWorker(c)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				record(time.Duration(j%1000) * time.Microsecond)
+			}
+		}()
+	}
+	wg.Wait()
+}
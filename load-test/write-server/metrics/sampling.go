@@ -0,0 +1,43 @@
+package metrics
+
+import "math/rand"
+
+// SetLatencySampleRate는 성공한 요청 중 지연시간을 기록할 비율(0 < rate <= 1)을 지정합니다.
+// 요청 수 카운터는 항상 정확하고, 지연시간 샘플만 rate 비율로 무작위 추출합니다.
+// 무작위 표본이므로 평균/백분위수는 그대로 쓰고, 건수인 히스토그램/히트맵만 1/rate 배로 보정합니다.
+// 범위를 벗어난 값은 1(모두 기록)로 처리합니다.
+func (c *Collector) SetLatencySampleRate(rate float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lockShards()
+	defer c.unlockShards()
+
+	if rate <= 0 || rate > 1 {
+		rate = 1
+	}
+	c.sampleRate = rate
+}
+
+// sampleLatency는 이번 요청의 지연시간을 기록할지 정합니다. c.mu나 샤드 하나를 잡은 상태에서 호출해야 합니다.
+func (c *Collector) sampleLatency() bool {
+	return c.sampleRate >= 1 || rand.Float64() < c.sampleRate
+}
+
+// sampleScale은 샘플 건수를 전체 요청 건수로 환산하는 배율입니다. c.mu나 샤드 하나를 잡은 상태에서 호출해야 합니다.
+func (c *Collector) sampleScale() float64 {
+	if c.sampleRate <= 0 || c.sampleRate >= 1 {
+		return 1
+	}
+	return 1 / c.sampleRate
+}
+
+// scaleBuckets는 히스토그램 건수에 scale을 곱합니다 (반올림).
+func scaleBuckets(buckets []LatencyBucket, scale float64) []LatencyBucket {
+	if scale == 1 {
+		return buckets
+	}
+	for i := range buckets {
+		buckets[i].Count = int64(float64(buckets[i].Count)*scale + 0.5)
+	}
+	return buckets
+}
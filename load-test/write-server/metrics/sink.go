@@ -0,0 +1,47 @@
+package metrics
+
+import "time"
+
+// Sink는 요청 결과를 받는 메트릭 출력입니다. Collector가 기본 구현입니다.
+// AddSink로 추가한 Sink는 Collector가 기록하는 성공/실패를 그대로 전달받으므로, 부하 생성기와 핸들러의 기록 코드를 바꾸지 않고
+// StatsD나 Prometheus로 직접 내보내는 출력을 붙일 수 있습니다.
+type Sink interface {
+	// RecordSuccess는 성공한 배치의 지연시간, 행 수, 추정 바이트 수를 받습니다.
+	RecordSuccess(label string, latency time.Duration, count int, bytes int64)
+	// RecordFailure는 실패한 배치의 실패할 때까지 걸린 시간과 행 수를 받습니다. 타임아웃과 연결 끊김도 실패로 전달됩니다.
+	RecordFailure(label string, elapsed time.Duration, count int)
+}
+
+var _ Sink = (*Collector)(nil)
+
+// AddSink는 이후 Collector가 기록하는 성공/실패를 s에도 전달합니다. 실행 중에도 추가할 수 있으며 Reset으로 지워지지 않습니다.
+// s는 워커의 기록 경로에서 동기적으로 호출되므로 빠르게 반환해야 합니다 (네트워크 전송은 s 안에서 버퍼링).
+// 마지막 Reset 이전에 시작한 요청이나 워밍업 구간도 걸러내지 않고 모두 전달하며, 강제 중지로 취소된 요청은 전달하지 않습니다.
+func (c *Collector) AddSink(s Sink) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 기록 경로는 잠금 없이 읽으므로 기존 목록을 바꾸지 않고 새 목록으로 교체
+	var sinks []Sink
+	if current := c.sinks.Load(); current != nil {
+		sinks = append(sinks, *current...)
+	}
+	sinks = append(sinks, s)
+	c.sinks.Store(&sinks)
+}
+
+func (c *Collector) sinkSuccess(label string, latency time.Duration, count int, bytes int64) {
+	if sinks := c.sinks.Load(); sinks != nil {
+		for _, s := range *sinks {
+			s.RecordSuccess(label, latency, count, bytes)
+		}
+	}
+}
+
+func (c *Collector) sinkFailure(label string, elapsed time.Duration, count int) {
+	if sinks := c.sinks.Load(); sinks != nil {
+		for _, s := range *sinks {
+			s.RecordFailure(label, elapsed, count)
+		}
+	}
+}
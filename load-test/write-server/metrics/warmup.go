@@ -0,0 +1,57 @@
+package metrics
+
+import "time"
+
+// WarmupStats는 워밍업 구간에 시작해 주 지연시간 통계(백분위수, 히스토그램, 라벨별 지연시간)에서 제외한 요청의 통계입니다.
+// 요청 수/처리율 카운터와 히트맵에는 워밍업 구간도 그대로 포함됩니다.
+type WarmupStats struct {
+	ExcludeSeconds float64   `json:"exclude_seconds"` // WarmupExclude
+	EndsAt         time.Time `json:"ends_at"`         // 이 시각 이전에 시작한 요청이 워밍업
+	Active         bool      `json:"active"`          // 아직 워밍업 구간인지
+	Samples        int       `json:"samples"`         // 워밍업으로 분류한 지연시간 샘플 수
+	AvgLatency     float64   `json:"avg_latency_ms"`
+	P50Latency     float64   `json:"p50_latency_ms"`
+	P95Latency     float64   `json:"p95_latency_ms"`
+	P99Latency     float64   `json:"p99_latency_ms"`
+}
+
+// SetWarmupExclude는 지금부터 d 동안 시작한 요청의 지연시간을 워밍업으로 따로 기록하게 합니다 (0 = 사용 안 함).
+// 창은 호출 시점 기준이므로 실행을 시작(또는 리로드로 재시작)할 때마다 호출합니다. Reset은 창을 바꾸지 않습니다.
+func (c *Collector) SetWarmupExclude(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lockShards()
+	defer c.unlockShards()
+
+	if d <= 0 {
+		c.warmup = 0
+		c.warmupUntil = time.Time{}
+		return
+	}
+	c.warmup = d
+	c.warmupUntil = time.Now().Add(d)
+}
+
+// inWarmup은 now에 latency만큼 걸려 끝난 요청이 워밍업 구간에 시작했는지 확인합니다.
+// c.mu나 샤드 하나를 잡은 상태에서 호출해야 합니다.
+func (c *Collector) inWarmup(now time.Time, latency time.Duration) bool {
+	return !c.warmupUntil.IsZero() && now.Add(-latency).Before(c.warmupUntil)
+}
+
+// warmupStats는 워밍업 샘플의 통계를 반환합니다. 워밍업 제외를 쓰지 않으면 nil입니다. c.mu를 잡은 상태에서 호출해야 합니다.
+func (c *Collector) warmupStats(latencies *latencyHistogram) *WarmupStats {
+	if c.warmup <= 0 {
+		return nil
+	}
+	avg, p50, p95, p99 := latencies.summarize()
+	return &WarmupStats{
+		ExcludeSeconds: c.warmup.Seconds(),
+		EndsAt:         c.warmupUntil,
+		Active:         time.Now().Before(c.warmupUntil),
+		Samples:        int(latencies.count),
+		AvgLatency:     avg,
+		P50Latency:     p50,
+		P95Latency:     p95,
+		P99Latency:     p99,
+	}
+}
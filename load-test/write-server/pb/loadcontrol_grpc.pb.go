@@ -0,0 +1,344 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: loadcontrol.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	LoadControl_Start_FullMethodName         = "/writeserver.loadcontrol.LoadControl/Start"
+	LoadControl_Stop_FullMethodName          = "/writeserver.loadcontrol.LoadControl/Stop"
+	LoadControl_UpdateConfig_FullMethodName  = "/writeserver.loadcontrol.LoadControl/UpdateConfig"
+	LoadControl_GetStatus_FullMethodName     = "/writeserver.loadcontrol.LoadControl/GetStatus"
+	LoadControl_GetMetrics_FullMethodName    = "/writeserver.loadcontrol.LoadControl/GetMetrics"
+	LoadControl_StreamMetrics_FullMethodName = "/writeserver.loadcontrol.LoadControl/StreamMetrics"
+)
+
+// LoadControlClient is the client API for LoadControl service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LoadControl은 HTTP 부하 제어 API(/load/*, /metrics)와 동일한 기능을 gRPC로 제공합니다.
+type LoadControlClient interface {
+	// 부하 생성 시작 (POST /load/start)
+	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error)
+	// 부하 생성 중지 (POST /load/stop)
+	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
+	// 부하 설정 변경 (POST /load/config)
+	UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*UpdateConfigResponse, error)
+	// 부하 생성 상태 조회 (GET /load/status)
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	// 메트릭 조회 (GET /metrics)
+	GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*Metrics, error)
+	// 메트릭을 주기적으로 스트리밍 (폴링 대체)
+	StreamMetrics(ctx context.Context, in *StreamMetricsRequest, opts ...grpc.CallOption) (LoadControl_StreamMetricsClient, error)
+}
+
+type loadControlClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLoadControlClient(cc grpc.ClientConnInterface) LoadControlClient {
+	return &loadControlClient{cc}
+}
+
+func (c *loadControlClient) Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartResponse)
+	err := c.cc.Invoke(ctx, LoadControl_Start_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadControlClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopResponse)
+	err := c.cc.Invoke(ctx, LoadControl_Stop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadControlClient) UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*UpdateConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateConfigResponse)
+	err := c.cc.Invoke(ctx, LoadControl_UpdateConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadControlClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, LoadControl_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadControlClient) GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*Metrics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Metrics)
+	err := c.cc.Invoke(ctx, LoadControl_GetMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loadControlClient) StreamMetrics(ctx context.Context, in *StreamMetricsRequest, opts ...grpc.CallOption) (LoadControl_StreamMetricsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LoadControl_ServiceDesc.Streams[0], LoadControl_StreamMetrics_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &loadControlStreamMetricsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LoadControl_StreamMetricsClient interface {
+	Recv() (*Metrics, error)
+	grpc.ClientStream
+}
+
+type loadControlStreamMetricsClient struct {
+	grpc.ClientStream
+}
+
+func (x *loadControlStreamMetricsClient) Recv() (*Metrics, error) {
+	m := new(Metrics)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LoadControlServer is the server API for LoadControl service.
+// All implementations must embed UnimplementedLoadControlServer
+// for forward compatibility
+//
+// LoadControl은 HTTP 부하 제어 API(/load/*, /metrics)와 동일한 기능을 gRPC로 제공합니다.
+type LoadControlServer interface {
+	// 부하 생성 시작 (POST /load/start)
+	Start(context.Context, *StartRequest) (*StartResponse, error)
+	// 부하 생성 중지 (POST /load/stop)
+	Stop(context.Context, *StopRequest) (*StopResponse, error)
+	// 부하 설정 변경 (POST /load/config)
+	UpdateConfig(context.Context, *UpdateConfigRequest) (*UpdateConfigResponse, error)
+	// 부하 생성 상태 조회 (GET /load/status)
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	// 메트릭 조회 (GET /metrics)
+	GetMetrics(context.Context, *GetMetricsRequest) (*Metrics, error)
+	// 메트릭을 주기적으로 스트리밍 (폴링 대체)
+	StreamMetrics(*StreamMetricsRequest, LoadControl_StreamMetricsServer) error
+	mustEmbedUnimplementedLoadControlServer()
+}
+
+// UnimplementedLoadControlServer must be embedded to have forward compatible implementations.
+type UnimplementedLoadControlServer struct {
+}
+
+func (UnimplementedLoadControlServer) Start(context.Context, *StartRequest) (*StartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
+}
+func (UnimplementedLoadControlServer) Stop(context.Context, *StopRequest) (*StopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+func (UnimplementedLoadControlServer) UpdateConfig(context.Context, *UpdateConfigRequest) (*UpdateConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfig not implemented")
+}
+func (UnimplementedLoadControlServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedLoadControlServer) GetMetrics(context.Context, *GetMetricsRequest) (*Metrics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
+}
+func (UnimplementedLoadControlServer) StreamMetrics(*StreamMetricsRequest, LoadControl_StreamMetricsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMetrics not implemented")
+}
+func (UnimplementedLoadControlServer) mustEmbedUnimplementedLoadControlServer() {}
+
+// UnsafeLoadControlServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LoadControlServer will
+// result in compilation errors.
+type UnsafeLoadControlServer interface {
+	mustEmbedUnimplementedLoadControlServer()
+}
+
+func RegisterLoadControlServer(s grpc.ServiceRegistrar, srv LoadControlServer) {
+	s.RegisterService(&LoadControl_ServiceDesc, srv)
+}
+
+func _LoadControl_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadControlServer).Start(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadControl_Start_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadControlServer).Start(ctx, req.(*StartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadControl_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadControlServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadControl_Stop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadControlServer).Stop(ctx, req.(*StopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadControl_UpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadControlServer).UpdateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadControl_UpdateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadControlServer).UpdateConfig(ctx, req.(*UpdateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadControl_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadControlServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadControl_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadControlServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadControl_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoadControlServer).GetMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoadControl_GetMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoadControlServer).GetMetrics(ctx, req.(*GetMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoadControl_StreamMetrics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMetricsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LoadControlServer).StreamMetrics(m, &loadControlStreamMetricsServer{ServerStream: stream})
+}
+
+type LoadControl_StreamMetricsServer interface {
+	Send(*Metrics) error
+	grpc.ServerStream
+}
+
+type loadControlStreamMetricsServer struct {
+	grpc.ServerStream
+}
+
+func (x *loadControlStreamMetricsServer) Send(m *Metrics) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// LoadControl_ServiceDesc is the grpc.ServiceDesc for LoadControl service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LoadControl_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "writeserver.loadcontrol.LoadControl",
+	HandlerType: (*LoadControlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Start",
+			Handler:    _LoadControl_Start_Handler,
+		},
+		{
+			MethodName: "Stop",
+			Handler:    _LoadControl_Stop_Handler,
+		},
+		{
+			MethodName: "UpdateConfig",
+			Handler:    _LoadControl_UpdateConfig_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _LoadControl_GetStatus_Handler,
+		},
+		{
+			MethodName: "GetMetrics",
+			Handler:    _LoadControl_GetMetrics_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMetrics",
+			Handler:       _LoadControl_StreamMetrics_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "loadcontrol.proto",
+}
@@ -0,0 +1,10 @@
+module deadlock-demo
+
+go 1.25.5
+
+require (
+	github.com/lib/pq v1.10.9
+	retry v0.0.0
+)
+
+replace retry => ../retry
@@ -0,0 +1,18 @@
+-- Deadlock Demo Database 초기화 스크립트
+
+-- accounts 테이블 생성
+CREATE TABLE accounts (
+    id SERIAL PRIMARY KEY,
+    owner VARCHAR(100) NOT NULL,
+    balance INTEGER NOT NULL CHECK (balance >= 0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 초기 데이터 삽입
+INSERT INTO accounts (owner, balance) VALUES
+    ('Alice', 10000),
+    ('Bob', 10000);
+
+-- 테이블 정보 출력 (디버깅용)
+SELECT 'Accounts table initialized successfully' AS status;
+SELECT * FROM accounts;
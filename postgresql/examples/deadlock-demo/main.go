@@ -0,0 +1,122 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"deadlock-demo/problem"
+	"deadlock-demo/solution"
+	"retry"
+)
+
+const (
+	host     = "localhost"
+	port     = 5434
+	user     = "postgres"
+	password = "postgres"
+	dbname   = "bank"
+)
+
+func main() {
+	fmt.Println("\n" + repeat("=", 70))
+	fmt.Println("🚀 PostgreSQL Deadlock 데모")
+	fmt.Println(repeat("=", 70))
+
+	// PostgreSQL 연결
+	db := connectDB()
+	defer db.Close()
+
+	// 연결 확인
+	if err := db.Ping(); err != nil {
+		log.Fatalf("❌ 데이터베이스 연결 실패: %v\n", err)
+	}
+	fmt.Println("✅ PostgreSQL 연결 성공")
+
+	// 1. 데드락 재현
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 1: 잠금 순서 불일치로 인한 데드락 재현")
+	fmt.Println(repeat("*", 70))
+	problem.RunProblemDemo(db)
+
+	// 사용자가 결과를 확인할 수 있도록 잠시 대기
+	fmt.Println("\n⏳ 3초 후 해결책 데모를 시작합니다...")
+	time.Sleep(3 * time.Second)
+
+	// 2. 일관된 잠금 순서 해결책
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 2: 일관된 잠금 순서 해결책")
+	fmt.Println(repeat("*", 70))
+	solution.RunSolutionDemo(db)
+
+	fmt.Println("\n⏳ 3초 후 재시도 해결책 데모를 시작합니다...")
+	time.Sleep(3 * time.Second)
+
+	// 3. 40P01 재시도 (잠금 순서는 그대로)
+	// 데드락 감지에 deadlock_timeout(기본 1초)이 걸리므로 기본 예산(2초)보다 넉넉하게 줌
+	retryPolicy := retry.DefaultPolicy
+	retryPolicy.Codes = []string{retry.DeadlockDetected}
+	retryPolicy.Budget = 10 * time.Second
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 3: 40P01 재시도 해결책")
+	fmt.Println(repeat("*", 70))
+	solution.RunRetryDemo(db, retryPolicy)
+
+	// 최종 요약
+	fmt.Println("\n" + repeat("=", 70))
+	fmt.Println("📚 핵심 요약")
+	fmt.Println(repeat("=", 70))
+	fmt.Println(`
+1️⃣  데드락이란?
+   - 두 트랜잭션이 서로가 보유한 잠금을 기다리는 순환 대기 상태
+   - 예: TX A는 1번 → 2번, TX B는 2번 → 1번 순서로 행을 잠금
+   - PostgreSQL은 deadlock_timeout(기본 1초) 후 감지하여 한쪽을 40P01로 중단
+
+2️⃣  일관된 잠금 순서 (Lock Ordering)
+   - 모든 트랜잭션이 같은 순서(예: id 오름차순)로 잠금을 획득
+   - 순환 대기가 구조적으로 불가능 → 데드락 자체가 발생하지 않음
+   - 가장 간단하고 효과적인 데드락 예방 기법
+
+3️⃣  주의사항
+   ✅ 장점: 재시도 없이 모든 트랜잭션이 성공
+   ⚠️  단점: 잠글 행을 미리 알아야 하고, 모든 코드 경로가 규칙을 지켜야 함
+   💡 팁: 여러 행은 SELECT ... WHERE id IN (...) ORDER BY id FOR UPDATE로 한 번에 잠금
+
+4️⃣  대안들
+   - 40P01 에러 시 트랜잭션 재시도 (PART 3, retry.Do)
+   - lock_timeout / NOWAIT로 대기 시간 제한
+   - 트랜잭션을 짧게 유지하여 잠금 보유 시간 최소화`)
+	fmt.Println(repeat("=", 70))
+	fmt.Println("✨ 데모 종료")
+	fmt.Println(repeat("=", 70) + "\n")
+}
+
+// connectDB는 PostgreSQL 데이터베이스에 연결합니다.
+func connectDB() *sql.DB {
+	psqlInfo := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		host, port, user, password, dbname)
+
+	db, err := sql.Open("postgres", psqlInfo)
+	if err != nil {
+		log.Fatalf("❌ 데이터베이스 연결 실패: %v\n", err)
+	}
+
+	// 연결 풀 설정
+	db.SetMaxOpenConns(25)                 // 최대 연결 수
+	db.SetMaxIdleConns(10)                 // 유휴 연결 수
+	db.SetConnMaxLifetime(5 * time.Minute) // 연결 최대 수명
+
+	return db
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}
@@ -0,0 +1,162 @@
+package problem
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"retry"
+)
+
+// Transfer는 fromID 계좌에서 toID 계좌로 amount를 이체합니다.
+//
+// ⚠️ 문제점: 항상 "출금 계좌 → 입금 계좌" 순서로 잠금을 획득합니다.
+// 1. TX A (1 → 2): 1번 행 잠금 획득
+// 2. TX B (2 → 1): 2번 행 잠금 획득
+// 3. TX A: 2번 행 잠금 대기 (TX B가 보유)
+// 4. TX B: 1번 행 잠금 대기 (TX A가 보유)
+// 5. 서로가 서로를 기다리는 순환 대기 → 데드락!
+//
+// PostgreSQL은 deadlock_timeout(기본 1초) 후 데드락을 감지하고
+// 둘 중 하나를 40P01(deadlock_detected) 에러로 중단시킵니다.
+func Transfer(db *sql.DB, fromID, toID, amount int) error {
+	return TransferContext(context.Background(), db, fromID, toID, amount)
+}
+
+// TransferContext는 ctx가 취소되면 진행 중인 쿼리도 취소되는 Transfer입니다 (retry.Do의 시간 예산용).
+func TransferContext(ctx context.Context, db *sql.DB, fromID, toID, amount int) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 1단계: 출금 계좌 잠금
+	var fromBalance int
+	err = tx.QueryRowContext(ctx, "SELECT balance FROM accounts WHERE id = $1 FOR UPDATE", fromID).Scan(&fromBalance)
+	if err != nil {
+		return fmt.Errorf("출금 계좌(%d) 잠금 실패: %w", fromID, err)
+	}
+
+	// 2단계: 경합 상황 시뮬레이션 (반대 방향 TX가 다른 행을 잠글 시간)
+	time.Sleep(50 * time.Millisecond)
+
+	// 3단계: 입금 계좌 잠금
+	// ⚠️ 반대 방향 이체가 이 행을 이미 잠갔다면 여기서 데드락 발생
+	var toBalance int
+	err = tx.QueryRowContext(ctx, "SELECT balance FROM accounts WHERE id = $1 FOR UPDATE", toID).Scan(&toBalance)
+	if err != nil {
+		return fmt.Errorf("입금 계좌(%d) 잠금 실패: %w", toID, err)
+	}
+
+	if fromBalance < amount {
+		return fmt.Errorf("잔액 부족: 현재 %d원, 요청 %d원", fromBalance, amount)
+	}
+
+	// 4단계: 이체
+	if _, err := tx.ExecContext(ctx, "UPDATE accounts SET balance = balance - $1, updated_at = NOW() WHERE id = $2", amount, fromID); err != nil {
+		return fmt.Errorf("출금 실패: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE accounts SET balance = balance + $1, updated_at = NOW() WHERE id = $2", amount, toID); err != nil {
+		return fmt.Errorf("입금 실패: %w", err)
+	}
+
+	// 5단계: 커밋
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("커밋 실패: %w", err)
+	}
+
+	return nil
+}
+
+// IsDeadlock은 에러가 PostgreSQL 40P01(deadlock_detected)인지 확인합니다.
+func IsDeadlock(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && string(pqErr.Code) == retry.DeadlockDetected
+}
+
+// RunProblemDemo는 반대 방향 이체를 동시에 실행하여 데드락을 재현합니다.
+func RunProblemDemo(db *sql.DB) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("❌ 데드락 재현 (잠금 순서 불일치)")
+	fmt.Println(repeat("=", 60))
+
+	RunTransferDemo(db, Transfer)
+
+	fmt.Println(repeat("=", 60))
+}
+
+// RunTransferDemo는 transfer 함수로 1 → 2, 2 → 1 이체를 동시에 여러 쌍 실행하고
+// 성공/데드락 건수와 최종 잔액 합계를 출력합니다.
+func RunTransferDemo(db *sql.DB, transfer func(db *sql.DB, fromID, toID, amount int) error) {
+	// 초기 잔액 설정
+	_, err := db.Exec("UPDATE accounts SET balance = 10000 WHERE id IN (1, 2)")
+	if err != nil {
+		fmt.Printf("초기 잔액 설정 실패: %v\n", err)
+		return
+	}
+
+	const pairs = 5
+	fmt.Printf("\n💰 초기 잔액: 1번 10000원, 2번 10000원\n")
+	fmt.Printf("🔄 %d쌍의 고루틴이 동시에 1 → 2, 2 → 1 방향으로 100원씩 이체\n\n", pairs)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var successCount, deadlockCount, otherFailCount int
+	startTime := time.Now()
+
+	for i := 1; i <= pairs; i++ {
+		for _, dir := range [][2]int{{1, 2}, {2, 1}} {
+			wg.Add(1)
+			go func(num, fromID, toID int) {
+				defer wg.Done()
+				err := transfer(db, fromID, toID, 100)
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err == nil:
+					successCount++
+					fmt.Printf("  [쌍 %d] ✅ %d → %d 이체 완료\n", num, fromID, toID)
+				case IsDeadlock(err):
+					deadlockCount++
+					fmt.Printf("  [쌍 %d] 💀 %d → %d 데드락 (40P01): %v\n", num, fromID, toID, err)
+				default:
+					otherFailCount++
+					fmt.Printf("  [쌍 %d] ❌ %d → %d 실패: %v\n", num, fromID, toID, err)
+				}
+			}(i, dir[0], dir[1])
+		}
+	}
+
+	wg.Wait()
+	elapsed := time.Since(startTime)
+
+	var total int
+	db.QueryRow("SELECT SUM(balance) FROM accounts WHERE id IN (1, 2)").Scan(&total)
+
+	fmt.Println("\n" + repeat("-", 60))
+	fmt.Printf("⏱️  실행 시간: %v\n", elapsed)
+	fmt.Printf("📊 성공: %d건, 데드락: %d건, 기타 실패: %d건\n", successCount, deadlockCount, otherFailCount)
+	fmt.Printf("📊 잔액 합계: %d원 (기대값: 20000원)\n", total)
+
+	if deadlockCount > 0 {
+		fmt.Printf("\n🚨 데드락 발생! %d건의 이체가 PostgreSQL에 의해 강제 중단되었습니다.\n", deadlockCount)
+		fmt.Printf("   각 데드락마다 deadlock_timeout(기본 1초)만큼 대기한 뒤 감지되어 실행 시간도 늘어났습니다.\n")
+	} else {
+		fmt.Printf("\n✅ 데드락으로 실패한 이체 없음: 모든 이체가 반영되었습니다.\n")
+	}
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}
@@ -0,0 +1,102 @@
+package solution
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"deadlock-demo/problem"
+)
+
+// TransferBetween은 항상 id 오름차순으로 잠금을 획득하여 데드락을 방지하는 이체 함수입니다.
+//
+// 작동 원리:
+// 1. 이체 방향과 관계없이 두 id를 정렬 (작은 id → 큰 id)
+// 2. 1 → 2 이체든 2 → 1 이체든 항상 1번 행을 먼저 잠금
+// 3. 1번 행 잠금을 얻지 못한 TX는 2번 행을 잠그기 전에 대기
+// 4. 순환 대기(circular wait)가 생길 수 없으므로 데드락 불가능!
+//
+// 장점:
+// - 가장 간단하고 효과적인 데드락 예방 기법
+// - 재시도 로직 없이도 모든 이체가 성공
+//
+// 단점:
+// - 잠글 행을 미리 알아야 함 (동적으로 잠금 대상이 바뀌는 경우 적용 어려움)
+// - 모든 코드 경로가 같은 순서 규칙을 지켜야 함
+func TransferBetween(db *sql.DB, fromID, toID, amount int) error {
+	if fromID == toID {
+		return fmt.Errorf("같은 계좌로 이체할 수 없습니다: %d", fromID)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 1단계: 잠금 순서 결정 (항상 id 오름차순)
+	firstID, secondID := fromID, toID
+	if firstID > secondID {
+		firstID, secondID = secondID, firstID
+	}
+
+	// 2단계: 작은 id 행 잠금
+	// 🔒 반대 방향 이체도 같은 행을 먼저 잠그려 하므로 여기서 대기
+	balances := make(map[int]int, 2)
+	var balance int
+	err = tx.QueryRow("SELECT balance FROM accounts WHERE id = $1 FOR UPDATE", firstID).Scan(&balance)
+	if err != nil {
+		return fmt.Errorf("계좌(%d) 잠금 실패: %w", firstID, err)
+	}
+	balances[firstID] = balance
+
+	// 3단계: 경합 상황 시뮬레이션 (문제 버전과 동일한 대기)
+	time.Sleep(50 * time.Millisecond)
+
+	// 4단계: 큰 id 행 잠금
+	// ✅ 이 행을 보유한 TX는 이미 작은 id 행도 보유하고 있으므로 순환 대기 없음
+	err = tx.QueryRow("SELECT balance FROM accounts WHERE id = $1 FOR UPDATE", secondID).Scan(&balance)
+	if err != nil {
+		return fmt.Errorf("계좌(%d) 잠금 실패: %w", secondID, err)
+	}
+	balances[secondID] = balance
+
+	if balances[fromID] < amount {
+		return fmt.Errorf("잔액 부족: 현재 %d원, 요청 %d원", balances[fromID], amount)
+	}
+
+	// 5단계: 이체
+	if _, err := tx.Exec("UPDATE accounts SET balance = balance - $1, updated_at = NOW() WHERE id = $2", amount, fromID); err != nil {
+		return fmt.Errorf("출금 실패: %w", err)
+	}
+	if _, err := tx.Exec("UPDATE accounts SET balance = balance + $1, updated_at = NOW() WHERE id = $2", amount, toID); err != nil {
+		return fmt.Errorf("입금 실패: %w", err)
+	}
+
+	// 6단계: 커밋 (잠금 해제)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("커밋 실패: %w", err)
+	}
+
+	return nil
+}
+
+// RunSolutionDemo는 잠금 순서를 통일한 이체가 반대 방향과 섞여도 데드락이 없음을 보여줍니다.
+func RunSolutionDemo(db *sql.DB) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("✅ 일관된 잠금 순서 해결책 (id 오름차순)")
+	fmt.Println(repeat("=", 60))
+
+	problem.RunTransferDemo(db, TransferBetween)
+
+	fmt.Println(repeat("=", 60))
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}
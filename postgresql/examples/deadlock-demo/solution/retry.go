@@ -0,0 +1,52 @@
+package solution
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"deadlock-demo/problem"
+	"retry"
+)
+
+// TransferWithRetry는 잠금 순서를 바꾸지 않은 이체(problem.Transfer)를 40P01 에러가 나면 처음부터 다시 실행합니다.
+//
+// 작동 원리:
+// 1. 데드락이 나면 PostgreSQL이 한쪽 TX만 40P01로 중단시키고, 다른 쪽은 잠금을 얻어 커밋
+// 2. 중단된 TX는 롤백되어 잠금을 모두 풀었으므로 처음부터 다시 실행하면 대부분 성공
+// 3. 지수 백오프 + 지터로 반대 방향 이체와 다시 같은 순간에 부딪히지 않게 함
+// 4. 재시도를 포함한 전체 시간이 예산(policy.Budget)을 넘으면 포기 (retry.ErrBudgetExceeded)
+//
+// 단점:
+// - 데드락마다 deadlock_timeout(기본 1초)을 기다린 뒤에야 재시도하므로 잠금 순서 통일보다 훨씬 느림
+//
+// 반환값은 재시도 횟수입니다.
+func TransferWithRetry(db *sql.DB, policy retry.Policy, fromID, toID, amount int) (int, error) {
+	return retry.Do(context.Background(), policy, func(ctx context.Context) error {
+		return problem.TransferContext(ctx, db, fromID, toID, amount)
+	})
+}
+
+// RunRetryDemo는 데드락이 나는 이체도 재시도로 모두 성공함을 보여줍니다.
+// 재시도 횟수, 백오프, 시간 예산은 policy를 따릅니다.
+func RunRetryDemo(db *sql.DB, policy retry.Policy) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("🔁 40P01 재시도 해결책 (잠금 순서는 그대로)")
+	fmt.Println(repeat("=", 60))
+	fmt.Printf("\n🔁 40P01 에러 시 최대 %d번 재시도 (백오프 %v~%v, 이체당 시간 예산 %v)\n",
+		policy.MaxRetries, policy.BaseDelay, policy.MaxDelay, policy.Budget)
+
+	var totalRetries atomic.Int64
+	problem.RunTransferDemo(db, func(db *sql.DB, fromID, toID, amount int) error {
+		retries, err := TransferWithRetry(db, policy, fromID, toID, amount)
+		totalRetries.Add(int64(retries))
+		return err
+	})
+
+	fmt.Printf("🔁 총 재시도: %d번\n", totalRetries.Load())
+	fmt.Printf("💡 데드락은 여전히 발생하지만 중단된 이체를 다시 실행해 모두 반영합니다.\n")
+	fmt.Printf("   → 데드락마다 deadlock_timeout만큼 늦어지므로, 잠금 순서를 정할 수 있다면 PART 2가 낫습니다.\n")
+
+	fmt.Println(repeat("=", 60))
+}
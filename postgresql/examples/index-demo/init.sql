@@ -0,0 +1,16 @@
+-- Index Demo Database 초기화 스크립트
+-- load-test/read-server가 조회하는 logs 테이블과 동일한 스키마를 사용합니다.
+-- 데이터 시딩과 인덱스 생성은 데모 프로그램이 직접 수행합니다.
+
+-- logs 테이블 생성 (PK 외 인덱스 없음)
+CREATE TABLE logs (
+    id BIGSERIAL PRIMARY KEY,
+    timestamp TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    level VARCHAR(10) NOT NULL,
+    service VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    metadata JSONB
+);
+
+-- 테이블 정보 출력 (디버깅용)
+SELECT 'Logs table initialized successfully' AS status;
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"index-demo/problem"
+	"index-demo/solution"
+)
+
+const (
+	host     = "localhost"
+	port     = 5437
+	user     = "postgres"
+	password = "postgres"
+	dbname   = "logs"
+)
+
+func main() {
+	fmt.Println("\n" + repeat("=", 70))
+	fmt.Println("🚀 PostgreSQL 인덱스 vs 순차 스캔 데모")
+	fmt.Println(repeat("=", 70))
+
+	// PostgreSQL 연결
+	db := connectDB()
+	defer db.Close()
+
+	// 연결 확인
+	if err := db.Ping(); err != nil {
+		log.Fatalf("❌ 데이터베이스 연결 실패: %v\n", err)
+	}
+	fmt.Println("✅ PostgreSQL 연결 성공")
+
+	// 1. 인덱스 없는 필터 쿼리
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 1: 인덱스 없이 필터 쿼리 실행")
+	fmt.Println(repeat("*", 70))
+	before, err := problem.RunProblemDemo(db)
+	if err != nil {
+		log.Fatalf("❌ %v\n", err)
+	}
+
+	// 사용자가 결과를 확인할 수 있도록 잠시 대기
+	fmt.Println("\n⏳ 3초 후 해결책 데모를 시작합니다...")
+	time.Sleep(3 * time.Second)
+
+	// 2. 복합 인덱스 생성 후 재측정
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 2: 복합 인덱스 생성 후 재측정")
+	fmt.Println(repeat("*", 70))
+	if err := solution.RunSolutionDemo(db, before); err != nil {
+		log.Fatalf("❌ %v\n", err)
+	}
+
+	// 최종 요약
+	fmt.Println("\n" + repeat("=", 70))
+	fmt.Println("📚 핵심 요약")
+	fmt.Println(repeat("=", 70))
+	fmt.Println(`
+1️⃣  인덱스가 없으면?
+   - 조건에 맞는 행을 찾기 위해 테이블 전체를 읽는 Seq Scan
+   - 필터링 후 남은 행을 ORDER BY를 위해 다시 정렬
+   - 데이터가 늘어날수록 지연 시간이 선형으로 증가
+
+2️⃣  복합 인덱스 설계 원칙
+   - 등호(=) 조건 컬럼을 앞에: (level, service, ...)
+   - 범위/정렬 컬럼을 뒤에: (..., timestamp DESC)
+   - ORDER BY와 인덱스 순서가 같으면 Sort 단계 제거 + LIMIT에서 조기 종료
+
+3️⃣  주의사항
+   ✅ 장점: 읽기 지연 시간 대폭 감소
+   ⚠️  단점: INSERT마다 인덱스 갱신 비용 → write-server 처리량 감소
+   💡 팁: read-server 부하 테스트로 효과를, write-server 부하 테스트로 비용을 함께 측정
+
+4️⃣  더 알아보기
+   - 커버링 인덱스(INCLUDE)로 Heap 접근까지 제거: ../../../load-test/COVERING_INDEX_DEEP_DIVE.md`)
+	fmt.Println(repeat("=", 70))
+	fmt.Println("✨ 데모 종료")
+	fmt.Println(repeat("=", 70) + "\n")
+}
+
+// connectDB는 PostgreSQL 데이터베이스에 연결합니다.
+func connectDB() *sql.DB {
+	psqlInfo := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		host, port, user, password, dbname)
+
+	db, err := sql.Open("postgres", psqlInfo)
+	if err != nil {
+		log.Fatalf("❌ 데이터베이스 연결 실패: %v\n", err)
+	}
+
+	// 연결 풀 설정
+	db.SetMaxOpenConns(25)                 // 최대 연결 수
+	db.SetMaxIdleConns(10)                 // 유휴 연결 수
+	db.SetConnMaxLifetime(5 * time.Minute) // 연결 최대 수명
+
+	return db
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}
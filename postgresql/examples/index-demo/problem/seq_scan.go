@@ -0,0 +1,166 @@
+package problem
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SeedRows는 시딩할 로그 행 수입니다.
+const SeedRows = 1_000_000
+
+// Runs는 지연 시간 측정 시 쿼리를 반복 실행하는 횟수입니다.
+const Runs = 20
+
+// FilterQuery는 read-server의 필터 쿼리(executeFilterQuery)와 동일한 쿼리입니다.
+const FilterQuery = `
+	SELECT id, timestamp, level, service, message
+	FROM logs
+	WHERE level = $1
+	  AND service = $2
+	  AND timestamp > NOW() - INTERVAL '1 hour'
+	ORDER BY timestamp DESC
+	LIMIT 100`
+
+// Level, Service는 측정에 사용할 필터 값입니다.
+const (
+	Level   = "ERROR"
+	Service = "payment"
+)
+
+// LatencyResult는 반복 실행한 쿼리의 지연 시간 통계입니다.
+type LatencyResult struct {
+	Avg time.Duration
+	P50 time.Duration
+	P95 time.Duration
+}
+
+// Seed는 logs 테이블을 비우고 최근 24시간에 고르게 분포한 로그를 생성합니다.
+// read-server와 같은 level 4종 × service 6종 조합을 사용합니다.
+func Seed(db *sql.DB, rows int) error {
+	if _, err := db.Exec("TRUNCATE logs"); err != nil {
+		return fmt.Errorf("테이블 초기화 실패: %w", err)
+	}
+	if _, err := db.Exec("DROP INDEX IF EXISTS idx_logs_level_service_timestamp"); err != nil {
+		return fmt.Errorf("인덱스 삭제 실패: %w", err)
+	}
+
+	_, err := db.Exec(`
+		INSERT INTO logs (timestamp, level, service, message)
+		SELECT
+			NOW() - random() * INTERVAL '24 hours',
+			(ARRAY['INFO', 'WARN', 'ERROR', 'DEBUG'])[floor(random() * 4 + 1)],
+			(ARRAY['auth', 'api', 'worker', 'scheduler', 'notification', 'payment'])[floor(random() * 6 + 1)],
+			'Seeded log message ' || g
+		FROM generate_series(1, $1) AS g`, rows)
+	if err != nil {
+		return fmt.Errorf("데이터 시딩 실패: %w", err)
+	}
+
+	// 플래너가 정확한 통계로 실행 계획을 세우도록 ANALYZE
+	if _, err := db.Exec("ANALYZE logs"); err != nil {
+		return fmt.Errorf("ANALYZE 실패: %w", err)
+	}
+	return nil
+}
+
+// MeasureFilterQuery는 FilterQuery를 runs번 실행하여 지연 시간 통계를 반환합니다.
+// 첫 실행은 캐시 워밍업으로 간주하여 측정에서 제외합니다.
+func MeasureFilterQuery(db *sql.DB, runs int) (LatencyResult, error) {
+	if err := runFilterQuery(db); err != nil {
+		return LatencyResult{}, err
+	}
+
+	latencies := make([]time.Duration, 0, runs)
+	var total time.Duration
+	for i := 0; i < runs; i++ {
+		start := time.Now()
+		if err := runFilterQuery(db); err != nil {
+			return LatencyResult{}, err
+		}
+		latency := time.Since(start)
+		latencies = append(latencies, latency)
+		total += latency
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return LatencyResult{
+		Avg: total / time.Duration(runs),
+		P50: latencies[len(latencies)*50/100],
+		P95: latencies[len(latencies)*95/100],
+	}, nil
+}
+
+func runFilterQuery(db *sql.DB) error {
+	rows, err := db.Query(FilterQuery, Level, Service)
+	if err != nil {
+		return fmt.Errorf("필터 쿼리 실패: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+// PrintExplainAnalyze는 FilterQuery의 EXPLAIN ANALYZE 결과를 출력합니다.
+func PrintExplainAnalyze(db *sql.DB) {
+	rows, err := db.Query("EXPLAIN (ANALYZE, BUFFERS) "+FilterQuery, Level, Service)
+	if err != nil {
+		fmt.Printf("  EXPLAIN 실패: %v\n", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			continue
+		}
+		fmt.Printf("  %s\n", line)
+	}
+}
+
+// PrintLatency는 지연 시간 통계를 출력합니다.
+func PrintLatency(result LatencyResult) {
+	fmt.Printf("⏱️  평균: %v, p50: %v, p95: %v (%d회 실행)\n", result.Avg, result.P50, result.P95, Runs)
+}
+
+// RunProblemDemo는 인덱스 없이 필터 쿼리를 실행하여 순차 스캔의 비용을 보여줍니다.
+func RunProblemDemo(db *sql.DB) (LatencyResult, error) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("❌ 인덱스 없는 필터 쿼리 (Sequential Scan)")
+	fmt.Println(repeat("=", 60))
+
+	fmt.Printf("\n📦 %d개 로그 시딩 중 (최근 24시간, level 4종 × service 6종)...\n", SeedRows)
+	if err := Seed(db, SeedRows); err != nil {
+		return LatencyResult{}, err
+	}
+	fmt.Printf("🔍 WHERE level = '%s' AND service = '%s' AND 최근 1시간, LIMIT 100\n\n", Level, Service)
+
+	fmt.Println("📋 EXPLAIN ANALYZE:")
+	PrintExplainAnalyze(db)
+
+	result, err := MeasureFilterQuery(db, Runs)
+	if err != nil {
+		return LatencyResult{}, err
+	}
+
+	fmt.Println("\n" + repeat("-", 60))
+	PrintLatency(result)
+	fmt.Printf("\n🚨 매 쿼리마다 %d행 전체를 읽고 필터링한 뒤 정렬합니다.\n", SeedRows)
+	fmt.Printf("💡 원인: level/service/timestamp 조건을 사용할 인덱스가 없어 Seq Scan만 가능합니다.\n")
+	fmt.Println(repeat("=", 60))
+
+	return result, nil
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}
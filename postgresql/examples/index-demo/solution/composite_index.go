@@ -0,0 +1,72 @@
+package solution
+
+import (
+	"database/sql"
+	"fmt"
+
+	"index-demo/problem"
+)
+
+// CreateCompositeIndex는 필터 쿼리에 맞춘 복합 인덱스를 생성합니다.
+//
+// 컬럼 순서:
+// 1. level, service: 등호(=) 조건 컬럼을 앞에 배치
+// 2. timestamp DESC: 범위 조건 + ORDER BY timestamp DESC를 인덱스 순서로 해결
+//
+// 결과:
+// - Index Scan으로 (level, service) 구간의 최신 행부터 읽고 100개에서 멈춤
+// - 정렬(Sort) 단계가 사라지고, 읽는 행 수가 LIMIT 수준으로 감소
+func CreateCompositeIndex(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_logs_level_service_timestamp
+		ON logs (level, service, timestamp DESC)`)
+	if err != nil {
+		return fmt.Errorf("인덱스 생성 실패: %w", err)
+	}
+	if _, err := db.Exec("ANALYZE logs"); err != nil {
+		return fmt.Errorf("ANALYZE 실패: %w", err)
+	}
+	return nil
+}
+
+// RunSolutionDemo는 복합 인덱스 생성 후 같은 필터 쿼리를 다시 측정하여 개선 폭을 보여줍니다.
+func RunSolutionDemo(db *sql.DB, before problem.LatencyResult) error {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("✅ 복합 인덱스 (level, service, timestamp DESC)")
+	fmt.Println(repeat("=", 60))
+
+	fmt.Println("\n🛠️  CREATE INDEX idx_logs_level_service_timestamp ON logs (level, service, timestamp DESC)")
+	if err := CreateCompositeIndex(db); err != nil {
+		return err
+	}
+	fmt.Printf("🔍 WHERE level = '%s' AND service = '%s' AND 최근 1시간, LIMIT 100\n\n", problem.Level, problem.Service)
+
+	fmt.Println("📋 EXPLAIN ANALYZE:")
+	problem.PrintExplainAnalyze(db)
+
+	after, err := problem.MeasureFilterQuery(db, problem.Runs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("\n" + repeat("-", 60))
+	problem.PrintLatency(after)
+
+	if after.Avg > 0 {
+		fmt.Printf("\n🎉 평균 지연 시간 %v → %v (약 %.1f배 개선)\n",
+			before.Avg, after.Avg, float64(before.Avg)/float64(after.Avg))
+	}
+	fmt.Printf("💡 Seq Scan + Sort 대신 Index Scan으로 필요한 100행만 읽었습니다.\n")
+	fmt.Println(repeat("=", 60))
+
+	return nil
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}
@@ -0,0 +1,26 @@
+-- Long Transaction Demo Database 초기화 스크립트
+-- load-test/write-server의 logs 테이블과 동일한 스키마를 사용합니다.
+
+-- logs 테이블 생성
+CREATE TABLE logs (
+    id BIGSERIAL PRIMARY KEY,
+    timestamp TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    level VARCHAR(10) NOT NULL,
+    service VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    metadata JSONB
+);
+
+-- 데모 중 autovacuum이 끼어들지 않도록 이 테이블만 비활성화 (VACUUM은 데모에서 직접 실행)
+ALTER TABLE logs SET (autovacuum_enabled = false);
+
+-- 초기 데이터 삽입
+INSERT INTO logs (level, service, message)
+SELECT
+    (ARRAY['INFO', 'WARN', 'ERROR', 'DEBUG'])[floor(random() * 4 + 1)],
+    (ARRAY['auth', 'api', 'worker', 'scheduler'])[floor(random() * 4 + 1)],
+    'Initial log message ' || generate_series
+FROM generate_series(1, 1000);
+
+-- 테이블 정보 출력 (디버깅용)
+SELECT 'Logs table initialized successfully' AS status;
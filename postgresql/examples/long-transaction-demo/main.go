@@ -0,0 +1,106 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"long-transaction-demo/problem"
+	"long-transaction-demo/solution"
+)
+
+const (
+	host     = "localhost"
+	port     = 5436
+	user     = "postgres"
+	password = "postgres"
+	dbname   = "logs"
+)
+
+func main() {
+	fmt.Println("\n" + repeat("=", 70))
+	fmt.Println("🚀 PostgreSQL Long-Running Transaction 데모")
+	fmt.Println(repeat("=", 70))
+
+	// PostgreSQL 연결
+	db := connectDB()
+	defer db.Close()
+
+	// 연결 확인
+	if err := db.Ping(); err != nil {
+		log.Fatalf("❌ 데이터베이스 연결 실패: %v\n", err)
+	}
+	fmt.Println("✅ PostgreSQL 연결 성공")
+
+	// 1. 방치된 트랜잭션이 VACUUM을 막는 문제 재현
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 1: 열린 트랜잭션과 dead tuple 누적")
+	fmt.Println(repeat("*", 70))
+	tx := problem.RunProblemDemo(db)
+
+	// 사용자가 결과를 확인할 수 있도록 잠시 대기
+	fmt.Println("\n⏳ 3초 후 해결책 데모를 시작합니다...")
+	time.Sleep(3 * time.Second)
+
+	// 2. 트랜잭션 종료 후 VACUUM
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 2: 트랜잭션 종료 후 VACUUM 따라잡기")
+	fmt.Println(repeat("*", 70))
+	solution.RunSolutionDemo(db, tx)
+
+	// 최종 요약
+	fmt.Println("\n" + repeat("=", 70))
+	fmt.Println("📚 핵심 요약")
+	fmt.Println(repeat("=", 70))
+	fmt.Println(`
+1️⃣  왜 오래 열린 트랜잭션이 문제인가?
+   - 트랜잭션은 스냅샷(backend_xmin)을 보유
+   - 그 이후 생긴 dead tuple은 "아직 누군가 볼 수 있는" 버전
+   - VACUUM은 xmin horizon 이후의 dead tuple을 제거할 수 없음
+
+2️⃣  결과: Bloat
+   - 테이블/인덱스 크기 증가 → 캐시 효율 저하, 스캔 비용 증가
+   - 장기화되면 XID wraparound 방지를 위한 aggressive vacuum까지 지연
+
+3️⃣  예방책
+   ✅ 트랜잭션은 짧게: 트랜잭션 안에서 외부 호출/사용자 입력 대기 금지
+   ✅ idle_in_transaction_session_timeout 설정
+   💡 pg_stat_activity의 xact_start, backend_xmin으로 오래된 트랜잭션 모니터링
+
+4️⃣  참고
+   - 복제 슬롯, hot_standby_feedback, 준비된 트랜잭션(2PC)도 같은 방식으로 VACUUM을 막음
+   - 자세한 내용: ../../Autovacuum_Tuning_Guide.md`)
+	fmt.Println(repeat("=", 70))
+	fmt.Println("✨ 데모 종료")
+	fmt.Println(repeat("=", 70) + "\n")
+}
+
+// connectDB는 PostgreSQL 데이터베이스에 연결합니다.
+func connectDB() *sql.DB {
+	psqlInfo := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		host, port, user, password, dbname)
+
+	db, err := sql.Open("postgres", psqlInfo)
+	if err != nil {
+		log.Fatalf("❌ 데이터베이스 연결 실패: %v\n", err)
+	}
+
+	// 연결 풀 설정
+	db.SetMaxOpenConns(25)                 // 최대 연결 수
+	db.SetMaxIdleConns(10)                 // 유휴 연결 수
+	db.SetConnMaxLifetime(5 * time.Minute) // 연결 최대 수명
+
+	return db
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}
@@ -0,0 +1,187 @@
+package problem
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// churnRounds는 테이블 전체를 UPDATE하는 횟수입니다. (1000행 × 5회 = 5000개의 dead tuple)
+const churnRounds = 5
+
+// statsFlushDelay는 pg_stat_user_tables에 통계가 반영될 때까지 기다리는 시간입니다.
+// PostgreSQL 15+의 누적 통계는 백엔드가 유휴 상태일 때 최대 1초 간격으로 반영됩니다.
+const statsFlushDelay = 1500 * time.Millisecond
+
+// TableStats는 pg_stat_user_tables에서 조회한 logs 테이블 상태입니다.
+type TableStats struct {
+	LiveTuples int64
+	DeadTuples int64
+}
+
+// HoldSnapshot은 REPEATABLE READ 트랜잭션을 열고 스냅샷을 고정한 뒤 커밋하지 않은 채 반환합니다.
+//
+// ⚠️ 문제점: 이 트랜잭션이 열려 있는 동안
+// 1. 세션은 "idle in transaction" 상태로 스냅샷(backend_xmin)을 계속 보유
+// 2. 스냅샷 이후 UPDATE/DELETE로 생긴 dead tuple은 이 트랜잭션이 "볼 수도 있는" 버전
+// 3. VACUUM은 xmin horizon 이후의 dead tuple을 제거할 수 없음
+// 4. 트랜잭션이 끝날 때까지 테이블과 인덱스가 계속 부풀어 오름 (bloat)
+func HoldSnapshot(db *sql.DB) (*sql.Tx, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+
+	if _, err := tx.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ"); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("격리 수준 설정 실패: %w", err)
+	}
+
+	// 첫 쿼리 시점에 스냅샷 고정
+	var count int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM logs").Scan(&count); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("스냅샷 획득 실패: %w", err)
+	}
+
+	return tx, nil
+}
+
+// GenerateChurn은 logs 테이블 전체를 rounds번 UPDATE하여 dead tuple을 만듭니다.
+//
+// write-server는 INSERT만 하므로 dead tuple이 생기지 않습니다.
+// 실제 서비스의 상태 변경(UPDATE)이나 보존 기간 정리(DELETE)와 같은 churn을 흉내 냅니다.
+func GenerateChurn(db *sql.DB, rounds int) (int64, error) {
+	var total int64
+	for i := 1; i <= rounds; i++ {
+		result, err := db.Exec("UPDATE logs SET message = message || '.', timestamp = NOW()")
+		if err != nil {
+			return total, fmt.Errorf("UPDATE 실패 (round %d): %w", i, err)
+		}
+		n, _ := result.RowsAffected()
+		total += n
+	}
+	return total, nil
+}
+
+// Vacuum은 logs 테이블에 VACUUM을 실행한 뒤 통계가 반영될 때까지 기다립니다.
+func Vacuum(db *sql.DB) error {
+	if _, err := db.Exec("VACUUM logs"); err != nil {
+		return fmt.Errorf("VACUUM 실패: %w", err)
+	}
+	time.Sleep(statsFlushDelay)
+	return nil
+}
+
+// GetTableStats는 logs 테이블의 live/dead tuple 수를 조회합니다.
+func GetTableStats(db *sql.DB) (TableStats, error) {
+	var stats TableStats
+	err := db.QueryRow(
+		"SELECT n_live_tup, n_dead_tup FROM pg_stat_user_tables WHERE relname = 'logs'",
+	).Scan(&stats.LiveTuples, &stats.DeadTuples)
+	if err != nil {
+		return stats, fmt.Errorf("통계 조회 실패: %w", err)
+	}
+	return stats, nil
+}
+
+// PrintIdleTransactions는 idle in transaction 세션과 보유 중인 스냅샷 나이를 출력합니다.
+func PrintIdleTransactions(db *sql.DB) {
+	rows, err := db.Query(`
+		SELECT pid, state, age(backend_xmin), NOW() - xact_start
+		FROM pg_stat_activity
+		WHERE state = 'idle in transaction' AND datname = current_database()`)
+	if err != nil {
+		fmt.Printf("  세션 조회 실패: %v\n", err)
+		return
+	}
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		var pid int
+		var state, xactAge string
+		var xminAge sql.NullInt64
+		if err := rows.Scan(&pid, &state, &xminAge, &xactAge); err != nil {
+			continue
+		}
+		found = true
+		fmt.Printf("  🕰️  pid=%d state=%q xmin_age=%d xact_duration=%s\n", pid, state, xminAge.Int64, xactAge)
+	}
+	if !found {
+		fmt.Println("  (idle in transaction 세션 없음)")
+	}
+}
+
+// RunProblemDemo는 열린 트랜잭션이 VACUUM을 막아 dead tuple이 쌓이는 것을 보여줍니다.
+// 데모가 끝난 뒤에도 트랜잭션은 열린 상태로 반환되며, 호출자가 종료해야 합니다.
+func RunProblemDemo(db *sql.DB) *sql.Tx {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("❌ 오래 열린 트랜잭션이 VACUUM을 막는 문제")
+	fmt.Println(repeat("=", 60))
+
+	// 초기 상태 설정: 기존 dead tuple 정리
+	if err := Vacuum(db); err != nil {
+		fmt.Printf("초기화 실패: %v\n", err)
+		return nil
+	}
+	before, err := GetTableStats(db)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return nil
+	}
+	fmt.Printf("\n📦 초기 상태: live=%d, dead=%d\n", before.LiveTuples, before.DeadTuples)
+
+	// 1단계: 스냅샷을 보유한 채 방치된 트랜잭션
+	tx, err := HoldSnapshot(db)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return nil
+	}
+	fmt.Println("\n🔓 REPEATABLE READ 트랜잭션 시작 후 방치 (COMMIT/ROLLBACK 하지 않음)")
+	PrintIdleTransactions(db)
+
+	// 2단계: churn 발생
+	fmt.Printf("\n🔄 logs 테이블 전체를 %d회 UPDATE (churn 발생)\n", churnRounds)
+	updated, err := GenerateChurn(db, churnRounds)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return tx
+	}
+	fmt.Printf("  %d개 행 버전이 dead tuple이 됨\n", updated)
+
+	// 3단계: VACUUM 시도
+	fmt.Println("\n🧹 VACUUM logs 실행")
+	if err := Vacuum(db); err != nil {
+		fmt.Printf("%v\n", err)
+		return tx
+	}
+	after, err := GetTableStats(db)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return tx
+	}
+
+	fmt.Println("\n" + repeat("-", 60))
+	fmt.Printf("📊 VACUUM 후: live=%d, dead=%d\n", after.LiveTuples, after.DeadTuples)
+
+	if after.DeadTuples > 0 {
+		fmt.Printf("\n🚨 VACUUM을 실행했지만 dead tuple %d개가 그대로 남아 있습니다!\n", after.DeadTuples)
+		fmt.Printf("💡 원인: 열린 트랜잭션의 스냅샷이 이 행 버전들을 아직 \"볼 수 있기\" 때문에\n")
+		fmt.Printf("   VACUUM은 이들을 제거할 수 없습니다. (xmin horizon이 전진하지 못함)\n")
+	} else {
+		fmt.Printf("\n⚠️  예상과 다른 결과입니다. dead tuple이 모두 정리되었습니다.\n")
+	}
+	fmt.Println(repeat("=", 60))
+
+	return tx
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package solution
+
+import (
+	"database/sql"
+	"fmt"
+
+	"long-transaction-demo/problem"
+)
+
+// RunSolutionDemo는 방치된 트랜잭션을 종료하면 VACUUM이 dead tuple을 정리하는 것을 보여줍니다.
+//
+// 작동 원리:
+// 1. 트랜잭션 종료 → 세션의 backend_xmin 해제
+// 2. xmin horizon이 현재 시점으로 전진
+// 3. 어떤 스냅샷도 볼 수 없는 dead tuple은 VACUUM이 제거 가능
+//
+// 예방책:
+// - 트랜잭션은 짧게 유지하고, 트랜잭션 안에서 외부 API 호출/사용자 입력 대기 금지
+// - idle_in_transaction_session_timeout으로 방치된 세션 자동 종료
+// - pg_stat_activity의 xact_start, backend_xmin 모니터링
+func RunSolutionDemo(db *sql.DB, tx *sql.Tx) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("✅ 트랜잭션 종료 후 VACUUM")
+	fmt.Println(repeat("=", 60))
+
+	before, err := problem.GetTableStats(db)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	fmt.Printf("\n📦 현재 상태: live=%d, dead=%d\n", before.LiveTuples, before.DeadTuples)
+
+	// 1단계: 방치된 트랜잭션 종료
+	if tx != nil {
+		if err := tx.Rollback(); err != nil {
+			fmt.Printf("트랜잭션 종료 실패: %v\n", err)
+			return
+		}
+	}
+	fmt.Println("\n🔒 방치된 트랜잭션 종료 (ROLLBACK)")
+	problem.PrintIdleTransactions(db)
+
+	// 2단계: VACUUM 재실행
+	fmt.Println("\n🧹 VACUUM logs 실행")
+	if err := problem.Vacuum(db); err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	after, err := problem.GetTableStats(db)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+
+	fmt.Println("\n" + repeat("-", 60))
+	fmt.Printf("📊 VACUUM 후: live=%d, dead=%d\n", after.LiveTuples, after.DeadTuples)
+
+	if after.DeadTuples == 0 {
+		fmt.Printf("\n🎉 성공! dead tuple %d개가 모두 정리되었습니다.\n", before.DeadTuples)
+		fmt.Printf("💡 스냅샷을 보유한 트랜잭션이 사라지자 VACUUM이 곧바로 따라잡았습니다.\n")
+	} else {
+		fmt.Printf("\n⚠️  dead tuple %d개가 남아 있습니다. 다른 오래된 트랜잭션이 있는지 확인하세요.\n", after.DeadTuples)
+	}
+	fmt.Println(repeat("=", 60))
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}
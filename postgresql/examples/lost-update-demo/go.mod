@@ -2,4 +2,9 @@ module lost-update-demo
 
 go 1.25.5
 
-require github.com/lib/pq v1.10.9 // indirect
+require (
+	github.com/lib/pq v1.10.9
+	retry v0.0.0
+)
+
+replace retry => ../retry
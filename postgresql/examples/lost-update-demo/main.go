@@ -2,14 +2,19 @@ package main
 
 import (
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	_ "github.com/lib/pq"
 
 	"lost-update-demo/problem"
+	"lost-update-demo/setup"
 	"lost-update-demo/solution"
+	"retry"
 )
 
 const (
@@ -21,6 +26,30 @@ const (
 )
 
 func main() {
+	// 경합 구간 대기 시간 (SELECT와 UPDATE 사이, 0이면 대기 없음)
+	delay := flag.Duration("delay", 10*time.Millisecond, "contention sleep between SELECT and UPDATE")
+	trials := flag.Int("trials", 5, "rounds per delay in the contention sweep")
+	products := flag.String("products", "1,2,5,10", "comma-separated product counts (N) for the multi-product demo")
+	productCount := flag.Int("product-count", setup.ProductCount, "number of products seeded before each single-row demo")
+	retryBudget := flag.Duration("retry-budget", retry.DefaultPolicy.Budget, "overall deadline per deduction across all retries in the retry demo (0 = unlimited)")
+	flag.Parse()
+	if *productCount < 1 {
+		log.Fatalf("❌ -product-count는 1 이상이어야 합니다: %d\n", *productCount)
+	}
+	setup.ProductCount = *productCount
+	productCounts, err := parseProductCounts(*products)
+	if err != nil {
+		log.Fatalf("❌ -products 값이 잘못되었습니다: %v\n", err)
+	}
+	if *retryBudget < 0 {
+		log.Fatalf("❌ -retry-budget는 0 이상이어야 합니다: %v\n", *retryBudget)
+	}
+	// 재시도 데모의 정책 (패키지 전역인 retry.DefaultPolicy는 바꾸지 않고 복사해서 사용)
+	retryPolicy := retry.DefaultPolicy
+	retryPolicy.Budget = *retryBudget
+	problem.ContentionDelay = *delay
+	solution.ContentionDelay = *delay
+
 	fmt.Println("\n" + repeat("=", 70))
 	fmt.Println("🚀 PostgreSQL Lost Update 데모")
 	fmt.Println(repeat("=", 70))
@@ -35,21 +64,81 @@ func main() {
 	}
 	fmt.Println("✅ PostgreSQL 연결 성공")
 
+	// 스키마 준비 (테이블이 없으면 생성, 있으면 그대로 사용)
+	if err := setup.EnsureSchema(db); err != nil {
+		log.Fatalf("❌ %v\n", err)
+	}
+	fmt.Println("✅ 스키마 준비 완료")
+
 	// 1. Lost Update 문제 재현
 	fmt.Println("\n" + repeat("*", 70))
 	fmt.Println("PART 1: Lost Update 문제 재현")
 	fmt.Println(repeat("*", 70))
-	problem.RunProblemDemo(db)
+	problemResult, problemErr := problem.RunProblemDemo(db)
 
 	// 사용자가 결과를 확인할 수 있도록 잠시 대기
+	fmt.Println("\n⏳ 3초 후 REPEATABLE READ 데모를 시작합니다...")
+	time.Sleep(3 * time.Second)
+
+	// 2. REPEATABLE READ에서의 동작 (스냅샷 격리를 단계별로 보여준 뒤 동시 차감)
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 2: REPEATABLE READ는 스냅샷을 읽고, Lost Update 대신 직렬화 에러")
+	fmt.Println(repeat("*", 70))
+	problem.RunSnapshotDemo(db)
+	problem.RunRepeatableReadDemo(db)
+
 	fmt.Println("\n⏳ 3초 후 해결책 데모를 시작합니다...")
 	time.Sleep(3 * time.Second)
 
-	// 2. SELECT FOR UPDATE 해결책
+	// 3. SELECT FOR UPDATE 해결책
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 3: SELECT FOR UPDATE 해결책")
+	fmt.Println(repeat("*", 70))
+	solutionResult, solutionErr := solution.RunSolutionDemo(db)
+
+	fmt.Println("\n⏳ 3초 후 경합 구간 스윕을 시작합니다...")
+	time.Sleep(3 * time.Second)
+
+	// 4. 경합 구간 길이에 따른 발생 확률과 잠금 대기 시간
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 4: 경합 구간 길이에 따른 Lost Update 확률과 잠금 대기")
+	fmt.Println(repeat("*", 70))
+	problem.RunContentionSweep(db, problem.DefaultSweepDelays, *trials)
+	solution.RunLockWaitSweep(db, problem.DefaultSweepDelays)
+
+	fmt.Println("\n⏳ 3초 후 다중 상품 경합 데모를 시작합니다...")
+	time.Sleep(3 * time.Second)
+
+	// 5. 잠금 핫스팟 분산
 	fmt.Println("\n" + repeat("*", 70))
-	fmt.Println("PART 2: SELECT FOR UPDATE 해결책")
+	fmt.Println("PART 5: 경합을 여러 행으로 분산하면 처리량이 늘어난다")
 	fmt.Println(repeat("*", 70))
-	solution.RunSolutionDemo(db)
+	solution.RunMultiProductDemo(db, productCounts)
+
+	fmt.Println("\n⏳ 3초 후 재시도 해결책 데모를 시작합니다...")
+	time.Sleep(3 * time.Second)
+
+	// 6. REPEATABLE READ + 재시도
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 6: REPEATABLE READ + 재시도 (지수 백오프)")
+	fmt.Println(repeat("*", 70))
+	solution.RunRetryDemo(db, retryPolicy)
+
+	// 문제 vs 해결책 비교 (PART 1과 PART 3이 모두 실행된 경우)
+	if problemErr == nil && solutionErr == nil {
+		printComparison(problemResult, solutionResult)
+	}
+	// 문제 코드의 손실은 0 이상, 해결책은 항상 0이어야 함 (어기면 데모 실패로 종료)
+	if problemErr == nil {
+		if err := problemResult.Verify(true); err != nil {
+			log.Fatalf("❌ %v\n", err)
+		}
+	}
+	if solutionErr == nil {
+		if err := solutionResult.Verify(false); err != nil {
+			log.Fatalf("❌ %v\n", err)
+		}
+	}
 
 	// 최종 요약
 	fmt.Println("\n" + repeat("=", 70))
@@ -63,8 +152,11 @@ func main() {
 
 2️⃣  REPEATABLE READ로는 왜 부족한가?
    - SELECT는 트랜잭션 시작 시점의 스냅샷을 사용
-   - 하지만 UPDATE는 최신 커밋된 버전을 기준으로 동작
-   - 여전히 Lost Update 발생 가능!
+     (다른 TX가 커밋한 뒤 다시 읽어도 옛 값이 보임, READ COMMITTED는 새 값)
+   - PostgreSQL은 스냅샷 이후 다른 TX가 변경·커밋한 행을 UPDATE하면
+     조용히 덮어쓰지 않고 40001(serialization_failure) 에러를 반환
+   - Lost Update는 막지만, 나중 TX는 실패하므로 재시도 로직이 필수!
+   - (MySQL InnoDB의 REPEATABLE READ는 에러 없이 Lost Update 발생 가능)
 
 3️⃣  SELECT FOR UPDATE의 작동 원리
    - 행(row) 단위 비관적 잠금 (Pessimistic Lock)
@@ -75,17 +167,59 @@ func main() {
    ✅ 장점: 간단하고 확실한 Lost Update 방지
    ⚠️  단점: 동시성 감소, 데드락 가능성
    💡 팁: 항상 동일한 순서로 잠금, 트랜잭션을 짧게 유지
+   ⏳ 잠금 보유 시간이 길수록 대기 시간도 비례해서 증가
 
-5️⃣  대안들
-   - Serializable 격리 수준 + 재시도 로직
+5️⃣  경합 구간과 재현 확률
+   - SELECT와 UPDATE 사이가 짧으면 Lost Update가 드물게만 발생
+   - 드물다고 안전한 것이 아님! 부하가 커지면 반드시 나타남
+   - 같은 행을 노리는 트랜잭션만 서로 기다림 → 핫스팟을 여러 행으로 분산하면 처리량 증가
+
+6️⃣  대안들
+   - REPEATABLE READ/SERIALIZABLE + 재시도 로직 (retry.Do, 지수 백오프 + 지터)
    - 낙관적 잠금 (version 컬럼 사용)
-   - 애플리케이션 레벨 큐/락 (Redis 등)
-`)
+   - 애플리케이션 레벨 큐/락 (Redis 등)`)
 	fmt.Println(repeat("=", 70))
 	fmt.Println("✨ 데모 종료")
 	fmt.Println(repeat("=", 70) + "\n")
 }
 
+// printComparison은 문제(PART 1)와 해결책(PART 3)의 결과를 한 표로 비교합니다.
+func printComparison(problem, solution setup.DemoResult) {
+	fmt.Println("\n" + repeat("=", 70))
+	fmt.Println("📊 문제 vs 해결책 비교")
+	fmt.Println(repeat("=", 70))
+
+	fmt.Printf("\n  %-14s %-22s %s\n", "항목", "❌ "+problem.Name, "✅ "+solution.Name)
+	fmt.Println("  " + repeat("-", 62))
+	fmt.Printf("  %-14s %-22s %s\n", "예상 재고",
+		fmt.Sprintf("%d개", problem.ExpectedStock), fmt.Sprintf("%d개", solution.ExpectedStock))
+	fmt.Printf("  %-14s %-22s %s\n", "최종 재고",
+		fmt.Sprintf("%d개 (손실 %d개)", problem.FinalStock, problem.LostCount),
+		fmt.Sprintf("%d개 (손실 %d개)", solution.FinalStock, solution.LostCount))
+	fmt.Printf("  %-14s %-22s %s\n", "성공 / 실패",
+		fmt.Sprintf("%d / %d", problem.Succeeded, problem.Failed),
+		fmt.Sprintf("%d / %d", solution.Succeeded, solution.Failed))
+	fmt.Printf("  %-14s %-22v %v\n", "실행 시간",
+		problem.Elapsed.Round(time.Millisecond), solution.Elapsed.Round(time.Millisecond))
+	fmt.Printf("  %-14s %-22s %s\n", "처리량",
+		fmt.Sprintf("%.1f건/s", problem.Throughput()), fmt.Sprintf("%.1f건/s", solution.Throughput()))
+
+	fmt.Println("\n" + repeat("-", 70))
+	diff := (solution.Elapsed - problem.Elapsed).Round(time.Millisecond)
+	sign := ""
+	if diff >= 0 {
+		sign = "+"
+	}
+	fmt.Printf("⏱️  실행 시간 차이: %s%v", sign, diff)
+	if problem.Elapsed > 0 {
+		fmt.Printf(" (문제 코드 대비 %.2f배)", float64(solution.Elapsed)/float64(problem.Elapsed))
+	}
+	fmt.Println()
+	fmt.Printf("💡 해결책은 잠금을 기다리는 만큼 느리지만, 커밋에 성공한 차감이 하나도 사라지지 않습니다.\n")
+	fmt.Printf("   문제 코드는 빨라 보여도 손실된 %d개는 \"성공\"으로 응답한 주문입니다.\n", problem.LostCount)
+	fmt.Println(repeat("=", 70))
+}
+
 // connectDB는 PostgreSQL 데이터베이스에 연결합니다.
 func connectDB() *sql.DB {
 	psqlInfo := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
@@ -104,6 +238,22 @@ func connectDB() *sql.DB {
 	return db
 }
 
+// parseProductCounts는 "1,2,5,10" 형식의 상품 수 목록을 파싱합니다.
+func parseProductCounts(s string) ([]int, error) {
+	var counts []int
+	for _, part := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if n < 1 {
+			return nil, fmt.Errorf("product count must be positive: %d", n)
+		}
+		counts = append(counts, n)
+	}
+	return counts, nil
+}
+
 // repeat는 문자열을 n번 반복합니다.
 func repeat(s string, n int) string {
 	result := ""
@@ -0,0 +1,107 @@
+package problem
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"lost-update-demo/setup"
+)
+
+// DefaultSweepDelays는 경합 구간 스윕에서 비교할 기본 대기 시간 목록입니다.
+var DefaultSweepDelays = []time.Duration{
+	0,
+	1 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+}
+
+// sweepResult는 한 대기 시간에 대한 스윕 결과입니다.
+type sweepResult struct {
+	Delay       time.Duration
+	Trials      int
+	LostTrials  int // Lost Update가 한 번이라도 발생한 시도 수
+	AvgLost     float64
+	AvgDuration time.Duration
+}
+
+// runDeductRound는 재고를 100으로 초기화하고 10개의 고루틴이 10개씩 동시에 차감한 뒤
+// 손실된 재고 수량을 반환합니다. (RunProblemDemo와 같은 시나리오, 출력 없음)
+func runDeductRound(db *sql.DB) (int, time.Duration, error) {
+	if err := setup.SeedProducts(db, setup.ProductCount, 100); err != nil {
+		return 0, 0, err
+	}
+
+	var wg sync.WaitGroup
+	startTime := time.Now()
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			DeductStockWithProblem(db, 1, 10)
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(startTime)
+
+	var finalStock int
+	if err := db.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&finalStock); err != nil {
+		return 0, 0, fmt.Errorf("재고 조회 실패: %w", err)
+	}
+	// 모든 차감이 반영되면 0이므로 남은 재고가 곧 손실된 수량
+	return finalStock, elapsed, nil
+}
+
+// RunContentionSweep은 ContentionDelay를 바꿔가며 같은 시나리오를 trials번씩 반복해
+// 경합 구간 길이에 따라 Lost Update 발생 확률이 어떻게 달라지는지 보여줍니다.
+// 실행이 끝나면 ContentionDelay는 원래 값으로 복원됩니다.
+func RunContentionSweep(db *sql.DB, delays []time.Duration, trials int) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("📈 경합 구간 길이에 따른 Lost Update 발생 확률 (READ COMMITTED)")
+	fmt.Println(repeat("=", 60))
+	fmt.Printf("\n🔄 대기 시간마다 %d회 반복 (10개 고루틴 × 10개 차감)\n\n", trials)
+
+	original := ContentionDelay
+	defer func() { ContentionDelay = original }()
+
+	results := make([]sweepResult, 0, len(delays))
+	for _, delay := range delays {
+		ContentionDelay = delay
+
+		result := sweepResult{Delay: delay}
+		var totalLost int
+		var totalDuration time.Duration
+		for i := 0; i < trials; i++ {
+			lost, elapsed, err := runDeductRound(db)
+			if err != nil {
+				fmt.Printf("  ❌ %v\n", err)
+				continue
+			}
+			result.Trials++
+			totalLost += lost
+			totalDuration += elapsed
+			if lost > 0 {
+				result.LostTrials++
+			}
+		}
+		if result.Trials > 0 {
+			result.AvgLost = float64(totalLost) / float64(result.Trials)
+			result.AvgDuration = totalDuration / time.Duration(result.Trials)
+		}
+		results = append(results, result)
+	}
+
+	fmt.Printf("  %-10s %-16s %-14s %s\n", "대기 시간", "Lost Update 발생", "평균 손실 재고", "평균 실행 시간")
+	fmt.Println("  " + repeat("-", 56))
+	for _, r := range results {
+		fmt.Printf("  %-10v %2d / %-11d %-14.1f %v\n",
+			r.Delay, r.LostTrials, r.Trials, r.AvgLost, r.AvgDuration.Round(time.Millisecond))
+	}
+
+	fmt.Println("\n" + repeat("-", 60))
+	fmt.Printf("💡 대기 시간이 0이면 SELECT와 UPDATE 사이 틈이 짧아 Lost Update가 드물게만 나타납니다.\n")
+	fmt.Printf("   대기 시간이 길어질수록 다른 트랜잭션이 끼어들 확률이 커져 거의 매번 발생합니다.\n")
+	fmt.Printf("   → 테스트에서 재현되지 않았다고 해서 안전한 코드가 아닙니다!\n")
+	fmt.Println(repeat("=", 60))
+}
@@ -5,8 +5,15 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"lost-update-demo/setup"
 )
 
+// ContentionDelay는 SELECT와 UPDATE 사이에 두는 대기 시간입니다 (경합 구간 시뮬레이션).
+// 값이 클수록 다른 트랜잭션이 끼어들 여지가 커져 Lost Update가 거의 확정적으로 발생하고,
+// 0이면 경합 구간이 짧아 문제가 드물게만 나타납니다.
+var ContentionDelay = 10 * time.Millisecond
+
 // DeductStockWithProblem은 Lost Update 문제가 발생하는 재고 차감 함수입니다.
 // READ COMMITTED 격리 수준(PostgreSQL 기본값)에서 실행됩니다.
 //
@@ -36,7 +43,7 @@ func DeductStockWithProblem(db *sql.DB, productID int, quantity int) error {
 	}
 
 	// 3단계: 경합 상황 시뮬레이션 (다른 트랜잭션이 동시에 실행될 시간을 줌)
-	time.Sleep(10 * time.Millisecond)
+	time.Sleep(ContentionDelay)
 
 	// 4단계: 재고 차감 (Lost Update 발생!)
 	// ⚠️ 문제: stock 변수는 이전에 읽은 값이므로, 다른 TX가 중간에 변경한 내용이 반영되지 않음
@@ -54,27 +61,29 @@ func DeductStockWithProblem(db *sql.DB, productID int, quantity int) error {
 	return nil
 }
 
-// RunProblemDemo는 Lost Update 문제를 재현하는 데모를 실행합니다.
-func RunProblemDemo(db *sql.DB) {
+// RunProblemDemo는 Lost Update 문제를 재현하는 데모를 실행하고 결과를 반환합니다.
+func RunProblemDemo(db *sql.DB) (setup.DemoResult, error) {
 	fmt.Println("\n" + repeat("=", 60))
 	fmt.Println("❌ Lost Update 문제 재현 (READ COMMITTED)")
 	fmt.Println(repeat("=", 60))
 
 	// 초기 재고 설정
-	_, err := db.Exec("UPDATE products SET stock = 100 WHERE id = 1")
-	if err != nil {
+	if err := setup.SeedProducts(db, setup.ProductCount, 100); err != nil {
 		fmt.Printf("초기 재고 설정 실패: %v\n", err)
-		return
+		return setup.DemoResult{}, err
 	}
 
 	var initialStock int
 	db.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&initialStock)
 	fmt.Printf("\n📦 초기 재고: %d개\n", initialStock)
 	fmt.Printf("🔄 10개의 고루틴이 각각 10개씩 차감 시도\n")
+	fmt.Printf("⏳ 경합 구간 대기: %v\n", ContentionDelay)
 	fmt.Printf("📊 예상 최종 재고: %d - (10 × 10) = 0개\n\n", initialStock)
 
 	// 동시성 테스트
 	var wg sync.WaitGroup
+	var successCount int
+	var mu sync.Mutex
 	startTime := time.Now()
 
 	// 10개의 goroutine이 동시에 재고 10개씩 차감
@@ -83,9 +92,12 @@ func RunProblemDemo(db *sql.DB) {
 		go func(num int) {
 			defer wg.Done()
 			err := DeductStockWithProblem(db, 1, 10)
+			mu.Lock()
+			defer mu.Unlock()
 			if err != nil {
 				fmt.Printf("  [고루틴 %2d] ❌ 실패: %v\n", num, err)
 			} else {
+				successCount++
 				fmt.Printf("  [고루틴 %2d] ✅ 10개 차감 완료\n", num)
 			}
 		}(i)
@@ -98,7 +110,7 @@ func RunProblemDemo(db *sql.DB) {
 	var finalStock int
 	db.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&finalStock)
 
-	fmt.Printf("\n" + repeat("-", 60) + "\n")
+	fmt.Println("\n" + repeat("-", 60))
 	fmt.Printf("⏱️  실행 시간: %v\n", elapsed)
 	fmt.Printf("📊 최종 재고: %d개\n", finalStock)
 
@@ -111,6 +123,8 @@ func RunProblemDemo(db *sql.DB) {
 		fmt.Printf("   (타이밍에 따라 발생하지 않을 수도 있습니다. 다시 실행해보세요)\n")
 	}
 	fmt.Println(repeat("=", 60))
+
+	return setup.NewDemoResult("READ COMMITTED", initialStock, finalStock, 10, successCount, 10, elapsed), nil
 }
 
 // repeat는 문자열을 n번 반복합니다 (헬퍼 함수)
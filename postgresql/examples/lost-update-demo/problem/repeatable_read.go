@@ -0,0 +1,141 @@
+package problem
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"lost-update-demo/setup"
+	"retry"
+)
+
+// DeductStockWithRepeatableRead는 DeductStockWithProblem과 같은 "읽고-계산하고-쓰기" 로직을
+// REPEATABLE READ 격리 수준에서 실행합니다.
+//
+// PostgreSQL의 REPEATABLE READ는 스냅샷 격리(Snapshot Isolation)이므로:
+//  1. SELECT는 트랜잭션 시작 시점의 스냅샷을 읽음
+//  2. 다른 트랜잭션이 같은 행을 변경하고 커밋하면
+//  3. 이 트랜잭션의 UPDATE는 조용히 덮어쓰지 않고
+//     "could not serialize access due to concurrent update" (40001) 에러로 실패
+//
+// 즉, Lost Update는 발생하지 않지만 나중에 커밋하려는 트랜잭션은 실패하므로
+// 애플리케이션이 재시도해야 합니다. (MySQL InnoDB의 REPEATABLE READ와 다른 동작)
+// ctx가 취소되면(재시도 예산 초과 등) 진행 중인 쿼리도 취소됩니다.
+func DeductStockWithRepeatableRead(ctx context.Context, db *sql.DB, productID int, quantity int) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	// REPEATABLE READ 명시 (트랜잭션의 첫 쿼리 전에 설정해야 함)
+	if _, err := tx.ExecContext(ctx, "SET TRANSACTION ISOLATION LEVEL REPEATABLE READ"); err != nil {
+		return fmt.Errorf("격리 수준 설정 실패: %w", err)
+	}
+
+	// 1단계: 현재 재고 조회 (이 시점에 스냅샷 고정)
+	var stock int
+	err = tx.QueryRowContext(ctx, "SELECT stock FROM products WHERE id = $1", productID).Scan(&stock)
+	if err != nil {
+		return fmt.Errorf("재고 조회 실패: %w", err)
+	}
+
+	// 2단계: 재고 충분한지 확인
+	if stock < quantity {
+		return fmt.Errorf("재고 부족: 현재 %d개, 요청 %d개", stock, quantity)
+	}
+
+	// 3단계: 경합 상황 시뮬레이션
+	time.Sleep(ContentionDelay)
+
+	// 4단계: 재고 차감
+	// ⚠️ 다른 TX가 이미 이 행을 변경하고 커밋했다면 여기서 40001 에러 발생
+	newStock := stock - quantity
+	_, err = tx.ExecContext(ctx, "UPDATE products SET stock = $1 WHERE id = $2", newStock, productID)
+	if err != nil {
+		return fmt.Errorf("재고 업데이트 실패: %w", err)
+	}
+
+	// 5단계: 커밋
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("커밋 실패: %w", err)
+	}
+
+	return nil
+}
+
+// IsSerializationFailure는 에러가 PostgreSQL 40001(serialization_failure)인지 확인합니다.
+func IsSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && string(pqErr.Code) == retry.SerializationFailure
+}
+
+// RunRepeatableReadDemo는 REPEATABLE READ에서 Lost Update 대신 직렬화 에러가 발생함을 보여줍니다.
+func RunRepeatableReadDemo(db *sql.DB) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("🔁 REPEATABLE READ에서의 동작 (PostgreSQL)")
+	fmt.Println(repeat("=", 60))
+
+	// 초기 재고 설정
+	if err := setup.SeedProducts(db, setup.ProductCount, 100); err != nil {
+		fmt.Printf("초기 재고 설정 실패: %v\n", err)
+		return
+	}
+
+	var initialStock int
+	db.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&initialStock)
+	fmt.Printf("\n📦 초기 재고: %d개\n", initialStock)
+	fmt.Printf("🔄 10개의 고루틴이 각각 10개씩 차감 시도 (REPEATABLE READ, 재시도 없음)\n\n")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var successCount, serializationCount, otherFailCount int
+	startTime := time.Now()
+
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(num int) {
+			defer wg.Done()
+			err := DeductStockWithRepeatableRead(context.Background(), db, 1, 10)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				successCount++
+				fmt.Printf("  [고루틴 %2d] ✅ 10개 차감 완료\n", num)
+			case IsSerializationFailure(err):
+				serializationCount++
+				fmt.Printf("  [고루틴 %2d] 🚫 직렬화 실패 (40001): %v\n", num, err)
+			default:
+				otherFailCount++
+				fmt.Printf("  [고루틴 %2d] ❌ 실패: %v\n", num, err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	elapsed := time.Since(startTime)
+
+	var finalStock int
+	db.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&finalStock)
+	expectedStock := initialStock - successCount*10
+
+	fmt.Println("\n" + repeat("-", 60))
+	fmt.Printf("⏱️  실행 시간: %v\n", elapsed)
+	fmt.Printf("📊 성공: %d건, 직렬화 실패: %d건, 기타 실패: %d건\n", successCount, serializationCount, otherFailCount)
+	fmt.Printf("📊 최종 재고: %d개 (성공 건수 기준 기대값: %d개)\n", finalStock, expectedStock)
+
+	if finalStock == expectedStock {
+		fmt.Printf("\n💡 Lost Update는 발생하지 않았습니다. 최종 재고가 성공한 차감 건수와 정확히 일치합니다.\n")
+		fmt.Printf("   대신 먼저 커밋한 TX와 같은 행을 수정하려던 TX는 40001 에러로 실패했습니다.\n")
+		fmt.Printf("   → REPEATABLE READ만으로는 \"모든 차감 성공\"을 보장할 수 없으며, 재시도 로직이 필요합니다.\n")
+	} else {
+		fmt.Printf("\n⚠️  예상과 다른 결과입니다. (기대값: %d, 실제: %d)\n", expectedStock, finalStock)
+	}
+	fmt.Println(repeat("=", 60))
+}
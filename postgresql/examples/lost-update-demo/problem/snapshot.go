@@ -0,0 +1,135 @@
+package problem
+
+import (
+	"database/sql"
+	"fmt"
+
+	"lost-update-demo/setup"
+)
+
+// snapshotResult는 한 격리 수준에서 스냅샷 시나리오를 실행한 결과입니다.
+type snapshotResult struct {
+	firstRead  int   // TX1의 첫 SELECT
+	reRead     int   // TX2 커밋 후 TX1의 두 번째 SELECT
+	committed  int   // TX2가 커밋한 값 (TX1 밖에서 조회)
+	updateErr  error // TX1의 UPDATE 에러 (성공하면 nil)
+	finalStock int   // TX1이 끝난 뒤 최종 재고
+}
+
+// runSnapshotScenario는 격리 수준 isolation에서 다음 순서를 한 단계씩 실행합니다.
+//
+// 1. TX1 시작, id=1 재고 조회
+// 2. TX2(자동 커밋)가 같은 행을 10개 차감하고 커밋
+// 3. TX1이 같은 행을 다시 조회
+// 4. TX1이 같은 행을 10개 차감 (UPDATE ... SET stock = stock - 10)
+//
+// READ COMMITTED는 문장마다 새 스냅샷을 찍으므로 3단계에서 TX2의 변경이 보이고 4단계도 최신 값에서 차감합니다.
+// REPEATABLE READ는 첫 문장의 스냅샷을 트랜잭션 끝까지 쓰므로 3단계에서 여전히 옛 값이 보이고,
+// 스냅샷 이후 변경된 행을 수정하려는 4단계는 40001(serialization_failure)로 실패합니다.
+// 경합 타이밍에 의존하지 않으므로 매번 같은 결과가 나옵니다.
+func runSnapshotScenario(db *sql.DB, isolation string) (snapshotResult, error) {
+	var result snapshotResult
+
+	if err := setup.SeedProducts(db, setup.ProductCount, 100); err != nil {
+		return result, fmt.Errorf("초기 재고 설정 실패: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return result, fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 격리 수준은 트랜잭션의 첫 쿼리 전에 설정해야 함
+	if _, err := tx.Exec("SET TRANSACTION ISOLATION LEVEL " + isolation); err != nil {
+		return result, fmt.Errorf("격리 수준 설정 실패: %w", err)
+	}
+
+	// 1단계: TX1 첫 조회 (REPEATABLE READ는 이 시점에 스냅샷 고정)
+	if err := tx.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&result.firstRead); err != nil {
+		return result, fmt.Errorf("재고 조회 실패: %w", err)
+	}
+
+	// 2단계: TX2가 다른 연결에서 차감하고 커밋 (db.Exec는 자동 커밋)
+	if _, err := db.Exec("UPDATE products SET stock = stock - 10 WHERE id = 1"); err != nil {
+		return result, fmt.Errorf("TX2 업데이트 실패: %w", err)
+	}
+	if err := db.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&result.committed); err != nil {
+		return result, fmt.Errorf("재고 조회 실패: %w", err)
+	}
+
+	// 3단계: TX1 재조회
+	if err := tx.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&result.reRead); err != nil {
+		return result, fmt.Errorf("재고 재조회 실패: %w", err)
+	}
+
+	// 4단계: TX1이 TX2가 변경한 행을 수정
+	if _, err := tx.Exec("UPDATE products SET stock = stock - 10 WHERE id = 1"); err != nil {
+		result.updateErr = err
+		tx.Rollback()
+	} else if err := tx.Commit(); err != nil {
+		result.updateErr = err
+	}
+
+	if err := db.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&result.finalStock); err != nil {
+		return result, fmt.Errorf("최종 재고 조회 실패: %w", err)
+	}
+	return result, nil
+}
+
+// RunSnapshotDemo는 같은 시나리오를 READ COMMITTED와 REPEATABLE READ에서 실행해
+// REPEATABLE READ가 트랜잭션 시작 시점의 스냅샷을 계속 읽는다는 것(스냅샷 격리)을 보여줍니다.
+func RunSnapshotDemo(db *sql.DB) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("📸 스냅샷 격리: 다른 TX가 커밋한 변경이 보이는가?")
+	fmt.Println(repeat("=", 60))
+
+	levels := []string{"READ COMMITTED", "REPEATABLE READ"}
+	results := make(map[string]snapshotResult, len(levels))
+
+	for _, level := range levels {
+		fmt.Printf("\n▶ TX1 격리 수준: %s\n", level)
+
+		result, err := runSnapshotScenario(db, level)
+		if err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			return
+		}
+		results[level] = result
+
+		fmt.Printf("  [TX1] SELECT stock → %d개\n", result.firstRead)
+		fmt.Printf("  [TX2] UPDATE stock = stock - 10, COMMIT → 커밋된 값 %d개\n", result.committed)
+		if result.reRead == result.firstRead {
+			fmt.Printf("  [TX1] SELECT stock → %d개 (📸 TX2의 커밋이 보이지 않음, 첫 조회의 스냅샷)\n", result.reRead)
+		} else {
+			fmt.Printf("  [TX1] SELECT stock → %d개 (👀 TX2의 커밋이 보임, 문장마다 새 스냅샷)\n", result.reRead)
+		}
+		switch {
+		case result.updateErr == nil:
+			fmt.Printf("  [TX1] UPDATE stock = stock - 10, COMMIT → ✅ 성공 (최신 커밋 버전에서 차감)\n")
+		case IsSerializationFailure(result.updateErr):
+			fmt.Printf("  [TX1] UPDATE stock = stock - 10 → 🚫 직렬화 실패 (40001): %v\n", result.updateErr)
+		default:
+			fmt.Printf("  [TX1] UPDATE stock = stock - 10 → ❌ 실패: %v\n", result.updateErr)
+		}
+		fmt.Printf("  📦 최종 재고: %d개\n", result.finalStock)
+	}
+
+	fmt.Println("\n" + repeat("-", 60))
+	fmt.Printf("%-16s %8s %8s %8s   %s\n", "격리 수준", "첫 조회", "재조회", "최종", "TX1 UPDATE")
+	for _, level := range levels {
+		result := results[level]
+		outcome := "✅ 성공"
+		if IsSerializationFailure(result.updateErr) {
+			outcome = "🚫 40001"
+		} else if result.updateErr != nil {
+			outcome = "❌ 실패"
+		}
+		fmt.Printf("%-16s %8d %8d %8d   %s\n", level, result.firstRead, result.reRead, result.finalStock, outcome)
+	}
+
+	fmt.Printf("\n💡 REPEATABLE READ는 첫 문장에서 찍은 스냅샷을 트랜잭션 끝까지 읽습니다 (스냅샷 격리).\n")
+	fmt.Printf("   그래서 재조회해도 옛 값이 보이고, 스냅샷 이후 다른 TX가 바꾼 행을 수정하려 하면 덮어쓰지 않고 40001로 실패합니다.\n")
+	fmt.Printf("   READ COMMITTED는 문장마다 새 스냅샷을 찍어 최신 커밋 값이 보이고, UPDATE도 최신 버전에 적용됩니다.\n")
+	fmt.Println(repeat("=", 60))
+}
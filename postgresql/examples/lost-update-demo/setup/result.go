@@ -0,0 +1,55 @@
+package setup
+
+import (
+	"fmt"
+	"time"
+)
+
+// DemoResult는 동시 차감 데모 한 번의 결과입니다.
+// main.go가 문제(PART 1)와 해결책(PART 3)의 결과를 한 표로 비교하는 데 사용합니다.
+type DemoResult struct {
+	Name          string
+	InitialStock  int
+	ExpectedStock int // 모든 차감이 반영되었을 때의 재고 (초기 재고 - 시도 수 × 수량)
+	FinalStock    int
+	Succeeded     int // 커밋에 성공한 차감 수
+	Failed        int
+	// LostCount는 커밋에 성공했지만 재고에 반영되지 않은 수량입니다 (Lost Update).
+	// 성공한 차감이 모두 반영되었다면 최종 재고는 초기 재고 - 성공 수 × 수량이어야 합니다.
+	LostCount int
+	Elapsed   time.Duration
+}
+
+// NewDemoResult는 quantity개씩 attempts번 차감을 시도한 데모의 결과를 만들고 LostCount를 계산합니다.
+func NewDemoResult(name string, initialStock, finalStock, attempts, succeeded, quantity int, elapsed time.Duration) DemoResult {
+	return DemoResult{
+		Name:          name,
+		InitialStock:  initialStock,
+		ExpectedStock: initialStock - attempts*quantity,
+		FinalStock:    finalStock,
+		Succeeded:     succeeded,
+		Failed:        attempts - succeeded,
+		LostCount:     finalStock - (initialStock - succeeded*quantity),
+		Elapsed:       elapsed,
+	}
+}
+
+// Verify는 LostCount의 불변식을 확인합니다. 음수 손실(성공한 차감보다 재고가 더 줄어듦)은 어느 쪽이든 잘못된 결과이고,
+// allowLost가 false(해결책)면 손실이 하나라도 있어도 에러입니다. 문제 코드는 타이밍에 따라 손실이 0일 수 있으므로 allowLost로 확인합니다.
+func (r DemoResult) Verify(allowLost bool) error {
+	if r.LostCount < 0 {
+		return fmt.Errorf("%s: lost count is negative (%d): stock dropped by more than the %d successful deductions", r.Name, r.LostCount, r.Succeeded)
+	}
+	if !allowLost && r.LostCount != 0 {
+		return fmt.Errorf("%s: %d units lost although every deduction should be applied", r.Name, r.LostCount)
+	}
+	return nil
+}
+
+// Throughput은 초당 성공한 차감 수입니다.
+func (r DemoResult) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Succeeded) / r.Elapsed.Seconds()
+}
@@ -0,0 +1,38 @@
+package setup
+
+import (
+	"testing"
+	"time"
+)
+
+// LostCount 계산과 문제/해결책 불변식(문제 >= 0, 해결책 == 0) 확인을 검증합니다.
+func TestDemoResultVerify(t *testing.T) {
+	tests := []struct {
+		name      string
+		final     int
+		succeeded int
+		allowLost bool
+		wantLost  int
+		wantErr   bool
+	}{
+		{"solution: all applied", 0, 10, false, 0, false},
+		{"solution: some failed, all successes applied", 30, 7, false, 0, false},
+		{"solution: lost update", 20, 10, false, 20, true},
+		{"problem: lost update", 60, 10, true, 60, false},
+		{"problem: no loss this time", 0, 10, true, 0, false},
+		{"problem: negative loss", -10, 10, true, -10, true},
+		{"solution: negative loss", -10, 10, false, -10, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 재고 100개에서 10개씩 10번 차감 시도
+			r := NewDemoResult(tt.name, 100, tt.final, 10, tt.succeeded, 10, time.Second)
+			if r.LostCount != tt.wantLost {
+				t.Fatalf("LostCount = %d, want %d", r.LostCount, tt.wantLost)
+			}
+			if err := r.Verify(tt.allowLost); (err != nil) != tt.wantErr {
+				t.Fatalf("Verify(%v) = %v, want error %v", tt.allowLost, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+-- Lost Update 데모 스키마 (데모 시작 시 매번 실행, 여러 번 실행해도 안전)
+
+-- products 테이블 생성
+CREATE TABLE IF NOT EXISTS products (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    stock INTEGER NOT NULL CHECK (stock >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 낙관적 잠금용 version 컬럼 (이전 버전 init.sql로 만든 테이블에도 추가)
+ALTER TABLE products ADD COLUMN IF NOT EXISTS version INTEGER DEFAULT 0;
+
+-- 모든 데모가 사용하는 id=1 상품
+INSERT INTO products (id, name, stock) VALUES (1, 'iPhone 15', 100)
+ON CONFLICT (id) DO NOTHING;
+
+-- id를 직접 지정해 INSERT했으므로 SERIAL 시퀀스를 현재 최대 id에 맞춤
+SELECT setval(pg_get_serial_sequence('products', 'id'), (SELECT MAX(id) FROM products));
@@ -0,0 +1,58 @@
+package setup
+
+import (
+	"database/sql"
+	_ "embed"
+	"fmt"
+)
+
+// schema는 데모에 필요한 테이블과 기본 데이터를 만드는 SQL입니다 (바이너리에 포함).
+//
+//go:embed schema.sql
+var schema string
+
+// EnsureSchema는 products 테이블, version 컬럼, id=1 상품이 없으면 만듭니다.
+// 이미 있으면 아무것도 바꾸지 않으므로 데모를 시작할 때마다 호출해도 안전합니다.
+// docker-compose의 init.sql 없이 빈 inventory 데이터베이스에서도 데모를 실행할 수 있습니다.
+func EnsureSchema(db *sql.DB) error {
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("스키마 초기화 실패: %w", err)
+	}
+	return nil
+}
+
+// ProductCount는 각 데모가 시작할 때 SeedProducts로 채우는 상품 수입니다 (-product-count 플래그).
+// 단일 행 데모는 id=1만 사용하지만, 같은 테이블 상태에서 시작하도록 모두 같은 수로 채웁니다.
+var ProductCount = 3
+
+// SeedProducts는 products 테이블을 비우고 id 1..count 상품을 initialStock 재고로 다시 채웁니다.
+// 각 데모가 이전 데모의 결과와 무관하게 같은 상태에서 시작하도록 데모 시작 시 호출합니다.
+func SeedProducts(db *sql.DB, count, initialStock int) error {
+	if count < 1 {
+		return fmt.Errorf("상품 수는 1 이상이어야 합니다: %d", count)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %w", err)
+	}
+	defer tx.Rollback()
+
+	// RESTART IDENTITY로 id를 1부터 다시 매김
+	if _, err := tx.Exec("TRUNCATE products RESTART IDENTITY"); err != nil {
+		return fmt.Errorf("상품 초기화 실패: %w", err)
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO products (name, stock) SELECT 'Product ' || g, $2 FROM generate_series(1, $1::int) AS g",
+		count, initialStock,
+	)
+	if err != nil {
+		return fmt.Errorf("상품 추가 실패: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("커밋 실패: %w", err)
+	}
+	return nil
+}
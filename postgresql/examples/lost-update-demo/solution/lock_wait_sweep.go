@@ -0,0 +1,58 @@
+package solution
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"lost-update-demo/setup"
+)
+
+// RunLockWaitSweep은 ContentionDelay(잠금 보유 시간)를 바꿔가며 SELECT FOR UPDATE 차감을 실행해
+// 잠금 보유 시간에 따라 전체 실행 시간(= 잠금 대기 시간)이 어떻게 늘어나는지 보여줍니다.
+// 실행이 끝나면 ContentionDelay는 원래 값으로 복원됩니다.
+func RunLockWaitSweep(db *sql.DB, delays []time.Duration) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("⏳ 잠금 보유 시간에 따른 대기 시간 (SELECT FOR UPDATE)")
+	fmt.Println(repeat("=", 60))
+	fmt.Printf("\n🔄 대기 시간마다 10개 고루틴 × 10개 차감\n\n")
+
+	original := ContentionDelay
+	defer func() { ContentionDelay = original }()
+
+	fmt.Printf("  %-10s %-12s %-10s %s\n", "대기 시간", "실행 시간", "최종 재고", "직렬 실행 하한")
+	fmt.Println("  " + repeat("-", 56))
+	for _, delay := range delays {
+		ContentionDelay = delay
+
+		if err := setup.SeedProducts(db, setup.ProductCount, 100); err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		startTime := time.Now()
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				DeductStockWithLock(db, 1, 10)
+			}()
+		}
+		wg.Wait()
+		elapsed := time.Since(startTime)
+
+		var finalStock int
+		db.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&finalStock)
+
+		// 잠금 때문에 10개의 트랜잭션이 한 줄로 실행되므로 최소 10 × delay가 걸림
+		fmt.Printf("  %-10v %-12v %-10d %v\n", delay, elapsed.Round(time.Millisecond), finalStock, 10*delay)
+	}
+
+	fmt.Println("\n" + repeat("-", 60))
+	fmt.Printf("💡 최종 재고는 대기 시간과 관계없이 항상 0개입니다 (Lost Update 없음).\n")
+	fmt.Printf("   대신 잠금 보유 시간이 길수록 나머지 트랜잭션의 대기 시간이 비례해서 늘어납니다.\n")
+	fmt.Printf("   → 잠금을 잡은 구간은 최대한 짧게 유지하세요!\n")
+	fmt.Println(repeat("=", 60))
+}
@@ -0,0 +1,102 @@
+package solution
+
+import (
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"lost-update-demo/setup"
+)
+
+const (
+	multiProductWorkers    = 10 // 동시에 차감하는 고루틴 수
+	multiProductDeductions = 20 // 고루틴당 차감 횟수
+	multiProductStock      = 1000
+)
+
+// runMultiProductRound는 상품 n개(id 1..n)를 새로 채운 뒤 고루틴들이 무작위로 고른 상품에서
+// 1개씩 차감하게 하고 성공 건수, 실행 시간, 남은 재고 합계를 반환합니다.
+func runMultiProductRound(db *sql.DB, n int) (int64, time.Duration, int, error) {
+	if err := setup.SeedProducts(db, n, multiProductStock); err != nil {
+		return 0, 0, 0, err
+	}
+
+	var wg sync.WaitGroup
+	var successCount atomic.Int64
+	startTime := time.Now()
+	for i := 0; i < multiProductWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < multiProductDeductions; j++ {
+				id := rand.Intn(n) + 1
+				if err := DeductStockWithLock(db, id, 1); err == nil {
+					successCount.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(startTime)
+
+	var remaining int
+	if err := db.QueryRow("SELECT SUM(stock) FROM products").Scan(&remaining); err != nil {
+		return 0, 0, 0, fmt.Errorf("재고 조회 실패: %w", err)
+	}
+
+	return successCount.Load(), elapsed, remaining, nil
+}
+
+// RunMultiProductDemo는 차감 대상을 N개 상품에 분산했을 때 SELECT FOR UPDATE의 처리량이
+// 어떻게 달라지는지 보여줍니다. 모든 고루틴이 한 행을 노리면 잠금 때문에 한 줄로 실행되지만,
+// 서로 다른 행을 잠그는 트랜잭션끼리는 기다리지 않으므로 N이 커질수록 처리량이 늘어납니다.
+func RunMultiProductDemo(db *sql.DB, productCounts []int) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("🔀 다중 상품 경합 데모 (SELECT FOR UPDATE)")
+	fmt.Println(repeat("=", 60))
+
+	total := multiProductWorkers * multiProductDeductions
+	fmt.Printf("\n🔄 %d개의 고루틴이 각각 %d번, N개 상품 중 무작위로 1개씩 차감 (총 %d건)\n",
+		multiProductWorkers, multiProductDeductions, total)
+	fmt.Printf("⏳ 잠금 보유 중 대기: %v\n\n", ContentionDelay)
+
+	fmt.Printf("  %-6s %-10s %-12s %-14s %s\n", "N", "성공", "실행 시간", "처리량", "정합성")
+	fmt.Println("  " + repeat("-", 56))
+
+	var baseline float64
+	for _, n := range productCounts {
+		if n < 1 {
+			continue
+		}
+
+		success, elapsed, remaining, err := runMultiProductRound(db, n)
+		if err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			return
+		}
+
+		throughput := float64(success) / elapsed.Seconds()
+		if baseline == 0 {
+			baseline = throughput
+		}
+
+		// 성공한 차감 수만큼 정확히 재고가 줄었는지 확인
+		consistent := "✅"
+		if expected := n*multiProductStock - int(success); remaining != expected {
+			consistent = fmt.Sprintf("❌ (기대 %d, 실제 %d)", expected, remaining)
+		}
+
+		fmt.Printf("  %-6d %-10d %-12v %-14s %s\n",
+			n, success, elapsed.Round(time.Millisecond),
+			fmt.Sprintf("%.0f/s (x%.1f)", throughput, throughput/baseline), consistent)
+	}
+
+	fmt.Println("\n" + repeat("-", 60))
+	fmt.Printf("💡 N=1이면 모든 트랜잭션이 같은 행의 잠금을 기다리므로 한 줄로 실행됩니다.\n")
+	fmt.Printf("   서로 다른 행을 잠그는 트랜잭션은 동시에 실행되므로 N이 커질수록 처리량이 늘어납니다.\n")
+	fmt.Printf("   → 잠금 핫스팟(모두가 노리는 한 행)을 줄이는 것이 동시성 확보의 핵심입니다.\n")
+	fmt.Println(repeat("=", 60))
+}
@@ -0,0 +1,136 @@
+package solution
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"lost-update-demo/problem"
+	"lost-update-demo/setup"
+	"retry"
+)
+
+// DeductStockWithRetry는 REPEATABLE READ 재고 차감을 40001 에러가 나면 처음부터 다시 실행합니다.
+//
+// 작동 원리:
+// 1. REPEATABLE READ는 Lost Update 대신 나중 TX를 40001로 실패시킴 (PART 2)
+// 2. 실패한 TX를 새 스냅샷으로 다시 시작하면 먼저 커밋된 차감을 보고 다시 계산
+// 3. 지수 백오프 + 지터로 충돌한 TX들이 다시 동시에 몰리지 않게 함
+// 4. 재시도를 포함한 전체 시간이 예산(policy.Budget)을 넘으면 포기 (retry.ErrBudgetExceeded)
+//
+// 반환값은 재시도 횟수입니다.
+func DeductStockWithRetry(db *sql.DB, policy retry.Policy, productID int, quantity int) (int, error) {
+	return retry.Do(context.Background(), policy, func(ctx context.Context) error {
+		return problem.DeductStockWithRepeatableRead(ctx, db, productID, quantity)
+	})
+}
+
+// RunRetryDemo는 REPEATABLE READ + 재시도로 모든 차감이 성공함을 보여줍니다.
+// 재시도 횟수, 백오프, 시간 예산은 policy를 따릅니다.
+func RunRetryDemo(db *sql.DB, policy retry.Policy) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("🔁 REPEATABLE READ + 재시도 해결책")
+	fmt.Println(repeat("=", 60))
+
+	// 초기 재고 설정
+	if err := setup.SeedProducts(db, setup.ProductCount, 100); err != nil {
+		fmt.Printf("초기 재고 설정 실패: %v\n", err)
+		return
+	}
+
+	var initialStock int
+	db.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&initialStock)
+	fmt.Printf("\n📦 초기 재고: %d개\n", initialStock)
+	fmt.Printf("🔄 10개의 고루틴이 각각 10개씩 차감 시도\n")
+	fmt.Printf("📊 예상 최종 재고: %d - (10 × 10) = 0개\n", initialStock)
+	fmt.Printf("🔁 40001 에러 시 최대 %d번 재시도 (백오프 %v~%v, 차감당 시간 예산 %v)\n\n",
+		policy.MaxRetries, policy.BaseDelay, policy.MaxDelay, budgetString(policy.Budget))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var successCount, failCount, budgetCount, totalRetries int
+	var maxElapsed time.Duration
+	retryCounts := make(map[int]int) // 재시도 횟수 → 차감 건수
+	startTime := time.Now()
+
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(num int) {
+			defer wg.Done()
+			opStart := time.Now()
+			retries, err := DeductStockWithRetry(db, policy, 1, 10)
+			opElapsed := time.Since(opStart)
+			mu.Lock()
+			defer mu.Unlock()
+			totalRetries += retries
+			retryCounts[retries]++
+			if opElapsed > maxElapsed {
+				maxElapsed = opElapsed
+			}
+			if errors.Is(err, retry.ErrBudgetExceeded) {
+				failCount++
+				budgetCount++
+				fmt.Printf("  [고루틴 %2d] ⏰ 시간 예산 초과로 포기 (재시도 %d번, %v)\n", num, retries, opElapsed.Round(time.Millisecond))
+			} else if err != nil {
+				failCount++
+				fmt.Printf("  [고루틴 %2d] ❌ %d번 재시도 후 실패: %v\n", num, retries, err)
+			} else {
+				successCount++
+				fmt.Printf("  [고루틴 %2d] ✅ 10개 차감 완료 (재시도 %d번)\n", num, retries)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	elapsed := time.Since(startTime)
+
+	var finalStock int
+	db.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&finalStock)
+
+	fmt.Println("\n" + repeat("-", 60))
+	fmt.Printf("⏱️  실행 시간: %v\n", elapsed)
+	fmt.Printf("📊 성공: %d건, 실패: %d건 (시간 예산 초과 %d건), 총 재시도: %d번\n", successCount, failCount, budgetCount, totalRetries)
+	fmt.Printf("⏱️  차감 1건의 최대 소요 시간: %v (예산 %v)\n", maxElapsed.Round(time.Millisecond), budgetString(policy.Budget))
+	fmt.Printf("📊 최종 재고: %d개\n", finalStock)
+	printRetryDistribution(retryCounts)
+
+	if finalStock == 0 && failCount == 0 {
+		fmt.Printf("\n🎉 정확함! 잠금 없이도 모든 차감이 반영되었습니다.\n")
+		fmt.Printf("💡 대신 충돌한 만큼 트랜잭션을 다시 실행했습니다 (총 %d번).\n", totalRetries)
+		fmt.Printf("   → 경합이 심할수록 재시도 비용이 커지므로, 핫스팟 행에는 SELECT FOR UPDATE가 유리합니다.\n")
+	} else {
+		fmt.Printf("\n⚠️  예상과 다른 결과입니다. (예상: 0, 실제: %d, 실패: %d건)\n", finalStock, failCount)
+		if budgetCount > 0 {
+			fmt.Printf("   → %d건은 시간 예산 안에 충돌을 벗어나지 못해 포기했습니다. 무한히 재시도하는 대신 꼬리 지연시간을 예산으로 묶은 결과입니다.\n", budgetCount)
+		}
+	}
+
+	fmt.Println(repeat("=", 60))
+}
+
+// printRetryDistribution은 재시도 횟수별 차감 건수를 막대그래프로 출력합니다.
+func printRetryDistribution(counts map[int]int) {
+	retries := make([]int, 0, len(counts))
+	for r := range counts {
+		retries = append(retries, r)
+	}
+	sort.Ints(retries)
+
+	fmt.Println("\n📊 재시도 횟수 분포:")
+	for _, r := range retries {
+		fmt.Printf("   %2d번: %-10s %d건\n", r, strings.Repeat("█", counts[r]), counts[r])
+	}
+}
+
+// budgetString은 시간 예산을 출력용 문자열로 바꿉니다 (0 = 무제한).
+func budgetString(budget time.Duration) string {
+	if budget <= 0 {
+		return "무제한"
+	}
+	return budget.String()
+}
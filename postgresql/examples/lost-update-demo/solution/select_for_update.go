@@ -5,8 +5,14 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"lost-update-demo/setup"
 )
 
+// ContentionDelay는 잠금을 쥔 채 SELECT와 UPDATE 사이에 두는 대기 시간입니다.
+// 잠금 보유 시간이 길어질수록 대기하는 트랜잭션의 잠금 대기 시간도 비례해서 늘어납니다.
+var ContentionDelay = 10 * time.Millisecond
+
 // DeductStockWithLock은 SELECT FOR UPDATE를 사용하여 Lost Update를 방지하는 재고 차감 함수입니다.
 //
 // 작동 원리:
@@ -47,7 +53,7 @@ func DeductStockWithLock(db *sql.DB, productID int, quantity int) error {
 
 	// 3단계: 경합 상황 시뮬레이션
 	// 다른 트랜잭션들은 이 행에 대한 잠금을 기다리는 중...
-	time.Sleep(10 * time.Millisecond)
+	time.Sleep(ContentionDelay)
 
 	// 4단계: 재고 차감 (안전하게!)
 	// ✅ 다른 트랜잭션이 중간에 stock을 변경할 수 없으므로 안전
@@ -66,17 +72,16 @@ func DeductStockWithLock(db *sql.DB, productID int, quantity int) error {
 	return nil
 }
 
-// RunSolutionDemo는 SELECT FOR UPDATE를 사용한 해결책을 데모합니다.
-func RunSolutionDemo(db *sql.DB) {
+// RunSolutionDemo는 SELECT FOR UPDATE를 사용한 해결책을 데모하고 결과를 반환합니다.
+func RunSolutionDemo(db *sql.DB) (setup.DemoResult, error) {
 	fmt.Println("\n" + repeat("=", 60))
 	fmt.Println("✅ SELECT FOR UPDATE 해결책")
 	fmt.Println(repeat("=", 60))
 
 	// 초기 재고 설정
-	_, err := db.Exec("UPDATE products SET stock = 100 WHERE id = 1")
-	if err != nil {
+	if err := setup.SeedProducts(db, setup.ProductCount, 100); err != nil {
 		fmt.Printf("초기 재고 설정 실패: %v\n", err)
-		return
+		return setup.DemoResult{}, err
 	}
 
 	var initialStock int
@@ -84,7 +89,7 @@ func RunSolutionDemo(db *sql.DB) {
 	fmt.Printf("\n📦 초기 재고: %d개\n", initialStock)
 	fmt.Printf("🔄 10개의 고루틴이 각각 10개씩 차감 시도\n")
 	fmt.Printf("📊 예상 최종 재고: %d - (10 × 10) = 0개\n", initialStock)
-	fmt.Printf("🔒 SELECT FOR UPDATE로 행 잠금 사용\n\n")
+	fmt.Printf("🔒 SELECT FOR UPDATE로 행 잠금 사용 (잠금 보유 중 대기: %v)\n\n", ContentionDelay)
 
 	// 동시성 테스트
 	var wg sync.WaitGroup
@@ -117,7 +122,7 @@ func RunSolutionDemo(db *sql.DB) {
 	var finalStock int
 	db.QueryRow("SELECT stock FROM products WHERE id = 1").Scan(&finalStock)
 
-	fmt.Printf("\n" + repeat("-", 60) + "\n")
+	fmt.Println("\n" + repeat("-", 60))
 	fmt.Printf("⏱️  실행 시간: %v\n", elapsed)
 	fmt.Printf("📊 성공: %d건, 실패: %d건\n", successCount, failCount)
 	fmt.Printf("📊 최종 재고: %d개\n", finalStock)
@@ -130,6 +135,8 @@ func RunSolutionDemo(db *sql.DB) {
 	}
 
 	fmt.Println(repeat("=", 60))
+
+	return setup.NewDemoResult("SELECT FOR UPDATE", initialStock, finalStock, 10, successCount, 10, elapsed), nil
 }
 
 // repeat는 문자열을 n번 반복합니다 (헬퍼 함수)
@@ -0,0 +1,123 @@
+// Package retry는 PostgreSQL이 다시 실행하면 성공할 수 있다고 알려 주는 에러(40001, 40P01)를 재시도하는 헬퍼입니다.
+// 예제 모듈들(lost-update-demo, deadlock-demo)이 go.mod의 replace로 함께 씁니다.
+// load-test의 부하 생성기는 40001을 재시도하지 않고 실패로 집계하므로(격리 수준별 충돌 비율이 측정 대상) 이 패키지를 쓰지 않습니다.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgreSQL이 "다시 실행하면 성공할 수 있다"고 알려 주는 에러 코드입니다.
+const (
+	SerializationFailure = "40001" // serialization_failure
+	DeadlockDetected     = "40P01" // deadlock_detected
+)
+
+// ErrBudgetExceeded는 재시도를 포함한 전체 시간이 Policy.Budget을 넘어 포기했음을 나타냅니다.
+// Do가 반환하는 에러는 이 에러와 마지막 시도의 에러를 함께 감싸므로 errors.Is로 둘 다 확인할 수 있습니다.
+var ErrBudgetExceeded = errors.New("retry budget exceeded")
+
+// Policy는 재시도 대상 에러 코드와 백오프 설정입니다.
+type Policy struct {
+	MaxRetries int           // 최대 재시도 횟수 (최초 시도 제외)
+	BaseDelay  time.Duration // 첫 재시도 전 대기 시간 (재시도마다 2배)
+	MaxDelay   time.Duration // 대기 시간 상한
+	Budget     time.Duration // 최초 시도부터 모든 재시도까지의 전체 시간 상한 (0 = 무제한)
+	Codes      []string      // 재시도할 PostgreSQL 에러 코드
+}
+
+// DefaultPolicy는 직렬화 실패와 데드락을 2초 안에서 최대 10번까지 재시도합니다.
+var DefaultPolicy = Policy{
+	MaxRetries: 10,
+	BaseDelay:  5 * time.Millisecond,
+	MaxDelay:   200 * time.Millisecond,
+	Budget:     2 * time.Second,
+	Codes:      []string{SerializationFailure, DeadlockDetected},
+}
+
+// Do는 fn이 성공하거나, 재시도 대상이 아닌 에러를 반환하거나, 재시도 횟수나 시간 예산을 다 쓸 때까지 fn을 실행합니다.
+// 재시도 사이에는 지수 백오프에 지터를 더해 대기하므로, 같은 행에서 충돌한 트랜잭션들이 다시 동시에 몰리지 않습니다.
+// fn은 매번 트랜잭션을 처음부터 다시 시작해야 하며, 전달받은 ctx를 쿼리에 넘겨야 실행 중인 시도도 예산에 맞춰 취소됩니다.
+// Budget이 있으면 ctx에 전체 작업의 데드라인을 걸고, 예산을 넘기면(또는 다음 대기가 예산을 넘기면)
+// ErrBudgetExceeded로 포기합니다. 반환값은 재시도 횟수와 마지막 에러입니다.
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error) (int, error) {
+	opCtx := ctx
+	var deadline time.Time
+	if policy.Budget > 0 {
+		deadline = time.Now().Add(policy.Budget)
+		var cancel context.CancelFunc
+		opCtx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	retries := 0
+	for {
+		err := fn(opCtx)
+		if err == nil {
+			return retries, nil
+		}
+		// 호출자가 아니라 예산 때문에 취소된 시도는 데드라인 실패
+		if ctx.Err() == nil && opCtx.Err() != nil {
+			return retries, budgetError(retries, err)
+		}
+		if !policy.retryable(err) || retries >= policy.MaxRetries {
+			return retries, err
+		}
+
+		delay := policy.backoff(retries)
+		if !deadline.IsZero() && time.Now().Add(delay).After(deadline) {
+			return retries, budgetError(retries, err)
+		}
+		select {
+		case <-time.After(delay):
+		case <-opCtx.Done():
+			if ctx.Err() == nil {
+				return retries, budgetError(retries, err)
+			}
+			return retries, err
+		}
+		retries++
+	}
+}
+
+// budgetError는 예산을 넘겨 포기했음을 나타내는 에러를 만듭니다.
+func budgetError(retries int, err error) error {
+	return fmt.Errorf("%w after %d retries: %w", ErrBudgetExceeded, retries, err)
+}
+
+// retryable은 err가 Codes에 포함된 PostgreSQL 에러인지 확인합니다.
+func (p Policy) retryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	for _, code := range p.Codes {
+		if string(pqErr.Code) == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff는 attempt번째 재시도 전 대기 시간을 반환합니다.
+// BaseDelay × 2^attempt (MaxDelay 상한)의 절반에서 전체 사이를 무작위로 고릅니다.
+func (p Policy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 0; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
@@ -0,0 +1,138 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// testPolicy는 테스트가 빨리 끝나도록 대기 시간을 줄인 정책입니다.
+var testPolicy = Policy{
+	MaxRetries: 10,
+	BaseDelay:  time.Millisecond,
+	MaxDelay:   5 * time.Millisecond,
+	Codes:      []string{SerializationFailure, DeadlockDetected},
+}
+
+var errSerialization = &pq.Error{Code: SerializationFailure}
+
+func TestDoSucceedsAfterRetry(t *testing.T) {
+	calls := 0
+	retries, err := Do(context.Background(), testPolicy, func(ctx context.Context) error {
+		calls++
+		if calls <= 2 {
+			return errSerialization
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if retries != 2 || calls != 3 {
+		t.Fatalf("retries = %d, calls = %d, want 2 and 3", retries, calls)
+	}
+}
+
+func TestDoDoesNotRetryOtherErrors(t *testing.T) {
+	want := &pq.Error{Code: "23505"} // unique_violation
+	calls := 0
+	retries, err := Do(context.Background(), testPolicy, func(ctx context.Context) error {
+		calls++
+		return want
+	})
+	if err != want || retries != 0 || calls != 1 {
+		t.Fatalf("Do = (%d, %v) after %d calls, want (0, %v) after 1 call", retries, err, calls, want)
+	}
+}
+
+func TestDoMaxRetries(t *testing.T) {
+	policy := testPolicy
+	policy.MaxRetries = 3
+	calls := 0
+	retries, err := Do(context.Background(), policy, func(ctx context.Context) error {
+		calls++
+		return errSerialization
+	})
+	if err != errSerialization || retries != 3 || calls != 4 {
+		t.Fatalf("Do = (%d, %v) after %d calls, want (3, %v) after 4 calls", retries, err, calls, errSerialization)
+	}
+}
+
+func TestDoBudgetExceeded(t *testing.T) {
+	policy := testPolicy
+	policy.MaxRetries = 1000
+	policy.Budget = 50 * time.Millisecond
+
+	start := time.Now()
+	retries, err := Do(context.Background(), policy, func(ctx context.Context) error {
+		return errSerialization
+	})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("err = %v, want ErrBudgetExceeded", err)
+	}
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != SerializationFailure {
+		t.Fatalf("err = %v, want it to wrap the last 40001 error", err)
+	}
+	if retries == 0 || retries >= policy.MaxRetries {
+		t.Fatalf("retries = %d, want some retries before the budget ran out", retries)
+	}
+	if elapsed > policy.Budget+50*time.Millisecond {
+		t.Fatalf("Do took %v, want about the %v budget", elapsed, policy.Budget)
+	}
+}
+
+// 예산이 끝나면 fn에 넘긴 ctx도 취소되어 실행 중인 시도가 중단되는지 확인합니다.
+func TestDoBudgetCancelsAttempt(t *testing.T) {
+	policy := testPolicy
+	policy.Budget = 20 * time.Millisecond
+
+	retries, err := Do(context.Background(), policy, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, ErrBudgetExceeded) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want ErrBudgetExceeded wrapping context.DeadlineExceeded", err)
+	}
+	if retries != 0 {
+		t.Fatalf("retries = %d, want 0", retries)
+	}
+}
+
+func TestDoContextCanceled(t *testing.T) {
+	policy := testPolicy
+	policy.BaseDelay = time.Hour
+	policy.MaxDelay = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	var retries int
+	var err error
+	go func() {
+		defer close(done)
+		retries, err = Do(ctx, policy, func(ctx context.Context) error {
+			return errSerialization
+		})
+	}()
+
+	// 첫 시도가 실패해 백오프 대기 중일 때 취소
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Do did not return after the context was canceled")
+	}
+
+	if errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("err = %v, want the last error rather than a budget error", err)
+	}
+	if err != errSerialization || retries != 0 {
+		t.Fatalf("Do = (%d, %v), want (0, %v)", retries, err, errSerialization)
+	}
+}
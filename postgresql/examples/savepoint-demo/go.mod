@@ -0,0 +1,5 @@
+module savepoint-demo
+
+go 1.25.5
+
+require github.com/lib/pq v1.10.9
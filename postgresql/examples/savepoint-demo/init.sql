@@ -0,0 +1,15 @@
+-- Savepoint Demo Database 초기화 스크립트
+-- load-test/write-server의 logs 테이블과 동일한 스키마를 사용합니다.
+
+-- logs 테이블 생성
+CREATE TABLE logs (
+    id BIGSERIAL PRIMARY KEY,
+    timestamp TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    level VARCHAR(10) NOT NULL,
+    service VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    metadata JSONB
+);
+
+-- 테이블 정보 출력 (디버깅용)
+SELECT 'Logs table initialized successfully' AS status;
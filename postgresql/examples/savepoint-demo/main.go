@@ -0,0 +1,107 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"savepoint-demo/problem"
+	"savepoint-demo/solution"
+)
+
+const (
+	host     = "localhost"
+	port     = 5435
+	user     = "postgres"
+	password = "postgres"
+	dbname   = "logs"
+)
+
+func main() {
+	fmt.Println("\n" + repeat("=", 70))
+	fmt.Println("🚀 PostgreSQL SAVEPOINT 데모")
+	fmt.Println(repeat("=", 70))
+
+	// PostgreSQL 연결
+	db := connectDB()
+	defer db.Close()
+
+	// 연결 확인
+	if err := db.Ping(); err != nil {
+		log.Fatalf("❌ 데이터베이스 연결 실패: %v\n", err)
+	}
+	fmt.Println("✅ PostgreSQL 연결 성공")
+
+	// 1. 평면 트랜잭션의 문제 재현
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 1: 불량 행 하나가 배치 전체를 중단시키는 문제")
+	fmt.Println(repeat("*", 70))
+	problem.RunProblemDemo(db)
+
+	// 사용자가 결과를 확인할 수 있도록 잠시 대기
+	fmt.Println("\n⏳ 3초 후 해결책 데모를 시작합니다...")
+	time.Sleep(3 * time.Second)
+
+	// 2. SAVEPOINT 해결책
+	fmt.Println("\n" + repeat("*", 70))
+	fmt.Println("PART 2: SAVEPOINT를 이용한 부분 롤백")
+	fmt.Println(repeat("*", 70))
+	solution.RunSolutionDemo(db)
+
+	// 최종 요약
+	fmt.Println("\n" + repeat("=", 70))
+	fmt.Println("📚 핵심 요약")
+	fmt.Println(repeat("=", 70))
+	fmt.Println(`
+1️⃣  PostgreSQL 트랜잭션의 에러 처리
+   - 트랜잭션 안에서 에러가 한 번 발생하면 트랜잭션 전체가 aborted 상태
+   - 이후 모든 문장은 25P02 (current transaction is aborted) 에러
+   - COMMIT을 보내도 ROLLBACK으로 처리됨 → 정상 행까지 모두 손실
+
+2️⃣  SAVEPOINT (서브트랜잭션)
+   - SAVEPOINT name: 트랜잭션 내부에 되돌릴 지점을 표시
+   - ROLLBACK TO SAVEPOINT name: 그 지점 이후 변경만 취소, 트랜잭션은 계속 사용 가능
+   - RELEASE SAVEPOINT name: 세이브포인트 제거 (변경은 유지)
+
+3️⃣  write-server 배치 INSERT에의 적용
+   - 멀티 VALUES INSERT는 한 행만 잘못돼도 문장 전체가 실패
+   - 행별 SAVEPOINT로 불량 행만 건너뛰고 나머지를 하나의 트랜잭션으로 커밋 가능
+
+4️⃣  주의사항
+   ✅ 장점: 부분 실패를 허용하면서도 원자성 유지
+   ⚠️  단점: 행마다 왕복 추가 → 처리량 감소, 서브트랜잭션 오버헤드
+   💡 팁: 먼저 멀티 VALUES로 시도하고, 실패한 배치만 SAVEPOINT 방식으로 재처리`)
+	fmt.Println(repeat("=", 70))
+	fmt.Println("✨ 데모 종료")
+	fmt.Println(repeat("=", 70) + "\n")
+}
+
+// connectDB는 PostgreSQL 데이터베이스에 연결합니다.
+func connectDB() *sql.DB {
+	psqlInfo := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		host, port, user, password, dbname)
+
+	db, err := sql.Open("postgres", psqlInfo)
+	if err != nil {
+		log.Fatalf("❌ 데이터베이스 연결 실패: %v\n", err)
+	}
+
+	// 연결 풀 설정
+	db.SetMaxOpenConns(25)                 // 최대 연결 수
+	db.SetMaxIdleConns(10)                 // 유휴 연결 수
+	db.SetConnMaxLifetime(5 * time.Minute) // 연결 최대 수명
+
+	return db
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}
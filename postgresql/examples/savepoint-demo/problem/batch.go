@@ -0,0 +1,143 @@
+package problem
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// inFailedSQLTransaction은 PostgreSQL의 in_failed_sql_transaction 에러 코드입니다.
+const inFailedSQLTransaction = "25P02"
+
+// LogEntry는 write-server가 배치로 INSERT하는 로그 한 건입니다.
+type LogEntry struct {
+	Level   string
+	Service string
+	Message string
+}
+
+// SampleBatch는 데모에 사용할 배치를 반환합니다.
+// 3번째 항목의 level은 VARCHAR(10)을 초과하여 INSERT가 실패합니다.
+func SampleBatch() []LogEntry {
+	return []LogEntry{
+		{Level: "INFO", Service: "api", Message: "request handled"},
+		{Level: "WARN", Service: "auth", Message: "token expiring soon"},
+		{Level: "CRITICAL_FAILURE", Service: "worker", Message: "bad row: level too long"},
+		{Level: "ERROR", Service: "scheduler", Message: "job failed"},
+		{Level: "DEBUG", Service: "api", Message: "cache miss"},
+	}
+}
+
+// InsertBatch는 하나의 트랜잭션에서 배치를 한 행씩 INSERT합니다.
+//
+// ⚠️ 문제점: PostgreSQL은 트랜잭션 안에서 에러가 한 번 발생하면
+// 그 트랜잭션 전체를 "aborted" 상태로 만듭니다.
+// 1. 1~2번 행 INSERT 성공
+// 2. 3번 행 INSERT 실패 (22001: value too long)
+// 3. 이후 모든 문장은 25P02 (current transaction is aborted) 에러
+// 4. 결국 ROLLBACK만 가능 → 정상 행까지 모두 버려짐
+//
+// 반환값은 INSERT에 성공한 행 수와 행별 에러입니다.
+func InsertBatch(db *sql.DB, entries []LogEntry) (int, []error) {
+	errs := make([]error, len(entries))
+
+	tx, err := db.Begin()
+	if err != nil {
+		for i := range errs {
+			errs[i] = fmt.Errorf("트랜잭션 시작 실패: %w", err)
+		}
+		return 0, errs
+	}
+	defer tx.Rollback()
+
+	inserted := 0
+	for i, e := range entries {
+		_, err := tx.Exec(
+			"INSERT INTO logs (level, service, message) VALUES ($1, $2, $3)",
+			e.Level, e.Service, e.Message,
+		)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		inserted++
+	}
+
+	// 커밋 시도: 중간에 에러가 있었다면 PostgreSQL은 COMMIT을 ROLLBACK으로 처리
+	// (lib/pq는 pq.ErrInFailedTransaction을 반환)
+	if err := tx.Commit(); err != nil {
+		return 0, errs
+	}
+
+	return inserted, errs
+}
+
+// IsInFailedTransaction은 에러가 PostgreSQL 25P02(in_failed_sql_transaction)인지 확인합니다.
+func IsInFailedTransaction(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == inFailedSQLTransaction
+}
+
+// CountLogs는 현재 logs 테이블의 행 수를 반환합니다.
+func CountLogs(db *sql.DB) int {
+	var count int
+	db.QueryRow("SELECT COUNT(*) FROM logs").Scan(&count)
+	return count
+}
+
+// PrintResults는 행별 INSERT 결과를 출력합니다.
+func PrintResults(entries []LogEntry, errs []error) {
+	for i, e := range entries {
+		switch {
+		case errs[i] == nil:
+			fmt.Printf("  [행 %d] ✅ %-16s INSERT 성공\n", i+1, e.Level)
+		case IsInFailedTransaction(errs[i]):
+			fmt.Printf("  [행 %d] 🚫 %-16s 트랜잭션 중단 상태 (25P02)\n", i+1, e.Level)
+		default:
+			fmt.Printf("  [행 %d] ❌ %-16s 실패: %v\n", i+1, e.Level, errs[i])
+		}
+	}
+}
+
+// RunProblemDemo는 배치 중 한 행의 실패가 트랜잭션 전체를 중단시키는 것을 보여줍니다.
+func RunProblemDemo(db *sql.DB) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("❌ 평면 트랜잭션 배치 INSERT (SAVEPOINT 없음)")
+	fmt.Println(repeat("=", 60))
+
+	// 초기 상태 설정
+	if _, err := db.Exec("TRUNCATE logs"); err != nil {
+		fmt.Printf("초기화 실패: %v\n", err)
+		return
+	}
+
+	entries := SampleBatch()
+	before := CountLogs(db)
+	fmt.Printf("\n📦 INSERT 전 logs 행 수: %d\n", before)
+	fmt.Printf("🔄 %d건 배치 INSERT 시도 (3번째 행은 level 길이 초과)\n\n", len(entries))
+
+	committed, errs := InsertBatch(db, entries)
+	PrintResults(entries, errs)
+
+	after := CountLogs(db)
+	fmt.Println("\n" + repeat("-", 60))
+	fmt.Printf("📊 INSERT 후 logs 행 수: %d (커밋된 행: %d건)\n", after, committed)
+
+	if after == before {
+		fmt.Printf("\n🚨 정상 행 %d건까지 모두 사라졌습니다!\n", len(entries)-1)
+		fmt.Printf("💡 원인: 3번째 행의 에러 이후 트랜잭션이 aborted 상태가 되어\n")
+		fmt.Printf("   이후 INSERT는 모두 25P02로 거부되고, COMMIT은 ROLLBACK으로 처리되었습니다.\n")
+	}
+	fmt.Println(repeat("=", 60))
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}
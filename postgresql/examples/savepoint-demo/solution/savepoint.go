@@ -0,0 +1,132 @@
+package solution
+
+import (
+	"database/sql"
+	"fmt"
+
+	"savepoint-demo/problem"
+)
+
+// InsertBatchWithSavepoint는 행마다 SAVEPOINT를 만들어 실패한 행만 되돌리는 배치 INSERT 함수입니다.
+//
+// 작동 원리:
+// 1. 각 행 INSERT 전에 SAVEPOINT 생성
+// 2. INSERT 성공 → RELEASE SAVEPOINT (세이브포인트 제거, 변경은 유지)
+// 3. INSERT 실패 → ROLLBACK TO SAVEPOINT (해당 행만 취소, 트랜잭션은 정상 상태로 복구)
+// 4. 마지막에 COMMIT → 정상 행은 모두 하나의 트랜잭션으로 반영!
+//
+// 장점:
+// - 불량 행 하나 때문에 배치 전체를 버리지 않음
+// - 여전히 하나의 트랜잭션 (정상 행들은 원자적으로 커밋)
+//
+// 단점:
+// - 행마다 SAVEPOINT 왕복이 추가되어 처리량 감소
+// - 서브트랜잭션이 많아지면 PostgreSQL 내부 오버헤드 증가 (64개 초과 시 subxid 캐시 overflow)
+func InsertBatchWithSavepoint(db *sql.DB, entries []problem.LogEntry) (int, []error) {
+	errs := make([]error, len(entries))
+
+	tx, err := db.Begin()
+	if err != nil {
+		for i := range errs {
+			errs[i] = fmt.Errorf("트랜잭션 시작 실패: %w", err)
+		}
+		return 0, errs
+	}
+	defer tx.Rollback()
+
+	inserted := 0
+	for i, e := range entries {
+		// 1단계: 세이브포인트 생성
+		if _, err := tx.Exec("SAVEPOINT batch_row"); err != nil {
+			errs[i] = fmt.Errorf("세이브포인트 생성 실패: %w", err)
+			return 0, errs
+		}
+
+		// 2단계: 행 INSERT
+		_, err := tx.Exec(
+			"INSERT INTO logs (level, service, message) VALUES ($1, $2, $3)",
+			e.Level, e.Service, e.Message,
+		)
+		if err != nil {
+			// 3단계: 실패한 행만 되돌림
+			// ↩️ 트랜잭션이 aborted 상태에서 벗어나 다음 행을 계속 처리 가능
+			errs[i] = err
+			if _, err := tx.Exec("ROLLBACK TO SAVEPOINT batch_row"); err != nil {
+				errs[i] = fmt.Errorf("세이브포인트 롤백 실패: %w", err)
+				return 0, errs
+			}
+			continue
+		}
+
+		// 4단계: 성공한 행은 세이브포인트만 해제
+		if _, err := tx.Exec("RELEASE SAVEPOINT batch_row"); err != nil {
+			errs[i] = fmt.Errorf("세이브포인트 해제 실패: %w", err)
+			return 0, errs
+		}
+		inserted++
+	}
+
+	// 5단계: 커밋 (정상 행만 반영)
+	if err := tx.Commit(); err != nil {
+		return 0, errs
+	}
+
+	return inserted, errs
+}
+
+// RunSolutionDemo는 SAVEPOINT로 불량 행만 롤백하고 나머지는 커밋하는 것을 보여줍니다.
+func RunSolutionDemo(db *sql.DB) {
+	fmt.Println("\n" + repeat("=", 60))
+	fmt.Println("✅ SAVEPOINT를 이용한 부분 롤백")
+	fmt.Println(repeat("=", 60))
+
+	// 초기 상태 설정
+	if _, err := db.Exec("TRUNCATE logs"); err != nil {
+		fmt.Printf("초기화 실패: %v\n", err)
+		return
+	}
+
+	entries := problem.SampleBatch()
+	before := problem.CountLogs(db)
+	fmt.Printf("\n📦 INSERT 전 logs 행 수: %d\n", before)
+	fmt.Printf("🔄 %d건 배치 INSERT 시도 (3번째 행은 level 길이 초과)\n", len(entries))
+	fmt.Printf("🔖 행마다 SAVEPOINT 사용\n\n")
+
+	committed, errs := InsertBatchWithSavepoint(db, entries)
+	problem.PrintResults(entries, errs)
+
+	after := problem.CountLogs(db)
+	fmt.Println("\n" + repeat("-", 60))
+	fmt.Printf("📊 INSERT 후 logs 행 수: %d (커밋된 행: %d건)\n", after, committed)
+
+	if after-before == len(entries)-1 {
+		fmt.Printf("\n🎉 성공! 불량 행 1건만 롤백되고 정상 행 %d건은 커밋되었습니다.\n", after-before)
+		fmt.Printf("💡 ROLLBACK TO SAVEPOINT가 실패한 INSERT만 취소하고 트랜잭션을 정상 상태로 되돌렸습니다.\n")
+	} else {
+		fmt.Printf("\n⚠️  예상과 다른 결과입니다. (기대: %d건, 실제: %d건)\n", len(entries)-1, after-before)
+	}
+
+	// 커밋된 행 확인
+	rows, err := db.Query("SELECT id, level, service FROM logs ORDER BY id")
+	if err == nil {
+		defer rows.Close()
+		fmt.Println("\n📋 커밋된 행:")
+		for rows.Next() {
+			var id int64
+			var level, service string
+			if err := rows.Scan(&id, &level, &service); err == nil {
+				fmt.Printf("  id=%d level=%s service=%s\n", id, level, service)
+			}
+		}
+	}
+	fmt.Println(repeat("=", 60))
+}
+
+// repeat는 문자열을 n번 반복합니다.
+func repeat(s string, n int) string {
+	result := ""
+	for i := 0; i < n; i++ {
+		result += s
+	}
+	return result
+}